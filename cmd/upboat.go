@@ -1,18 +1,37 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/alexedwards/scs"
 	"github.com/go-chi/chi"
 	"github.com/godwhoa/upboat/pkg/api"
 	"github.com/godwhoa/upboat/pkg/api/middleware"
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/curation"
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/godwhoa/upboat/pkg/mail"
+	"github.com/godwhoa/upboat/pkg/moderation"
+	"github.com/godwhoa/upboat/pkg/notifications"
+	"github.com/godwhoa/upboat/pkg/passwords"
 	"github.com/godwhoa/upboat/pkg/postgres"
 	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/privacy"
+	"github.com/godwhoa/upboat/pkg/profiles"
+	"github.com/godwhoa/upboat/pkg/ranking"
+	"github.com/godwhoa/upboat/pkg/ratelimit"
+	"github.com/godwhoa/upboat/pkg/reports"
+	"github.com/godwhoa/upboat/pkg/search"
 	"github.com/godwhoa/upboat/pkg/users"
 	openzipkin "github.com/openzipkin/zipkin-go"
 	zhttp "github.com/openzipkin/zipkin-go/reporter/http"
@@ -21,6 +40,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func key() string {
@@ -30,6 +50,48 @@ func key() string {
 }
 
 func main() {
+	rankingOpts := ranking.Options{}
+	flag.StringVar(&rankingOpts.Algorithm, "ranking", "hn", "hot ranking algorithm, hn or reddit")
+	flag.Float64Var(&rankingOpts.Gravity, "gravity", 1.8, "gravity for hn ranking")
+	flag.DurationVar(&rankingOpts.Window, "ranking-window", 72*time.Hour, "how long posts keep getting re-ranked")
+	flag.DurationVar(&rankingOpts.Interval, "ranking-interval", time.Minute, "how often posts are re-ranked")
+	recount := flag.Bool("recount-votes", false, "repair vote counters from the votes tables and exit")
+	grant := flag.Int("grant-moderator", 0, "make the user with this id a global moderator and exit")
+	export := flag.Int("export-user", 0, "write the data of the user with this id to stdout as JSON and exit")
+	erase := flag.Int("erase-user", 0, "erase the user with this id and exit")
+	usersOpts := users.Options{}
+	flag.StringVar(&usersOpts.BaseURL, "base-url", "http://localhost:8080", "where the frontend is served, used for links in emails")
+	flag.DurationVar(&usersOpts.ResetTTL, "reset-ttl", users.DefaultResetTTL, "how long password reset links are valid")
+	flag.DurationVar(&usersOpts.VerifyTTL, "verify-ttl", users.DefaultVerifyTTL, "how long email verification links are valid")
+	flag.BoolVar(&usersOpts.RequireVerified, "require-verified", false, "only let users with a verified email post and vote")
+	usersOpts.Lockout = users.DefaultLockout
+	flag.IntVar(&usersOpts.Lockout.AccountThreshold, "lockout-account", users.DefaultLockout.AccountThreshold, "failed logins before an account is locked out")
+	flag.IntVar(&usersOpts.Lockout.IPThreshold, "lockout-ip", users.DefaultLockout.IPThreshold, "failed logins before an IP is locked out")
+	hasher := flag.String("password-hash", "bcrypt", "algorithm new password hashes use, bcrypt or argon2id; older hashes are upgraded on login")
+	bcryptCost := flag.Int("bcrypt-cost", bcrypt.DefaultCost, "bcrypt cost")
+	argonMemory := flag.Uint("argon2-memory", uint(passwords.DefaultArgon2id.Memory), "argon2id memory in KiB")
+	argonTime := flag.Uint("argon2-time", uint(passwords.DefaultArgon2id.Time), "argon2id passes over memory")
+	smtpOpts := mail.SMTPOptions{}
+	flag.StringVar(&smtpOpts.Addr, "smtp-addr", "", "smtp server to send emails through, emails are written to -mail-dir if unset")
+	flag.StringVar(&smtpOpts.User, "smtp-user", "", "smtp username")
+	flag.StringVar(&smtpOpts.Pass, "smtp-pass", "", "smtp password")
+	flag.StringVar(&smtpOpts.From, "mail-from", "upboat <noreply@localhost>", "sender of emails")
+	mailDir := flag.String("mail-dir", "mail", "directory emails are written to when -smtp-addr is unset")
+	limitStore := flag.String("ratelimit-store", "memory", "where rate limits are kept, memory or postgres for multiple instances")
+	eventsVia := flag.String("events", "memory", "how thread events reach subscribers, memory or postgres for multiple instances")
+	limits := map[string]*ratelimit.Policy{}
+	for group, policy := range ratelimit.DefaultPolicies {
+		policy := policy
+		limits[group] = &policy
+		flag.Var(limits[group], "ratelimit-"+group, "rate limit for "+group+" as burst/interval")
+	}
+	flag.Parse()
+	// a threshold of 0 would lock out every login
+	if usersOpts.Lockout.AccountThreshold < 1 || usersOpts.Lockout.IPThreshold < 1 {
+		fmt.Fprintln(os.Stderr, "-lockout-account and -lockout-ip must be at least 1")
+		os.Exit(2)
+	}
+
 	localEndpoint, _ := openzipkin.NewEndpoint("upboat", "192.168.1.5:5454")
 
 	reporter := zhttp.NewReporter("http://localhost:9411/api/v2/spans")
@@ -48,47 +110,314 @@ func main() {
 
 	// setup platform dependencies
 	sessionManager := scs.NewCookieManager(key())
-	repos, err := postgres.NewFromOptions(postgres.Options{
+	pgOpts := postgres.Options{
 		Host:   "localhost",
 		DBName: "upboat",
 		Port:   5432,
 		User:   "postgres",
 		Pass:   "bingbong",
-	})
+	}
+	repos, err := postgres.NewFromOptions(pgOpts, rankingOpts)
 	if err != nil {
 		log.Fatal("postgres.NewFromOptions", zap.Error(err))
 	}
+	if *recount {
+		nposts, ncomments, err := postgres.RecountVotes(context.Background(), repos.DB)
+		if err != nil {
+			log.Fatal("postgres.RecountVotes", zap.Error(err))
+		}
+		log.Info("Recounted votes", zap.Int64("posts", nposts), zap.Int64("comments", ncomments))
+		return
+	}
+	if *grant != 0 {
+		err := repos.ModerationRepo.Act(context.Background(), &moderation.Entry{
+			Action:   moderation.AddModerator,
+			TargetID: *grant,
+			Reason:   "granted from the command line",
+		})
+		if err != nil {
+			log.Fatal("moderation.Repository.Act", zap.Error(err))
+		}
+		log.Info("Granted global moderator", zap.Int("user_id", *grant))
+		return
+	}
+	if *export != 0 {
+		archive, err := repos.PrivacyRepo.Export(context.Background(), *export)
+		if err != nil {
+			log.Fatal("privacy.Repository.Export", zap.Error(err))
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(archive); err != nil {
+			log.Fatal("json.Encode", zap.Error(err))
+		}
+		return
+	}
+	if *erase != 0 {
+		if err := repos.PrivacyRepo.Erase(context.Background(), *erase); err != nil {
+			log.Fatal("privacy.Repository.Erase", zap.Error(err))
+		}
+		log.Info("Erased user", zap.Int("user_id", *erase))
+		return
+	}
+	ranker, err := ranking.NewRanker(rankingOpts)
+	if err != nil {
+		log.Fatal("ranking.NewRanker", zap.Error(err))
+	}
+	var mailer mail.Mailer
+	if smtpOpts.Addr != "" {
+		mailer = mail.NewSMTP(smtpOpts)
+	} else {
+		mailer, err = mail.NewFile(*mailDir, smtpOpts.From)
+		if err != nil {
+			log.Fatal("mail.NewFile", zap.Error(err))
+		}
+	}
+	policies := map[string]ratelimit.Policy{}
+	for group, policy := range limits {
+		policies[group] = *policy
+	}
+	var store ratelimit.Store
+	switch *limitStore {
+	case "memory":
+		store = ratelimit.NewMemory()
+	case "postgres":
+		store = postgres.NewRateLimitStore(repos.DB)
+	default:
+		log.Fatal("Unknown -ratelimit-store, must be memory or postgres", zap.String("ratelimit-store", *limitStore))
+	}
+	limiter := ratelimit.New(store, policies)
+	go ratelimit.Schedule(context.Background(), limiter, 10*time.Minute)
+	// thread events always reach subscribers through the hub, with postgres they take a detour through NOTIFY
+	// so subscribers on other instances get them too
+	hub := events.NewHub()
+	var publisher events.Publisher
+	switch *eventsVia {
+	case "memory":
+		publisher = hub
+	case "postgres":
+		publisher = postgres.NewEventPublisher(repos.DB)
+		go func() {
+			err := postgres.ListenEvents(context.Background(), pgOpts.ConnectionInfo(), hub, func(err error) {
+				log.Error("Error from postgres.ListenEvents()", zap.Error(err))
+			})
+			if err != nil {
+				log.Fatal("postgres.ListenEvents", zap.Error(err))
+			}
+		}()
+	default:
+		log.Fatal("Unknown -events, must be memory or postgres", zap.String("events", *eventsVia))
+	}
+	publisher = events.Logging(publisher, log)
+	switch *hasher {
+	case "bcrypt":
+		usersOpts.Hasher = passwords.Bcrypt{Cost: *bcryptCost}
+	case "argon2id":
+		argon := passwords.DefaultArgon2id
+		argon.Memory, argon.Time = uint32(*argonMemory), uint32(*argonTime)
+		usersOpts.Hasher = argon
+	default:
+		log.Fatal("Unknown -password-hash, must be bcrypt or argon2id", zap.String("password-hash", *hasher))
+	}
+	usersOpts.OnError = func(err error) {
+		log.Error("Error from users.Service that wasn't returned", zap.Error(err))
+	}
+
 	// setup services
-	us := users.NewService(repos.UserRepo)
+	us := users.NewService(repos.UserRepo, mailer, usersOpts)
 	us = users.Chain(us, users.Logging(log), users.Tracing)
-	ps := posts.NewService(repos.PostRepo)
-	ps = posts.Chain(ps, posts.Logging(log), posts.Tracing)
+	pfs := profiles.NewService(repos.ProfileRepo)
+	pfs = profiles.Chain(pfs, profiles.Logging(log), profiles.Tracing)
+	rs := ranking.NewService(repos.RankingRepo, ranker, rankingOpts.Window)
+	rs = ranking.Chain(rs, ranking.Logging(log), ranking.Tracing)
+	// hn ranks aren't stored, listings rank posts themselves
+	var rerank posts.Middleware = func(service posts.Service) posts.Service { return service }
+	if rankingOpts.Stored() {
+		rerank = ranking.Rerank(rs)
+		go ranking.Schedule(context.Background(), rs, rankingOpts.Interval)
+	}
+	ms := communities.NewService(repos.CommunityRepo)
+	ms = communities.Chain(ms, communities.Logging(log), communities.Tracing)
+	ns := notifications.NewService(repos.NotificationRepo)
+	ns = notifications.Chain(ns, notifications.Logging(log), notifications.Tracing)
+	ps := posts.NewService(repos.PostRepo, publisher)
+	ps = posts.Chain(ps, posts.Logging(log), posts.Tracing, communities.Membership(ms), rerank, notifications.OnPost(ns, log))
+	cs := comments.NewService(repos.CommentRepo, publisher)
+	cs = comments.Chain(cs, comments.Logging(log), comments.Tracing, notifications.OnComment(ns, log))
+	mods := moderation.NewService(repos.ModerationRepo, publisher)
+	mods = moderation.Chain(mods, moderation.Logging(log), moderation.Tracing)
+	reps := reports.NewService(repos.ReportRepo, repos.ModerationRepo)
+	reps = reports.Chain(reps, reports.Logging(log), reports.Tracing)
+	ts := apitokens.NewService(repos.APITokenRepo)
+	ts = apitokens.Chain(ts, apitokens.Logging(log), apitokens.Tracing)
+	prs := privacy.NewService(repos.PrivacyRepo, us)
+	prs = privacy.Chain(prs, privacy.Logging(log), privacy.Tracing)
+	cus := curation.NewService(repos.CurationRepo)
+	cus = curation.Chain(cus, curation.Logging(log), curation.Tracing)
+	ss := search.NewService(repos.SearchRepo)
+	ss = search.Chain(ss, search.Logging(log), search.Tracing)
 	usersapi := api.NewUsersAPI(us, sessionManager, log)
+	profilesapi := api.NewProfilesAPI(pfs, log)
 	postsapi := api.NewPostsAPI(ps, log)
+	commentsapi := api.NewCommentsAPI(cs, log)
+	eventsapi := api.NewEventsAPI(ps, hub, log)
+	searchapi := api.NewSearchAPI(ss, log)
+	markdownapi := api.NewMarkdownAPI(log)
+	tokensapi := api.NewAPITokensAPI(ts, log)
+	notificationsapi := api.NewNotificationsAPI(ns, log)
+	curationapi := api.NewCurationAPI(cus, log)
+	privacyapi := api.NewPrivacyAPI(prs, sessionManager, log)
+	communitiesapi := api.NewCommunitiesAPI(ms, ps, log)
+	modapi := api.NewModerationAPI(mods, log)
+	reportsapi := api.NewReportsAPI(reps, log)
+	auth := middleware.Auth(sessionManager, us, ts)
+	viewer := middleware.Viewer(sessionManager, us, ts)
+	verified := middleware.Verified(us)
+	read := middleware.RequireScope(apitokens.ScopeRead)
+	write := middleware.RequireScope(apitokens.ScopePost)
+	vote := middleware.RequireScope(apitokens.ScopeVote)
+	limit := func(group string) func(http.Handler) http.Handler {
+		return middleware.RateLimit(limiter, group, log)
+	}
 	// setup handlers
 	r := chi.NewRouter()
 	r.Route("/v1/api/", func(r chi.Router) {
 		r.Use(sessionManager.Use)
 		r.Route("/users", func(r chi.Router) {
-			r.Post("/", usersapi.Register)
-			r.Post("/login", usersapi.Login)
+			r.With(limit(ratelimit.GroupRegister)).Post("/", usersapi.Register)
+			r.With(limit(ratelimit.GroupLogin)).Post("/login", usersapi.Login)
+			r.With(limit(ratelimit.GroupLogin)).Post("/login/2fa", usersapi.LoginTwoFactor)
 			r.Post("/logout", usersapi.Logout)
+			r.With(limit(ratelimit.GroupMail)).Post("/password/reset", usersapi.RequestPasswordReset)
+			r.With(limit(ratelimit.GroupToken)).Post("/password/reset/confirm", usersapi.ResetPassword)
+			r.With(limit(ratelimit.GroupToken)).Post("/verify", usersapi.VerifyEmail)
+			r.With(limit(ratelimit.GroupToken)).Post("/unlock", usersapi.Unlock)
+			r.With(auth, middleware.SessionOnly, limit(ratelimit.GroupMail)).Post("/verify/resend", usersapi.ResendVerification)
+			r.Route("/{username}", func(r chi.Router) {
+				r.Use(middleware.Username)
+				r.Get("/", profilesapi.Get)
+				r.With(viewer).Get("/posts", profilesapi.Posts)
+				r.With(viewer).Get("/comments", profilesapi.Comments)
+				r.With(auth, write).Post("/block", curationapi.Block)
+				r.With(auth, write).Delete("/block", curationapi.Unblock)
+				r.With(auth, write).Post("/follow", profilesapi.Follow)
+				r.With(auth, write).Delete("/follow", profilesapi.Unfollow)
+			})
+		})
+		r.Route("/account", func(r chi.Router) {
+			r.Use(auth, middleware.SessionOnly)
+			r.Put("/password", usersapi.ChangePassword)
+			r.Put("/email", usersapi.ChangeEmail)
+			r.Delete("/", usersapi.Deactivate)
+			r.Get("/export", privacyapi.Export)
+			r.Post("/erase", privacyapi.Erase)
+			r.Route("/2fa", func(r chi.Router) {
+				r.Post("/", usersapi.EnrollTwoFactor)
+				r.Post("/confirm", usersapi.ConfirmTwoFactor)
+				r.Delete("/", usersapi.DisableTwoFactor)
+			})
+			r.Route("/tokens", func(r chi.Router) {
+				r.Post("/", tokensapi.Create)
+				r.Get("/", tokensapi.List)
+				r.With(middleware.TokenID).Delete("/{tokenID}", tokensapi.Revoke)
+			})
+			r.Route("/notifications", func(r chi.Router) {
+				r.Get("/", notificationsapi.Inbox)
+				r.Get("/unread", notificationsapi.Unread)
+				r.Post("/read", notificationsapi.MarkAllRead)
+				r.With(middleware.NotificationID).Post("/{notificationID}/read", notificationsapi.MarkRead)
+				r.Get("/mutes", notificationsapi.Mutes)
+				r.Put("/mutes/{kind}", notificationsapi.Mute)
+				r.Delete("/mutes/{kind}", notificationsapi.Unmute)
+			})
+			r.Get("/saved/posts", curationapi.SavedPosts)
+			r.Get("/saved/comments", curationapi.SavedComments)
+			r.Get("/blocked", curationapi.Blocked)
 		})
 		r.Route("/posts", func(r chi.Router) {
-			r.Use(middleware.Auth(sessionManager))
-			// CRUD posts
-			r.Post("/", postsapi.Create)
+			r.With(viewer).Get("/", postsapi.List)
+			r.Group(func(r chi.Router) {
+				r.Use(auth)
+				// CRUD posts
+				r.With(write, verified, limit(ratelimit.GroupPost)).Post("/", postsapi.Create)
+				r.With(read).Get("/following", postsapi.Following)
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.PostID)
+					r.With(read).Get("/{postID}", postsapi.Get)
+					r.With(write).Put("/{postID}", postsapi.Update)
+					r.With(write).Delete("/{postID}", postsapi.Delete)
+					// CRUD vote
+					r.With(read).Get("/{postID}/score", postsapi.Score)
+					r.With(read).Get("/{postID}/events", eventsapi.Stream)
+					r.With(vote, verified, limit(ratelimit.GroupVote)).Post("/{postID}/vote", postsapi.Vote)
+					r.With(vote, verified, limit(ratelimit.GroupVote)).Delete("/{postID}/vote", postsapi.Unvote)
+					r.With(write).Post("/{postID}/report", reportsapi.ReportPost)
+					r.With(write).Post("/{postID}/save", curationapi.SavePost)
+					r.With(write).Delete("/{postID}/save", curationapi.UnsavePost)
+					r.With(write).Post("/{postID}/hide", curationapi.Hide)
+					r.With(write).Delete("/{postID}/hide", curationapi.Unhide)
+					// CRUD comments
+					r.Route("/{postID}/comments", func(r chi.Router) {
+						r.With(write, verified, limit(ratelimit.GroupComment)).Post("/", commentsapi.Create)
+						r.With(read).Get("/", commentsapi.List)
+						r.With(read).Get("/tree", commentsapi.Tree)
+						r.Group(func(r chi.Router) {
+							r.Use(middleware.CommentID)
+							r.With(write).Delete("/{commentID}", commentsapi.Delete)
+							// CRUD vote
+							r.With(read).Get("/{commentID}/score", commentsapi.Score)
+							r.With(vote, verified, limit(ratelimit.GroupVote)).Post("/{commentID}/vote", commentsapi.Vote)
+							r.With(vote, verified, limit(ratelimit.GroupVote)).Delete("/{commentID}/vote", commentsapi.Unvote)
+							r.With(write).Post("/{commentID}/report", reportsapi.ReportComment)
+							r.With(write).Post("/{commentID}/save", curationapi.SaveComment)
+							r.With(write).Delete("/{commentID}/save", curationapi.UnsaveComment)
+						})
+					})
+				})
+			})
+		})
+		r.Route("/communities", func(r chi.Router) {
+			r.With(auth, write).Post("/", communitiesapi.Create)
+			r.Route("/{name}", func(r chi.Router) {
+				r.Use(middleware.CommunityName)
+				r.Get("/", communitiesapi.Get)
+				r.With(viewer).Get("/posts", communitiesapi.Posts)
+				r.Group(func(r chi.Router) {
+					r.Use(auth, write)
+					r.Put("/", communitiesapi.Update)
+					// CRUD membership
+					r.Post("/membership", communitiesapi.Join)
+					r.Delete("/membership", communitiesapi.Leave)
+				})
+			})
+		})
+		r.Route("/mod", func(r chi.Router) {
+			r.With(auth, read).Get("/log", modapi.Log)
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.PostID)
-				r.Get("/{postID}", postsapi.Get)
-				r.Put("/{postID}", postsapi.Update)
-				r.Delete("/{postID}", postsapi.Delete)
-				// CRUD vote
-				r.Get("/{postID}/score", postsapi.Score)
-				r.Post("/{postID}/vote", postsapi.Vote)
-				r.Delete("/{postID}/vote", postsapi.Unvote)
+				r.Use(auth, middleware.SessionOnly)
+				r.Post("/moderators", modapi.AddModerator)
+				r.Delete("/moderators", modapi.RemoveModerator)
+				r.Get("/reports", reportsapi.Queue)
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.PostID)
+					r.Post("/posts/{postID}/remove", modapi.RemovePost)
+					r.Post("/posts/{postID}/restore", modapi.RestorePost)
+					r.Post("/posts/{postID}/lock", modapi.LockPost)
+					r.Post("/posts/{postID}/unlock", modapi.UnlockPost)
+					r.Post("/reports/posts/{postID}/resolve", reportsapi.ResolvePost)
+					r.Post("/reports/posts/{postID}/dismiss", reportsapi.DismissPost)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.CommentID)
+					r.Post("/comments/{commentID}/remove", modapi.RemoveComment)
+					r.Post("/comments/{commentID}/restore", modapi.RestoreComment)
+					r.Post("/reports/comments/{commentID}/resolve", reportsapi.ResolveComment)
+					r.Post("/reports/comments/{commentID}/dismiss", reportsapi.DismissComment)
+				})
 			})
 		})
+		r.With(viewer).Get("/search", searchapi.Search)
+		r.With(auth, write).Post("/markdown/preview", markdownapi.Preview)
 	})
 	r.Get("/v1/map", func(w http.ResponseWriter, _ *http.Request) {
 		json.NewEncoder(w).Encode(maproutes(r))
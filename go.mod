@@ -1,12 +1,34 @@
 module github.com/godwhoa/upboat
 
+go 1.27.1
+
+require (
+	github.com/alexedwards/scs v1.3.0
+	github.com/basvanbeek/ocsql v0.0.0-20180908125828-63b3e35325e2
+	github.com/frankban/quicktest v1.1.0
+	github.com/go-chi/chi v3.3.3+incompatible
+	github.com/go-ozzo/ozzo-validation v3.4.0+incompatible
+	github.com/gofrs/uuid v3.1.0+incompatible
+	github.com/golang-migrate/migrate v3.4.0+incompatible
+	github.com/jmoiron/sqlx v0.0.0-20180614180643-0dae4fefe7c0
+	github.com/lib/pq v1.0.0
+	github.com/microcosm-cc/bluemonday v1.0.1
+	github.com/openzipkin/zipkin-go v0.1.1
+	github.com/ory/dockertest v3.3.2+incompatible
+	github.com/pressly/chi v3.3.3+incompatible
+	github.com/russross/blackfriday/v2 v2.1.0
+	go.opencensus.io v0.16.0
+	go.uber.org/zap v1.9.1
+	golang.org/x/crypto v0.0.0-20180904163835-0709b304e793
+)
+
 require (
+	git.apache.org/thrift.git v0.0.0-20180807212849-6e67faa92827 // indirect
 	github.com/Azure/go-ansiterm v0.0.0-20170929234023-d6e3b3328b78 // indirect
 	github.com/Microsoft/go-winio v0.4.11 // indirect
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
-	github.com/alexedwards/scs v1.3.0
 	github.com/asaskevich/govalidator v0.0.0-20180720115003-f9ffefc3facf // indirect
-	github.com/basvanbeek/ocsql v0.0.0-20180908125828-63b3e35325e2
+	github.com/beorn7/perks v0.0.0-20180321164747-3a771d992973 // indirect
 	github.com/cenkalti/backoff v2.0.0+incompatible // indirect
 	github.com/containerd/continuity v0.0.0-20180829013124-f44b615e492b // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -14,37 +36,38 @@ require (
 	github.com/docker/docker v1.13.1 // indirect
 	github.com/docker/go-connections v0.4.0 // indirect
 	github.com/docker/go-units v0.3.3 // indirect
-	github.com/frankban/quicktest v1.1.0
-	github.com/go-chi/chi v3.3.3+incompatible
-	github.com/go-ozzo/ozzo-validation v3.4.0+incompatible
 	github.com/go-sql-driver/mysql v1.4.0 // indirect
-	github.com/gofrs/uuid v3.1.0+incompatible
-	github.com/golang-migrate/migrate v3.4.0+incompatible
+	github.com/golang/protobuf v1.2.0 // indirect
+	github.com/google/go-cmp v0.2.0 // indirect
 	github.com/gorilla/context v1.1.1 // indirect
 	github.com/gorilla/mux v1.6.2 // indirect
 	github.com/gotestyourself/gotestyourself v2.1.0+incompatible // indirect
-	github.com/jmoiron/sqlx v0.0.0-20180614180643-0dae4fefe7c0
-	github.com/lib/pq v1.0.0
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/pty v1.1.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
 	github.com/mattn/go-sqlite3 v1.9.0 // indirect
-	github.com/microcosm-cc/bluemonday v1.0.1
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
 	github.com/opencontainers/go-digest v1.0.0-rc1 // indirect
 	github.com/opencontainers/image-spec v1.0.1 // indirect
 	github.com/opencontainers/runc v0.1.1 // indirect
-	github.com/openzipkin/zipkin-go v0.1.1
-	github.com/ory/dockertest v3.3.2+incompatible
 	github.com/pkg/errors v0.8.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/pressly/chi v3.3.3+incompatible
+	github.com/prometheus/client_golang v0.8.0 // indirect
+	github.com/prometheus/client_model v0.0.0-20180712105110-5c3871d89910 // indirect
+	github.com/prometheus/common v0.0.0-20180801064454-c7de2306084e // indirect
+	github.com/prometheus/procfs v0.0.0-20180725123919-05ee40e3a273 // indirect
 	github.com/sirupsen/logrus v1.0.6 // indirect
 	github.com/stevvooe/resumable v0.0.0-20180830230917-22b14a53ba50 // indirect
 	github.com/stretchr/testify v1.2.2 // indirect
-	go.opencensus.io v0.16.0
 	go.uber.org/atomic v1.3.2 // indirect
 	go.uber.org/multierr v1.1.0 // indirect
-	go.uber.org/zap v1.9.1
-	golang.org/x/crypto v0.0.0-20180904163835-0709b304e793
 	golang.org/x/net v0.0.0-20180906233101-161cd47e91fd // indirect
+	golang.org/x/sync v0.0.0-20180314180146-1d60e4601c6f // indirect
 	golang.org/x/sys v0.0.0-20180906133057-8cf3aee42992 // indirect
+	golang.org/x/text v0.3.0 // indirect
+	google.golang.org/api v0.0.0-20180818000503-e21acd801f91 // indirect
 	google.golang.org/appengine v1.1.0 // indirect
+	google.golang.org/genproto v0.0.0-20180817151627-c66870c02cf8 // indirect
+	google.golang.org/grpc v1.14.0 // indirect
 	gotest.tools v2.1.0+incompatible // indirect
 )
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// APITokensAPI contains the handlers for managing personal API tokens
+type APITokensAPI struct {
+	service apitokens.Service
+	log     *zap.Logger
+}
+
+// NewAPITokensAPI takes in all the deps. and constructs a type with all the handlers
+func NewAPITokensAPI(service apitokens.Service, log *zap.Logger) *APITokensAPI {
+	return &APITokensAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// Create makes a new token, its secret is only ever part of this response
+func (t *APITokensAPI) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &tokenRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	secret, token, err := t.service.Create(ctx, userID, req.Name, req.Scopes)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Created("Token created, it won't be shown again", map[string]interface{}{
+		"secret": secret,
+		"token":  token,
+	}))
+}
+
+// List lists the logged in user's tokens
+func (t *APITokensAPI) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	tokens, err := t.service.List(ctx, userID)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Tokens found", tokens))
+}
+
+// Revoke revokes one of the logged in user's tokens
+func (t *APITokensAPI) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+	tokenID := ctx.Value("token_id").(int)
+
+	if err := t.service.Revoke(ctx, userID, tokenID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Token revoked"))
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// CommentsAPI contains all the handlers releated to comments
+type CommentsAPI struct {
+	service comments.Service
+	log     *zap.Logger
+}
+
+// NewCommentsAPI takes in all the deps. and constructs a type with all the handlers
+func NewCommentsAPI(service comments.Service, log *zap.Logger) *CommentsAPI {
+	return &CommentsAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// Create creates a new comment on a post, optionally as a reply to another comment
+func (c *CommentsAPI) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID := ctx.Value("post_id").(int)
+	userID := ctx.Value("user_id").(int)
+
+	req := &commentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	comment := &comments.Comment{
+		PostID:      postID,
+		ParentID:    req.ParentID,
+		CommenterID: userID,
+		Body:        req.Body,
+	}
+	commentID, err := c.service.Create(ctx, comment)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Created("Comment created!", map[string]int{"comment_id": commentID}))
+}
+
+// List fetches all the comments on a specific post, except those by users the logged in user blocked
+func (c *CommentsAPI) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID := ctx.Value("post_id").(int)
+
+	list, err := c.service.Comments(ctx, postID, ctx.Value("user_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Comments found", list))
+}
+
+// Tree fetches comments on a specific post nested by replies.
+// Truncated subtrees carry a cursor which can be passed back to load the rest.
+func (c *CommentsAPI) Tree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID := ctx.Value("post_id").(int)
+
+	depth, err := queryInt(r, "depth", comments.DefaultDepth)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	children, err := queryInt(r, "limit", comments.DefaultChildren)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	tree, err := c.service.Tree(ctx, postID, comments.TreeOptions{
+		Depth:    depth,
+		Children: children,
+		Cursor:   r.URL.Query().Get("cursor"),
+		ViewerID: ctx.Value("user_id").(int),
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Comments found", tree))
+}
+
+// Delete deletes a specific comment of the user
+func (c *CommentsAPI) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID := ctx.Value("post_id").(int)
+	commentID := ctx.Value("comment_id").(int)
+	userID := ctx.Value("user_id").(int)
+
+	if err := c.service.Delete(ctx, postID, commentID, userID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Deleted!"))
+}
+
+// Score fetches the score of a specific comment
+func (c *CommentsAPI) Score(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	commentID := ctx.Value("comment_id").(int)
+
+	score, err := c.service.Score(ctx, commentID)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Score for the comment", map[string]int{"score": score}))
+}
+
+// Vote votes on a specific comment
+func (c *CommentsAPI) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	commentID := ctx.Value("comment_id").(int)
+	userID := ctx.Value("user_id").(int)
+
+	req := &voteRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := c.service.Vote(ctx, commentID, userID, req.Delta); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Voted!"))
+}
+
+// Unvote deletes user's vote on a specific comment
+func (c *CommentsAPI) Unvote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	commentID := ctx.Value("comment_id").(int)
+	userID := ctx.Value("user_id").(int)
+
+	if err := c.service.Unvote(ctx, commentID, userID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Vote removed!"))
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/posts"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// CommunitiesAPI contains all the handlers releated to communities
+type CommunitiesAPI struct {
+	service communities.Service
+	posts   posts.Service
+	log     *zap.Logger
+}
+
+// NewCommunitiesAPI takes in all the deps. and constructs a type with all the handlers
+func NewCommunitiesAPI(service communities.Service, posts posts.Service, log *zap.Logger) *CommunitiesAPI {
+	return &CommunitiesAPI{
+		service: service,
+		posts:   posts,
+		log:     log,
+	}
+}
+
+// Create creates a new community with the user as its first member
+func (c *CommunitiesAPI) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &communityRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	community := &communities.Community{
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       req.Rules,
+		CreatorID:   userID,
+	}
+	communityID, err := c.service.Create(ctx, community)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Created("Community created!", map[string]int{"community_id": communityID}))
+}
+
+// Get fetches a community by its name
+func (c *CommunitiesAPI) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := ctx.Value("community_name").(string)
+
+	community, err := c.service.GetByName(ctx, name)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Community found", community))
+}
+
+// Update changes the description and rules of the user's community
+func (c *CommunitiesAPI) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := ctx.Value("community_name").(string)
+	userID := ctx.Value("user_id").(int)
+
+	req := &settingsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	community, err := c.service.GetByName(ctx, name)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	community.Description = req.Description
+	community.Rules = req.Rules
+	community.CreatorID = userID
+	if err := c.service.Update(ctx, community); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Community updated!"))
+}
+
+// Join makes the user a member of a community
+func (c *CommunitiesAPI) Join(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := ctx.Value("community_name").(string)
+	userID := ctx.Value("user_id").(int)
+
+	community, err := c.service.GetByName(ctx, name)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := c.service.Join(ctx, community.ID, userID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Joined!"))
+}
+
+// Leave removes the user from the members of a community
+func (c *CommunitiesAPI) Leave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := ctx.Value("community_name").(string)
+	userID := ctx.Value("user_id").(int)
+
+	community, err := c.service.GetByName(ctx, name)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := c.service.Leave(ctx, community.ID, userID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Ok("Left!"))
+}
+
+// Posts fetches a page of a community's posts, it takes the same params as PostsAPI.List
+func (c *CommunitiesAPI) Posts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := ctx.Value("community_name").(string)
+	viewerID, _ := ctx.Value("user_id").(int)
+
+	limit, err := queryInt(r, "limit", posts.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	community, err := c.service.GetByName(ctx, name)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	listing, err := c.posts.Listing(ctx, posts.ListingOptions{
+		Sort:        posts.Sort(r.URL.Query().Get("sort")),
+		Cursor:      r.URL.Query().Get("cursor"),
+		Limit:       limit,
+		Domain:      r.URL.Query().Get("domain"),
+		CommunityID: community.ID,
+		ViewerID:    viewerID,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Posts found", listing))
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/curation"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// CurationAPI contains the handlers for saving, hiding and blocking
+type CurationAPI struct {
+	service curation.Service
+	log     *zap.Logger
+}
+
+// NewCurationAPI takes in all the deps. and constructs a type with all the handlers
+func NewCurationAPI(service curation.Service, log *zap.Logger) *CurationAPI {
+	return &CurationAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// page parses the `offset` and `limit` params
+func page(r *http.Request) (curation.Page, error) {
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		return curation.Page{}, err
+	}
+	limit, err := queryInt(r, "limit", curation.DefaultLimit)
+	if err != nil {
+		return curation.Page{}, err
+	}
+	return curation.Page{Offset: offset, Limit: limit}, nil
+}
+
+// SavePost adds a post to the user's saved posts
+func (a *CurationAPI) SavePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.SavePost(ctx, ctx.Value("user_id").(int), ctx.Value("post_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Saved!"))
+}
+
+// UnsavePost removes a post from the user's saved posts
+func (a *CurationAPI) UnsavePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.UnsavePost(ctx, ctx.Value("user_id").(int), ctx.Value("post_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unsaved!"))
+}
+
+// SavedPosts lists the user's saved posts, most recently saved first
+func (a *CurationAPI) SavedPosts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	p, err := page(r)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	saved, err := a.service.SavedPosts(ctx, ctx.Value("user_id").(int), p)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Saved posts found", saved))
+}
+
+// SaveComment adds a comment to the user's saved comments
+func (a *CurationAPI) SaveComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.SaveComment(ctx, ctx.Value("user_id").(int), ctx.Value("comment_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Saved!"))
+}
+
+// UnsaveComment removes a comment from the user's saved comments
+func (a *CurationAPI) UnsaveComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.UnsaveComment(ctx, ctx.Value("user_id").(int), ctx.Value("comment_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unsaved!"))
+}
+
+// SavedComments lists the user's saved comments, most recently saved first
+func (a *CurationAPI) SavedComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	p, err := page(r)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	saved, err := a.service.SavedComments(ctx, ctx.Value("user_id").(int), p)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Saved comments found", saved))
+}
+
+// Hide leaves a post out of the user's listings
+func (a *CurationAPI) Hide(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.Hide(ctx, ctx.Value("user_id").(int), ctx.Value("post_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Hidden!"))
+}
+
+// Unhide brings a hidden post back into the user's listings
+func (a *CurationAPI) Unhide(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.Unhide(ctx, ctx.Value("user_id").(int), ctx.Value("post_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unhidden!"))
+}
+
+// Block filters the user in the url out of the logged in user's listings and comment trees
+func (a *CurationAPI) Block(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.Block(ctx, ctx.Value("user_id").(int), ctx.Value("username").(string)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Blocked!"))
+}
+
+// Unblock undoes Block
+func (a *CurationAPI) Unblock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := a.service.Unblock(ctx, ctx.Value("user_id").(int), ctx.Value("username").(string)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unblocked!"))
+}
+
+// Blocked lists the users the logged in user blocked
+func (a *CurationAPI) Blocked(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	blocked, err := a.service.Blocked(ctx, ctx.Value("user_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Blocked users found", blocked))
+}
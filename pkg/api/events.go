@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/godwhoa/upboat/pkg/posts"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// heartbeat keeps idle streams from being closed by proxies
+const heartbeat = 25 * time.Second
+
+// EventsAPI streams what happens in a thread as Server-Sent Events
+type EventsAPI struct {
+	posts posts.Service
+	hub   events.Subscriber
+	log   *zap.Logger
+}
+
+// NewEventsAPI takes in all the deps. and constructs a type with all the handlers
+func NewEventsAPI(posts posts.Service, hub events.Subscriber, log *zap.Logger) *EventsAPI {
+	return &EventsAPI{
+		posts: posts,
+		hub:   hub,
+		log:   log,
+	}
+}
+
+// Stream sends the events of a post until the client goes away
+func (e *EventsAPI) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID := ctx.Value("post_id").(int)
+
+	if _, err := e.posts.Get(ctx, postID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		R.Respond(w, R.InternalError())
+		e.log.Error("Streaming unsupported, http.ResponseWriter isn't an http.Flusher")
+		return
+	}
+
+	stream, cancel := e.hub.Subscribe(postID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				e.log.Error("Error from json.Marshal()", zap.Error(err))
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
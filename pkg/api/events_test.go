@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"go.uber.org/zap"
+)
+
+// mockPosts only knows post 1
+type mockPosts struct {
+	posts.Service
+}
+
+func (m *mockPosts) Get(ctx context.Context, postID int) (*posts.Post, error) {
+	if postID != 1 {
+		return nil, posts.ErrPostNotFound
+	}
+	return &posts.Post{ID: 1}, nil
+}
+
+func streamServer(hub *events.Hub) *httptest.Server {
+	log, _ := zap.NewProduction()
+	eventsapi := NewEventsAPI(&mockPosts{}, hub, log)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		postID := 1
+		if r.URL.Path != "/1" {
+			postID = 2
+		}
+		ctx := context.WithValue(r.Context(), "post_id", postID)
+		eventsapi.Stream(w, r.WithContext(ctx))
+	}))
+}
+
+func TestStream(t *testing.T) {
+	c := qt.New(t)
+	hub := events.NewHub()
+	server := streamServer(hub)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/1")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), qt.Equals, "text/event-stream")
+
+	lines := bufio.NewReader(resp.Body)
+	line, err := lines.ReadString('\n')
+	c.Assert(err, qt.IsNil)
+	c.Assert(line, qt.Equals, ": connected\n")
+
+	c.Assert(hub.Subscribers(1), qt.Equals, 1)
+	hub.Publish(context.Background(), events.Event{Type: events.CommentCreated, PostID: 1, CommentID: 7})
+	lines.ReadString('\n') // the blank line ending the comment
+	line, err = lines.ReadString('\n')
+	c.Assert(err, qt.IsNil)
+	c.Assert(line, qt.Equals, "event: comment-created\n")
+	line, err = lines.ReadString('\n')
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.TrimSpace(line), qt.Equals, `data: {"type":"comment-created","post_id":1,"comment_id":7}`)
+
+	// the subscription ends with the request
+	resp.Body.Close()
+	for i := 0; i < 100 && hub.Subscribers(1) > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(hub.Subscribers(1), qt.Equals, 0)
+}
+
+func TestStream_PostNotFound(t *testing.T) {
+	c := qt.New(t)
+	server := streamServer(events.NewHub())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/2")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusNotFound)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/markdown"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// MarkdownAPI contains the handlers for previewing Markdown
+type MarkdownAPI struct {
+	log *zap.Logger
+}
+
+// NewMarkdownAPI takes in all the deps. and constructs a type with all the handlers
+func NewMarkdownAPI(log *zap.Logger) *MarkdownAPI {
+	return &MarkdownAPI{
+		log: log,
+	}
+}
+
+// Preview renders a body the way it would look once posted, without saving anything
+func (m *MarkdownAPI) Preview(w http.ResponseWriter, r *http.Request) {
+	req := &previewRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Preview rendered", map[string]string{
+		"body_html": markdown.Render(req.Body),
+	}))
+}
@@ -3,24 +3,110 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/alexedwards/scs"
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	"github.com/godwhoa/upboat/pkg/errors"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"github.com/godwhoa/upboat/pkg/users"
 )
 
-// Auth middleware only lets threw requests with a valid session.
-// Additionally it sets user_id key in context
-func Auth(sm *scs.Manager) func(next http.Handler) http.Handler {
+// Auth middleware only lets threw requests with a valid session or API token, sent as a Bearer token.
+// Sessions ended by a password change or deactivation are turned away too.
+// Additionally it sets user_id key in context, and token for requests made with an API token.
+func Auth(sm *scs.Manager, service users.Service, tokens apitokens.Service) func(next http.Handler) http.Handler {
+	return authenticate(sm, service, tokens, true)
+}
+
+// Viewer is Auth for routes anyone can reach but which differ for logged in users, like listings.
+// Requests without a session or API token go through without user_id, so do ones with an ended session,
+// which is dropped. A bad API token is still turned away.
+func Viewer(sm *scs.Manager, service users.Service, tokens apitokens.Service) func(next http.Handler) http.Handler {
+	return authenticate(sm, service, tokens, false)
+}
+
+func authenticate(sm *scs.Manager, service users.Service, tokens apitokens.Service, required bool) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
+			// other schemes, like Basic auth added by a proxy, are left for the session to authenticate
+			if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+				secret := strings.TrimPrefix(header, "Bearer ")
+				token, err := tokens.Authenticate(r.Context(), secret)
+				if err != nil {
+					R.Respond(w, R.Err(err))
+					return
+				}
+				ctx := context.WithValue(r.Context(), "user_id", token.UserID)
+				ctx = context.WithValue(ctx, "token", token)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			session := sm.Load(r)
 			userID, err := session.GetInt("user_id")
 			if err != nil || userID < 1 {
+				if !required {
+					next.ServeHTTP(w, r)
+					return
+				}
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
+			epoch, err := session.GetInt("epoch")
+			if err != nil {
+				if !required {
+					session.Destroy(w)
+					next.ServeHTTP(w, r)
+					return
+				}
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if err := service.CheckSession(r.Context(), userID, epoch); err != nil {
+				if !required {
+					// an ended session is dropped, one that failed to check is kept for the next request
+					if !errors.Is(errors.Internal, err) {
+						session.Destroy(w)
+					}
+					next.ServeHTTP(w, r)
+					return
+				}
+				R.Respond(w, R.Err(err))
+				return
+			}
 			ctx := context.WithValue(r.Context(), "user_id", userID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 		return http.HandlerFunc(fn)
 	}
 }
+
+// RequireScope turns away token requests whose token lacks scope, sessions can do everything.
+// It relies on Auth.
+func RequireScope(scope apitokens.Scope) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			token, ok := r.Context().Value("token").(*apitokens.Token)
+			if ok && !token.Has(scope) {
+				http.Error(w, "Token lacks the "+string(scope)+" scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// SessionOnly turns away token requests, for routes only a logged in user should reach like account settings.
+// It relies on Auth.
+func SessionOnly(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value("token").(*apitokens.Token); ok {
+			http.Error(w, "Not available to API tokens", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
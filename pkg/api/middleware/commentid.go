@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+)
+
+// CommentID validates commentID param and sets it as a context value
+func CommentID(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		commentID, err := strconv.Atoi(chi.URLParam(r, "commentID"))
+		if err != nil {
+			http.Error(w, "Invalid CommentID Param", http.StatusBadRequest)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "comment_id", commentID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
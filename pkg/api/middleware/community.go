@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// CommunityName validates name param and sets it as a context value
+func CommunityName(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "name")
+		if name == "" {
+			http.Error(w, "Invalid Name Param", http.StatusBadRequest)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "community_name", name)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
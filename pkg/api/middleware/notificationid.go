@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+)
+
+// NotificationID validates notificationID param and sets it as a context value
+func NotificationID(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		notificationID, err := strconv.Atoi(chi.URLParam(r, "notificationID"))
+		if err != nil {
+			http.Error(w, "Invalid NotificationID Param", http.StatusBadRequest)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "notification_id", notificationID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
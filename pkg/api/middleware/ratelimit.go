@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/godwhoa/upboat/pkg/ratelimit"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// RateLimit limits requests with the group's policy.
+// Requests are keyed by user_id when it's set by Auth, by client IP otherwise.
+// If the limiter fails the request is let through rather than locking everyone out.
+func RateLimit(l *ratelimit.Limiter, group string, log *zap.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := "ip:" + ClientIP(r)
+			if userID, ok := r.Context().Value("user_id").(int); ok {
+				key = "user:" + strconv.Itoa(userID)
+			}
+
+			wait, err := l.Allow(r.Context(), group, key)
+			if err == ratelimit.ErrLimited {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				R.Respond(w, R.Err(err))
+				return
+			}
+			if err != nil {
+				log.Error("Error from ratelimit.Limiter.Allow()", zap.Error(err))
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ClientIP is the address the request came from, a proxy in front should be setting RemoteAddr accordingly
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+)
+
+// TokenID validates tokenID param and sets it as a context value
+func TokenID(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		tokenID, err := strconv.Atoi(chi.URLParam(r, "tokenID"))
+		if err != nil {
+			http.Error(w, "Invalid TokenID Param", http.StatusBadRequest)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "token_id", tokenID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// Username validates username param and sets it as a context value
+func Username(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		username := chi.URLParam(r, "username")
+		if username == "" {
+			http.Error(w, "Invalid Username Param", http.StatusBadRequest)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "username", username)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	R "github.com/godwhoa/upboat/pkg/response"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+// Verified only lets through users who are allowed to post and vote.
+// It relies on user_id set by Auth.
+func Verified(service users.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			userID := r.Context().Value("user_id").(int)
+			if err := service.EnsureVerified(r.Context(), userID); err != nil {
+				R.Respond(w, R.Err(err))
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/moderation"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// ModerationAPI contains all the handlers releated to moderation
+type ModerationAPI struct {
+	service moderation.Service
+	log     *zap.Logger
+}
+
+// NewModerationAPI takes in all the deps. and constructs a type with all the handlers
+func NewModerationAPI(service moderation.Service, log *zap.Logger) *ModerationAPI {
+	return &ModerationAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// act applies an action on a post or comment, the reason in the body is optional except for removals
+func (m *ModerationAPI) act(w http.ResponseWriter, r *http.Request, action moderation.Action, targetID int) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &reasonRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	entry := &moderation.Entry{
+		ModeratorID: userID,
+		Action:      action,
+		TargetID:    targetID,
+		Reason:      req.Reason,
+	}
+	if err := m.service.Moderate(ctx, entry); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Done!", entry))
+}
+
+// RemovePost hides a post from everyone, it can be restored
+func (m *ModerationAPI) RemovePost(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.RemovePost, r.Context().Value("post_id").(int))
+}
+
+// RestorePost undoes RemovePost
+func (m *ModerationAPI) RestorePost(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.RestorePost, r.Context().Value("post_id").(int))
+}
+
+// LockPost stops a post from taking new comments
+func (m *ModerationAPI) LockPost(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.LockPost, r.Context().Value("post_id").(int))
+}
+
+// UnlockPost undoes LockPost
+func (m *ModerationAPI) UnlockPost(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.UnlockPost, r.Context().Value("post_id").(int))
+}
+
+// RemoveComment replaces a comment with a placeholder, it can be restored
+func (m *ModerationAPI) RemoveComment(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.RemoveComment, r.Context().Value("comment_id").(int))
+}
+
+// RestoreComment undoes RemoveComment
+func (m *ModerationAPI) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	m.act(w, r, moderation.RestoreComment, r.Context().Value("comment_id").(int))
+}
+
+// moderators adds or removes a moderator of a community, or a global one when community_id is left out
+func (m *ModerationAPI) moderators(w http.ResponseWriter, r *http.Request, action moderation.Action) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &moderatorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	entry := &moderation.Entry{
+		ModeratorID: userID,
+		CommunityID: req.CommunityID,
+		Action:      action,
+		TargetID:    req.UserID,
+		Reason:      req.Reason,
+	}
+	if err := m.service.Moderate(ctx, entry); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Done!", entry))
+}
+
+// AddModerator makes a user a moderator
+func (m *ModerationAPI) AddModerator(w http.ResponseWriter, r *http.Request) {
+	m.moderators(w, r, moderation.AddModerator)
+}
+
+// RemoveModerator takes away a user's moderator role
+func (m *ModerationAPI) RemoveModerator(w http.ResponseWriter, r *http.Request) {
+	m.moderators(w, r, moderation.RemoveModerator)
+}
+
+// Log fetches a page of the moderation log newest first, filtered by the `community_id` and `moderator_id` params.
+// Following pages are fetched by passing back `next` as `before`.
+func (m *ModerationAPI) Log(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := moderation.LogQuery{}
+	params := map[string]*int{
+		"community_id": &q.CommunityID,
+		"moderator_id": &q.ModeratorID,
+		"before":       &q.Before,
+		"limit":        &q.Limit,
+	}
+	for name, value := range params {
+		n, err := queryInt(r, name, 0)
+		if err != nil {
+			R.Respond(w, R.Err(err))
+			return
+		}
+		*value = n
+	}
+
+	page, err := m.service.Log(ctx, q)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Log found", page))
+}
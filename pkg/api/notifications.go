@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/godwhoa/upboat/pkg/notifications"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// NotificationsAPI contains the handlers for the logged in user's notifications
+type NotificationsAPI struct {
+	service notifications.Service
+	log     *zap.Logger
+}
+
+// NewNotificationsAPI takes in all the deps. and constructs a type with all the handlers
+func NewNotificationsAPI(service notifications.Service, log *zap.Logger) *NotificationsAPI {
+	return &NotificationsAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// Inbox lists notifications newest first, ?unread=true leaves out read ones
+// and ?before= continues from the last notification of the previous page
+func (n *NotificationsAPI) Inbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	before, err := queryInt(r, "before", 0)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	limit, err := queryInt(r, "limit", notifications.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	inbox, err := n.service.Inbox(ctx, notifications.InboxQuery{
+		UserID: ctx.Value("user_id").(int),
+		Unread: r.URL.Query().Get("unread") == "true",
+		Before: before,
+		Limit:  limit,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Notifications found", inbox))
+}
+
+// Unread counts the unread notifications
+func (n *NotificationsAPI) Unread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	count, err := n.service.Unread(ctx, ctx.Value("user_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Unread notifications counted", map[string]int{"unread": count}))
+}
+
+// MarkRead marks one notification read
+func (n *NotificationsAPI) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := n.service.MarkRead(ctx, ctx.Value("user_id").(int), ctx.Value("notification_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Notification marked read"))
+}
+
+// MarkAllRead marks every notification read
+func (n *NotificationsAPI) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := n.service.MarkAllRead(ctx, ctx.Value("user_id").(int)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Notifications marked read"))
+}
+
+// Mutes lists the kinds of notifications the user muted
+func (n *NotificationsAPI) Mutes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	kinds, err := n.service.Mutes(ctx, ctx.Value("user_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Muted kinds found", kinds))
+}
+
+// Mute stops notifications of the {kind} in the url
+func (n *NotificationsAPI) Mute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	kind := notifications.Kind(chi.URLParam(r, "kind"))
+	if err := n.service.Mute(ctx, ctx.Value("user_id").(int), kind); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Muted"))
+}
+
+// Unmute resumes notifications of the {kind} in the url
+func (n *NotificationsAPI) Unmute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	kind := notifications.Kind(chi.URLParam(r, "kind"))
+	if err := n.service.Unmute(ctx, ctx.Value("user_id").(int), kind); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unmuted"))
+}
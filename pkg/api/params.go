@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// queryInt parses an optional integer query param, returns def if it's absent
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errors.E(errors.Invalid, "Invalid '"+name+"' param")
+	}
+	return n, nil
+}
@@ -39,11 +39,19 @@ func (p *PostsAPI) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post := &posts.Post{
-		AuthorID: userID,
-		Title:    req.Title,
-		Body:     req.Body,
+		AuthorID:    userID,
+		CommunityID: req.CommunityID,
+		Title:       req.Title,
+		Body:        req.Body,
+		URL:         req.URL,
 	}
 	postID, err := p.service.Create(ctx, post)
+	if err == posts.ErrDuplicateURL {
+		resp := R.Err(err)
+		resp.Data = map[string]int{"post_id": postID}
+		R.Respond(w, resp)
+		return
+	}
 	if err != nil {
 		R.Respond(w, R.Err(err))
 		return
@@ -66,6 +74,61 @@ func (p *PostsAPI) Get(w http.ResponseWriter, r *http.Request) {
 	R.Respond(w, R.OkData("Post found", post))
 }
 
+// List fetches a page of posts ordered by the `sort` param (new, top or hot),
+// optionally only links to the `domain` param.
+// Following pages are fetched by passing back the `next` cursor as `cursor`.
+// Logged in users don't see posts they hid or posts by users they blocked.
+func (p *PostsAPI) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	viewerID, _ := ctx.Value("user_id").(int)
+
+	limit, err := queryInt(r, "limit", posts.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	listing, err := p.service.Listing(ctx, posts.ListingOptions{
+		Sort:     posts.Sort(r.URL.Query().Get("sort")),
+		Cursor:   r.URL.Query().Get("cursor"),
+		Limit:    limit,
+		Domain:   r.URL.Query().Get("domain"),
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Posts found", listing))
+}
+
+// Following fetches a page of posts by users the logged in user follows, it takes the same params as List
+func (p *PostsAPI) Following(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := queryInt(r, "limit", posts.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	listing, err := p.service.Listing(ctx, posts.ListingOptions{
+		Sort:      posts.Sort(r.URL.Query().Get("sort")),
+		Cursor:    r.URL.Query().Get("cursor"),
+		Limit:     limit,
+		Domain:    r.URL.Query().Get("domain"),
+		ViewerID:  ctx.Value("user_id").(int),
+		Following: true,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Posts found", listing))
+}
+
 // Update updates a specific post of the user
 func (p *PostsAPI) Update(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/alexedwards/scs"
+	"github.com/godwhoa/upboat/pkg/privacy"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// PrivacyAPI contains the handlers for exporting and erasing a user's data
+type PrivacyAPI struct {
+	service privacy.Service
+	sm      *scs.Manager
+	log     *zap.Logger
+}
+
+// NewPrivacyAPI takes in all the deps. and constructs a type with all the handlers
+func NewPrivacyAPI(service privacy.Service, sm *scs.Manager, log *zap.Logger) *PrivacyAPI {
+	return &PrivacyAPI{
+		service: service,
+		sm:      sm,
+		log:     log,
+	}
+}
+
+// Export sends the logged in user everything stored about them as a JSON download
+func (p *PrivacyAPI) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	archive, err := p.service.Export(ctx, userID)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="upboat-%d.json"`, userID))
+	R.Respond(w, R.OkData("Data exported", archive))
+}
+
+// Erase erases the logged in user's account and data, and logs them out
+func (p *PrivacyAPI) Erase(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &passwordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := p.service.Erase(ctx, userID, req.Password); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	session := p.sm.Load(r)
+	if err := session.Destroy(w); err != nil {
+		p.log.Error("Error from session.Destroy()", zap.Error(err))
+	}
+	R.Respond(w, R.Ok("Account erased"))
+}
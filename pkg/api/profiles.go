@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/profiles"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// ProfilesAPI contains the handlers for public user profiles
+type ProfilesAPI struct {
+	service profiles.Service
+	log     *zap.Logger
+}
+
+// NewProfilesAPI takes in all the deps. and constructs a type with all the handlers
+func NewProfilesAPI(service profiles.Service, log *zap.Logger) *ProfilesAPI {
+	return &ProfilesAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// profilePage reads the `before` and `limit` params, logged in users get nothing from users they blocked
+func profilePage(r *http.Request) (profiles.Page, error) {
+	before, err := queryInt(r, "before", 0)
+	if err != nil {
+		return profiles.Page{}, err
+	}
+	limit, err := queryInt(r, "limit", profiles.DefaultLimit)
+	if err != nil {
+		return profiles.Page{}, err
+	}
+	viewerID, _ := r.Context().Value("user_id").(int)
+	return profiles.Page{Before: before, Limit: limit, ViewerID: viewerID}, nil
+}
+
+// Get fetches a user's public profile
+func (p *ProfilesAPI) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := ctx.Value("username").(string)
+
+	profile, err := p.service.Profile(ctx, username)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("User found", profile))
+}
+
+// Posts fetches a page of a user's posts newest first, following pages are fetched by passing back `next` as `before`
+func (p *ProfilesAPI) Posts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := ctx.Value("username").(string)
+
+	pg, err := profilePage(r)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	result, err := p.service.Posts(ctx, username, pg)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Posts found", result))
+}
+
+// Comments fetches a page of a user's comments newest first, following pages are fetched by passing back `next` as `before`
+func (p *ProfilesAPI) Comments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := ctx.Value("username").(string)
+
+	pg, err := profilePage(r)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	result, err := p.service.Comments(ctx, username, pg)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Comments found", result))
+}
+
+// Follow adds the user in the url to the logged in user's following feed
+func (p *ProfilesAPI) Follow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := p.service.Follow(ctx, ctx.Value("user_id").(int), ctx.Value("username").(string)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Followed!"))
+}
+
+// Unfollow undoes Follow
+func (p *ProfilesAPI) Unfollow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := p.service.Unfollow(ctx, ctx.Value("user_id").(int), ctx.Value("username").(string)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Unfollowed!"))
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/godwhoa/upboat/pkg/reports"
+	R "github.com/godwhoa/upboat/pkg/response"
+	"go.uber.org/zap"
+)
+
+// ReportsAPI contains all the handlers releated to reports
+type ReportsAPI struct {
+	service reports.Service
+	log     *zap.Logger
+}
+
+// NewReportsAPI takes in all the deps. and constructs a type with all the handlers
+func NewReportsAPI(service reports.Service, log *zap.Logger) *ReportsAPI {
+	return &ReportsAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+func (a *ReportsAPI) report(w http.ResponseWriter, r *http.Request, kind reports.Kind, targetID int) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &reportRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	reportID, err := a.service.Report(ctx, &reports.Report{
+		ReporterID: userID,
+		Kind:       kind,
+		TargetID:   targetID,
+		Reason:     reports.Reason(req.Reason),
+		Details:    req.Details,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.Created("Reported!", map[string]int{"report_id": reportID}))
+}
+
+// ReportPost flags a post for moderators
+func (a *ReportsAPI) ReportPost(w http.ResponseWriter, r *http.Request) {
+	a.report(w, r, reports.KindPost, r.Context().Value("post_id").(int))
+}
+
+// ReportComment flags a comment for moderators
+func (a *ReportsAPI) ReportComment(w http.ResponseWriter, r *http.Request) {
+	a.report(w, r, reports.KindComment, r.Context().Value("comment_id").(int))
+}
+
+// Queue fetches a page of reported content, most reported first.
+// Community moderators pass `community_id`, global moderators can leave it out to see everything.
+func (a *ReportsAPI) Queue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	communityID, err := queryInt(r, "community_id", 0)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	limit, err := queryInt(r, "limit", reports.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	queue, err := a.service.Queue(ctx, userID, reports.QueueQuery{
+		CommunityID: communityID,
+		Offset:      offset,
+		Limit:       limit,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Reports found", queue))
+}
+
+// ResolvePost closes the reports on a post which was acted on
+func (a *ReportsAPI) ResolvePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := a.service.Resolve(ctx, ctx.Value("user_id").(int), reports.KindPost, ctx.Value("post_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Reports resolved!"))
+}
+
+// DismissPost closes the reports on a post which was fine
+func (a *ReportsAPI) DismissPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := a.service.Dismiss(ctx, ctx.Value("user_id").(int), reports.KindPost, ctx.Value("post_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Reports dismissed!"))
+}
+
+// ResolveComment closes the reports on a comment which was acted on
+func (a *ReportsAPI) ResolveComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := a.service.Resolve(ctx, ctx.Value("user_id").(int), reports.KindComment, ctx.Value("comment_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Reports resolved!"))
+}
+
+// DismissComment closes the reports on a comment which was fine
+func (a *ReportsAPI) DismissComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := a.service.Dismiss(ctx, ctx.Value("user_id").(int), reports.KindComment, ctx.Value("comment_id").(int))
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Reports dismissed!"))
+}
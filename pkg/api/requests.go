@@ -1,8 +1,11 @@
 package api
 
 import (
+	"regexp"
+
 	v "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/godwhoa/upboat/pkg/apitokens"
 )
 
 type loginRequest struct {
@@ -17,6 +20,9 @@ func (l loginRequest) Validate() error {
 	)
 }
 
+// usernameChars is the charset @mentions match, anything else couldn't be mentioned
+var usernameChars = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 type registerRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
@@ -25,26 +31,37 @@ type registerRequest struct {
 
 func (r registerRequest) Validate() error {
 	return v.ValidateStruct(&r,
-		v.Field(&r.Username, v.Required),
+		v.Field(&r.Username, v.Required, v.Match(usernameChars).Error("must contain only letters, digits, _ and -")),
 		v.Field(&r.Email, v.Required, is.Email),
 		v.Field(&r.Password, v.Required),
 	)
 }
 
+// createRequest needs either a Body or a URL, which posts.Service enforces after sanitizing
 type createRequest struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	URL         string `json:"url"`
+	CommunityID *int   `json:"community_id"`
 }
 
 func (r createRequest) Validate() error {
 	return v.ValidateStruct(&r,
-		v.Field(&r.Body, v.Required),
 		v.Field(&r.Title, v.Required, v.Length(1, 200)),
+		v.Field(&r.URL, v.Length(0, 2000), is.URL),
 	)
 }
 
+// updateRequest leaves out URL, links can't be changed after submission
 type updateRequest struct {
-	createRequest
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (r updateRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Title, v.Required, v.Length(1, 200)),
+	)
 }
 
 type voteRequest struct {
@@ -56,3 +73,174 @@ func (r voteRequest) Validate() error {
 		v.Field(&r.Delta, v.Required, v.In(-1, +1)),
 	)
 }
+
+type commentRequest struct {
+	ParentID *int   `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+func (r commentRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Body, v.Required, v.Length(1, 10000)),
+	)
+}
+
+type previewRequest struct {
+	Body string `json:"body"`
+}
+
+func (r previewRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Body, v.Required, v.Length(1, 10000)),
+	)
+}
+
+type communityRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Rules       string `json:"rules"`
+}
+
+func (r communityRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Name, v.Required, v.Length(3, 21)),
+		v.Field(&r.Description, v.Length(0, 500)),
+		v.Field(&r.Rules, v.Length(0, 10000)),
+	)
+}
+
+type settingsRequest struct {
+	Description string `json:"description"`
+	Rules       string `json:"rules"`
+}
+
+func (r settingsRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Description, v.Length(0, 500)),
+		v.Field(&r.Rules, v.Length(0, 10000)),
+	)
+}
+
+type reasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r reasonRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Reason, v.Length(0, 500)),
+	)
+}
+
+type moderatorRequest struct {
+	UserID      int    `json:"user_id"`
+	CommunityID *int   `json:"community_id"`
+	Reason      string `json:"reason"`
+}
+
+func (r moderatorRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.UserID, v.Required),
+		v.Field(&r.Reason, v.Length(0, 500)),
+	)
+}
+
+type reportRequest struct {
+	Reason  string `json:"reason"`
+	Details string `json:"details"`
+}
+
+func (r reportRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Reason, v.Required),
+		v.Field(&r.Details, v.Length(0, 1000)),
+	)
+}
+
+type forgotRequest struct {
+	Email string `json:"email"`
+}
+
+func (r forgotRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Email, v.Required, is.Email),
+	)
+}
+
+type resetRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (r resetRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Token, v.Required),
+		v.Field(&r.Password, v.Required, v.Length(8, 0)),
+	)
+}
+
+type verifyRequest struct {
+	Token string `json:"token"`
+}
+
+func (r verifyRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Token, v.Required),
+	)
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password"`
+}
+
+func (r changePasswordRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.CurrentPassword, v.Required),
+		v.Field(&r.Password, v.Required, v.Length(8, 0)),
+	)
+}
+
+type changeEmailRequest struct {
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+func (r changeEmailRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Password, v.Required),
+		v.Field(&r.Email, v.Required, is.Email),
+	)
+}
+
+// passwordRequest confirms a destructive account action with the user's password
+type passwordRequest struct {
+	Password string `json:"password"`
+}
+
+func (r passwordRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Password, v.Required),
+	)
+}
+
+type tokenRequest struct {
+	Name   string            `json:"name"`
+	Scopes []apitokens.Scope `json:"scopes"`
+}
+
+func (r tokenRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Name, v.Required, v.Length(1, 100)),
+		v.Field(&r.Scopes, v.Required),
+	)
+}
+
+type codeRequest struct {
+	Code string `json:"code"`
+}
+
+func (r codeRequest) Validate() error {
+	return v.ValidateStruct(&r,
+		v.Field(&r.Code, v.Required, v.Length(1, 50)),
+	)
+}
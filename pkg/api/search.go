@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	R "github.com/godwhoa/upboat/pkg/response"
+	"github.com/godwhoa/upboat/pkg/search"
+	"go.uber.org/zap"
+)
+
+// SearchAPI contains the handlers for searching posts and comments
+type SearchAPI struct {
+	service search.Service
+	log     *zap.Logger
+}
+
+// NewSearchAPI takes in all the deps. and constructs a type with all the handlers
+func NewSearchAPI(service search.Service, log *zap.Logger) *SearchAPI {
+	return &SearchAPI{
+		service: service,
+		log:     log,
+	}
+}
+
+// Search finds posts and comments matching the `q` param, most relevant first.
+// Results can be narrowed with `type` (post or comment) and paged with `offset` and `limit`.
+// Logged in users don't see results by users they blocked.
+func (s *SearchAPI) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	viewerID, _ := ctx.Value("user_id").(int)
+
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	limit, err := queryInt(r, "limit", search.DefaultLimit)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	page, err := s.service.Search(ctx, search.Options{
+		Text:     r.URL.Query().Get("q"),
+		Type:     r.URL.Query().Get("type"),
+		Offset:   offset,
+		Limit:    limit,
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	R.Respond(w, R.OkData("Search results", page))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/alexedwards/scs"
@@ -17,6 +18,8 @@ type mockService struct {
 	loginerr    bool
 	regerr      bool
 	logincalled bool
+	unverified  bool
+	twofactor   bool
 }
 
 func (s *mockService) Register(ctx context.Context, u *users.User, password string) (*users.User, error) {
@@ -26,19 +29,104 @@ func (s *mockService) Register(ctx context.Context, u *users.User, password stri
 	return u, nil
 }
 
-func (s *mockService) Login(ctx context.Context, email string, password string) (*users.User, error) {
+func (s *mockService) Login(ctx context.Context, email string, password string, ip string) (*users.User, error) {
 	s.logincalled = true
 	if s.loginerr {
 		return nil, users.ErrInvalidCredentials
 	}
 	return &users.User{
-		ID:       0,
-		Username: "blah",
-		Email:    "blah@blah.com",
-		Hash:     "kjadkjglkjlkj",
+		ID:        0,
+		Username:  "blah",
+		Email:     "blah@blah.com",
+		Hash:      "kjadkjglkjlkj",
+		TwoFactor: s.twofactor,
 	}, nil
 }
 
+func (s *mockService) RequestPasswordReset(ctx context.Context, email string) error {
+	return nil
+}
+
+func (s *mockService) ResetPassword(ctx context.Context, token string, password string) error {
+	if token != "token" {
+		return users.ErrInvalidToken
+	}
+	return nil
+}
+
+func (s *mockService) VerifyEmail(ctx context.Context, token string) error {
+	if token != "token" {
+		return users.ErrInvalidToken
+	}
+	return nil
+}
+
+func (s *mockService) ResendVerification(ctx context.Context, userID int) error {
+	return nil
+}
+
+func (s *mockService) EnsureVerified(ctx context.Context, userID int) error {
+	if s.unverified {
+		return users.ErrUnverified
+	}
+	return nil
+}
+
+func (s *mockService) ChangePassword(ctx context.Context, userID int, current string, password string) (int, error) {
+	if current != "password" {
+		return 0, users.ErrIncorrectPassword
+	}
+	return 1, nil
+}
+
+func (s *mockService) ChangeEmail(ctx context.Context, userID int, password string, email string) error {
+	return nil
+}
+
+func (s *mockService) Deactivate(ctx context.Context, userID int, password string) error {
+	return nil
+}
+
+func (s *mockService) VerifyPassword(ctx context.Context, userID int, password string) error {
+	if password != "password" {
+		return users.ErrIncorrectPassword
+	}
+	return nil
+}
+
+func (s *mockService) CheckSession(ctx context.Context, userID int, epoch int) error {
+	return nil
+}
+
+func (s *mockService) Unlock(ctx context.Context, token string) error {
+	if token != "token" {
+		return users.ErrInvalidToken
+	}
+	return nil
+}
+
+func (s *mockService) EnrollTwoFactor(ctx context.Context, userID int) (string, string, error) {
+	return "JBSWY3DPEHPK3PXP", "otpauth://totp/upboat:blah@blah.com?secret=JBSWY3DPEHPK3PXP", nil
+}
+
+func (s *mockService) ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	if code != "123456" {
+		return nil, users.ErrInvalidCode
+	}
+	return []string{"aaaa-bbbb-cccc-dddd"}, nil
+}
+
+func (s *mockService) VerifyTwoFactor(ctx context.Context, userID int, code string, ip string) error {
+	if code != "123456" {
+		return users.ErrInvalidCode
+	}
+	return nil
+}
+
+func (s *mockService) DisableTwoFactor(ctx context.Context, userID int, password string) error {
+	return nil
+}
+
 func deps() (*zap.Logger, *scs.Manager, *mockService) {
 	log, _ := zap.NewProduction()
 	sm := scs.NewCookieManager("ksajkjgfkjkjkjkjkjijijkjdkljfkl")
@@ -83,6 +171,64 @@ func TestLoginOK(t *testing.T) {
 	c.Assert(rr.Code, qt.Equals, http.StatusOK)
 }
 
+// With 2FA the password only gets the session as far as waiting for a code
+func TestLoginTwoFactor(t *testing.T) {
+	c := qt.New(t)
+	log, sm, service := deps()
+	service.twofactor = true
+	userapi := NewUsersAPI(service, sm, log)
+
+	req, err := post("/api/login", `{"email":"blah@blah.com", "password":"password"}`)
+	c.Assert(err, qt.IsNil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(userapi.Login).
+		ServeHTTP(rr, req)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(strings.Contains(rr.Body.String(), `"two_factor":true`), qt.Equals, true)
+	cookies := rr.Result().Cookies()
+	c.Assert(cookies, qt.Not(qt.HasLen), 0)
+
+	withCookies := func(payload string) *http.Request {
+		req, err := post("/api/login/2fa", payload)
+		c.Assert(err, qt.IsNil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		return req
+	}
+
+	session := sm.Load(withCookies(`{}`))
+	exists, err := session.Exists("user_id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(exists, qt.Equals, false)
+
+	// no pending login without the cookie
+	req, err = post("/api/login/2fa", `{"code":"123456"}`)
+	c.Assert(err, qt.IsNil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(userapi.LoginTwoFactor).
+		ServeHTTP(rr, req)
+	c.Assert(rr.Code, qt.Equals, http.StatusUnauthorized)
+
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(userapi.LoginTwoFactor).
+		ServeHTTP(rr, withCookies(`{"code":"000000"}`))
+	c.Assert(rr.Code, qt.Equals, http.StatusUnauthorized)
+
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(userapi.LoginTwoFactor).
+		ServeHTTP(rr, withCookies(`{"code":"123456"}`))
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	cookies = rr.Result().Cookies()
+	session = sm.Load(withCookies(`{}`))
+	exists, err = session.Exists("user_id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(exists, qt.Equals, true)
+	exists, err = session.Exists("pending_user_id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(exists, qt.Equals, false)
+}
+
 func TestLogout(t *testing.T) {
 	c := qt.New(t)
 	log, sm, service := deps()
@@ -139,3 +285,85 @@ func TestRegisterOK(t *testing.T) {
 		ServeHTTP(rr, req)
 	c.Assert(rr.Code, qt.Equals, http.StatusOK)
 }
+
+func TestRegisterUsername(t *testing.T) {
+	c := qt.New(t)
+	log, sm, service := deps()
+	userapi := NewUsersAPI(service, sm, log)
+
+	for username, code := range map[string]int{
+		"blah_-42":  http.StatusOK,
+		"blah.blah": http.StatusBadRequest,
+		"blah blah": http.StatusBadRequest,
+		"bläh":      http.StatusBadRequest,
+	} {
+		req, err := post("/api/register", `{"email":"blah@blah.com", "username": "`+username+`", "password":"password"}`)
+		c.Assert(err, qt.IsNil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(userapi.Register).
+			ServeHTTP(rr, req)
+		c.Assert(rr.Code, qt.Equals, code, qt.Commentf(username))
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	c := qt.New(t)
+	log, sm, service := deps()
+	userapi := NewUsersAPI(service, sm, log)
+
+	for payload, code := range map[string]int{
+		`{"token":"token", "password":"newpassword"}`: http.StatusOK,
+		`{"token":"token", "password":"short"}`:       http.StatusBadRequest,
+		`{"token":"bogus", "password":"newpassword"}`: http.StatusBadRequest,
+	} {
+		req, err := post("/api/users/password/reset/confirm", payload)
+		c.Assert(err, qt.IsNil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(userapi.ResetPassword).
+			ServeHTTP(rr, req)
+		c.Assert(rr.Code, qt.Equals, code, qt.Commentf(payload))
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	c := qt.New(t)
+	log, sm, service := deps()
+	userapi := NewUsersAPI(service, sm, log)
+
+	for payload, code := range map[string]int{
+		`{"token":"token"}`: http.StatusOK,
+		`{"token":"bogus"}`: http.StatusBadRequest,
+		`{}`:                http.StatusBadRequest,
+	} {
+		req, err := post("/api/users/verify", payload)
+		c.Assert(err, qt.IsNil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(userapi.VerifyEmail).
+			ServeHTTP(rr, req)
+		c.Assert(rr.Code, qt.Equals, code, qt.Commentf(payload))
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	c := qt.New(t)
+	log, sm, service := deps()
+	userapi := NewUsersAPI(service, sm, log)
+
+	for payload, code := range map[string]int{
+		`{"current_password":"password", "password":"newpassword"}`: http.StatusOK,
+		`{"current_password":"wrong", "password":"newpassword"}`:    http.StatusBadRequest,
+		`{"current_password":"password", "password":"short"}`:       http.StatusBadRequest,
+	} {
+		req, err := http.NewRequest("PUT", "/api/account/password", bytes.NewBufferString(payload))
+		c.Assert(err, qt.IsNil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", 1))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(userapi.ChangePassword).
+			ServeHTTP(rr, req)
+		c.Assert(rr.Code, qt.Equals, code, qt.Commentf(payload))
+	}
+}
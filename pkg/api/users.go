@@ -3,8 +3,10 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/alexedwards/scs"
+	"github.com/godwhoa/upboat/pkg/api/middleware"
 	R "github.com/godwhoa/upboat/pkg/response"
 	"github.com/godwhoa/upboat/pkg/users"
 	"go.uber.org/zap"
@@ -38,14 +40,38 @@ func (u *UsersAPI) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := u.service.Login(ctx, req.Email, req.Password)
+	user, err := u.service.Login(ctx, req.Email, req.Password, middleware.ClientIP(r))
 	if err != nil {
 		R.Respond(w, R.Err(err))
 		return
 	}
 
 	session := u.sm.Load(r)
+	if user.TwoFactor {
+		// the session stays logged out until LoginTwoFactor gets a code
+		err = session.Remove(w, "user_id")
+		if err == nil {
+			err = session.PutInt(w, "pending_user_id", user.ID)
+		}
+		if err == nil {
+			err = session.PutInt(w, "pending_epoch", user.Epoch)
+		}
+		if err == nil {
+			err = session.PutTime(w, "pending_at", time.Now())
+		}
+		if err != nil {
+			R.Respond(w, R.InternalError())
+			u.log.Error("Error from session.Put()", zap.Error(err))
+			return
+		}
+		R.Respond(w, R.OkData("Two-factor code required", map[string]bool{"two_factor": true}))
+		return
+	}
+
 	err = session.PutInt(w, "user_id", user.ID)
+	if err == nil {
+		err = session.PutInt(w, "epoch", user.Epoch)
+	}
 	if err != nil {
 		R.Respond(w, R.InternalError())
 		u.log.Error("Error from session.PutInt()", zap.Error(err))
@@ -55,6 +81,68 @@ func (u *UsersAPI) Login(w http.ResponseWriter, r *http.Request) {
 	R.Respond(w, R.Ok("Logged in!"))
 }
 
+// pendingTTL is how long a login waits for its two-factor code
+const pendingTTL = 5 * time.Minute
+
+// LoginTwoFactor finishes a login that's waiting for a two-factor code
+func (u *UsersAPI) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &codeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	session := u.sm.Load(r)
+	at, err := session.GetTime("pending_at")
+	if err != nil || at.IsZero() {
+		R.Respond(w, R.Unauthorized("No login is waiting for a two-factor code"))
+		return
+	}
+	userID, err := session.GetInt("pending_user_id")
+	if err != nil || time.Since(at) > pendingTTL {
+		u.clearPending(w, session)
+		R.Respond(w, R.Unauthorized("Login expired, log in again"))
+		return
+	}
+
+	if err := u.service.VerifyTwoFactor(ctx, userID, req.Code, middleware.ClientIP(r)); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	epoch, err := session.GetInt("pending_epoch")
+	if err == nil {
+		err = u.clearPending(w, session)
+	}
+	if err == nil {
+		err = session.PutInt(w, "user_id", userID)
+	}
+	if err == nil {
+		err = session.PutInt(w, "epoch", epoch)
+	}
+	if err != nil {
+		R.Respond(w, R.InternalError())
+		u.log.Error("Error from session.PutInt()", zap.Error(err))
+		return
+	}
+	R.Respond(w, R.Ok("Logged in!"))
+}
+
+func (u *UsersAPI) clearPending(w http.ResponseWriter, session *scs.Session) error {
+	for _, key := range []string{"pending_user_id", "pending_epoch", "pending_at"} {
+		if err := session.Remove(w, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Register handles user registration request
 func (u *UsersAPI) Register(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -81,6 +169,243 @@ func (u *UsersAPI) Register(w http.ResponseWriter, r *http.Request) {
 	R.Respond(w, R.Ok("Registered!"))
 }
 
+// RequestPasswordReset emails a reset link, it answers the same whether or not the email is registered
+func (u *UsersAPI) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &forgotRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.RequestPasswordReset(ctx, req.Email); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("If an account uses that email, a reset link is on its way"))
+}
+
+// ResetPassword sets a new password using the token from the reset email
+func (u *UsersAPI) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &resetRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Password changed!"))
+}
+
+// VerifyEmail confirms an email using the token from the verification email
+func (u *UsersAPI) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &verifyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.VerifyEmail(ctx, req.Token); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Email verified!"))
+}
+
+// Unlock lifts a login lockout using the token from the lockout email
+func (u *UsersAPI) Unlock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &verifyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.Unlock(ctx, req.Token); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Account unlocked!"))
+}
+
+// ResendVerification sends the logged in user another verification email
+func (u *UsersAPI) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	if err := u.service.ResendVerification(ctx, userID); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Verification email sent"))
+}
+
+// ChangePassword changes the password of the logged in user, other sessions are logged out
+func (u *UsersAPI) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &changePasswordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	epoch, err := u.service.ChangePassword(ctx, userID, req.CurrentPassword, req.Password)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	// keep this session going
+	session := u.sm.Load(r)
+	if err := session.PutInt(w, "epoch", epoch); err != nil {
+		R.Respond(w, R.InternalError())
+		u.log.Error("Error from session.PutInt()", zap.Error(err))
+		return
+	}
+	R.Respond(w, R.Ok("Password changed!"))
+}
+
+// ChangeEmail changes the email of the logged in user and sends a verification to the new one
+func (u *UsersAPI) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &changeEmailRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.ChangeEmail(ctx, userID, req.Password, req.Email); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Email changed, check it for a verification link"))
+}
+
+// Deactivate disables the logged in user's account and logs them out
+func (u *UsersAPI) Deactivate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &passwordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.Deactivate(ctx, userID, req.Password); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	session := u.sm.Load(r)
+	if err := session.Destroy(w); err != nil {
+		u.log.Error("Error from session.Destroy()", zap.Error(err))
+	}
+	R.Respond(w, R.Ok("Account deactivated"))
+}
+
+// EnrollTwoFactor starts setting up 2FA for the logged in user
+func (u *UsersAPI) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	secret, uri, err := u.service.EnrollTwoFactor(ctx, userID)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Add this to your authenticator app, then confirm with a code from it", map[string]string{
+		"secret": secret,
+		"uri":    uri,
+	}))
+}
+
+// ConfirmTwoFactor enables 2FA with a first code and hands out the recovery codes
+func (u *UsersAPI) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &codeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	recovery, err := u.service.ConfirmTwoFactor(ctx, userID, req.Code)
+	if err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.OkData("Two-factor authentication enabled, keep these recovery codes somewhere safe", map[string][]string{
+		"recovery_codes": recovery,
+	}))
+}
+
+// DisableTwoFactor turns 2FA off for the logged in user
+func (u *UsersAPI) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+
+	req := &passwordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+
+	if err := u.service.DisableTwoFactor(ctx, userID, req.Password); err != nil {
+		R.Respond(w, R.Err(err))
+		return
+	}
+	R.Respond(w, R.Ok("Two-factor authentication disabled"))
+}
+
 // Logout clears the session
 func (u *UsersAPI) Logout(w http.ResponseWriter, r *http.Request) {
 	session := u.sm.Load(r)
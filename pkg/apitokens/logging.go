@@ -0,0 +1,71 @@
+package apitokens
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Create(ctx context.Context, userID int, name string, scopes []Scope) (secret string, token *Token, err error) {
+	secret, token, err = m.service.Create(ctx, userID, name, scopes)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from apitokens.Service.Create()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) List(ctx context.Context, userID int) (tokens []*Token, err error) {
+	tokens, err = m.service.List(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from apitokens.Service.List()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Revoke(ctx context.Context, userID, tokenID int) (err error) {
+	err = m.service.Revoke(ctx, userID, tokenID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from apitokens.Service.Revoke()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Authenticate(ctx context.Context, secret string) (token *Token, err error) {
+	token, err = m.service.Authenticate(ctx, secret)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from apitokens.Service.Authenticate()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,82 @@
+package apitokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var policy = bluemonday.StrictPolicy()
+
+var scopes = map[Scope]bool{ScopeRead: true, ScopePost: true, ScopeVote: true}
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for apitokens.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID int, name string, requested []Scope) (string, *Token, error) {
+	if len(requested) == 0 {
+		return "", nil, ErrInvalidScope
+	}
+	// keep scopes in a stable order without duplicates
+	seen := map[Scope]bool{}
+	for _, scope := range requested {
+		if !scopes[scope] {
+			return "", nil, ErrInvalidScope
+		}
+		seen[scope] = true
+	}
+	token := &Token{UserID: userID, Name: policy.Sanitize(name)}
+	for _, scope := range []Scope{ScopeRead, ScopePost, ScopeVote} {
+		if seen[scope] {
+			token.Scopes = append(token.Scopes, scope)
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, errors.E(errors.Internal, errors.Op("rand.Read"), err)
+	}
+	secret := Prefix + base64.RawURLEncoding.EncodeToString(b)
+
+	id, err := s.repo.Create(ctx, token, hash(secret))
+	if err != nil {
+		return "", nil, err
+	}
+	token.ID = id
+	return secret, token, nil
+}
+
+func (s *service) List(ctx context.Context, userID int) ([]*Token, error) {
+	return s.repo.List(ctx, userID)
+}
+
+func (s *service) Revoke(ctx context.Context, userID, tokenID int) error {
+	return s.repo.Revoke(ctx, userID, tokenID)
+}
+
+func (s *service) Authenticate(ctx context.Context, secret string) (*Token, error) {
+	if !strings.HasPrefix(secret, Prefix) {
+		return nil, ErrInvalidToken
+	}
+	return s.repo.Authenticate(ctx, hash(secret))
+}
+
+// hash hashes a secret for storage, secrets are random so a fast hash is fine
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
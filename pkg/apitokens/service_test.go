@@ -0,0 +1,93 @@
+package apitokens
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type mockRepo struct {
+	tokens map[string]*Token
+}
+
+func (r *mockRepo) Create(ctx context.Context, token *Token, hash string) (int, error) {
+	if r.tokens == nil {
+		r.tokens = map[string]*Token{}
+	}
+	r.tokens[hash] = token
+	return len(r.tokens), nil
+}
+
+func (r *mockRepo) List(ctx context.Context, userID int) ([]*Token, error) {
+	tokens := []*Token{}
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *mockRepo) Revoke(ctx context.Context, userID, tokenID int) error {
+	for hash, token := range r.tokens {
+		if token.UserID == userID && token.ID == tokenID {
+			delete(r.tokens, hash)
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+func (r *mockRepo) Authenticate(ctx context.Context, hash string) (*Token, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return token, nil
+}
+
+func TestService_Create(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	secret, token, err := service.Create(ctx, 1, "bot", []Scope{ScopeVote, ScopeRead, ScopeVote})
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(secret, Prefix), qt.Equals, true)
+	c.Assert(token.Scopes, qt.DeepEquals, []Scope{ScopeRead, ScopeVote})
+	// only the hash is stored
+	for hash := range repo.tokens {
+		c.Assert(hash, qt.Not(qt.Equals), secret)
+	}
+
+	found, err := service.Authenticate(ctx, secret)
+	c.Assert(err, qt.IsNil)
+	c.Assert(found.UserID, qt.Equals, 1)
+	c.Assert(found.Has(ScopeVote), qt.Equals, true)
+	c.Assert(found.Has(ScopePost), qt.Equals, false)
+
+	err = service.Revoke(ctx, 1, token.ID)
+	c.Assert(err, qt.IsNil)
+	_, err = service.Authenticate(ctx, secret)
+	c.Assert(err, qt.Equals, ErrInvalidToken)
+}
+
+func TestService_Create_InvalidScope(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{})
+
+	_, _, err := service.Create(context.Background(), 1, "bot", nil)
+	c.Assert(err, qt.Equals, ErrInvalidScope)
+	_, _, err = service.Create(context.Background(), 1, "bot", []Scope{"admin"})
+	c.Assert(err, qt.Equals, ErrInvalidScope)
+}
+
+func TestService_Authenticate_Malformed(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{})
+	_, err := service.Authenticate(context.Background(), "not-a-token")
+	c.Assert(err, qt.Equals, ErrInvalidToken)
+}
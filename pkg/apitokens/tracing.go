@@ -0,0 +1,40 @@
+package apitokens
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Create(ctx context.Context, userID int, name string, scopes []Scope) (string, *Token, error) {
+	ctx, span := trace.StartSpan(ctx, "apitokens.Service.Create")
+	defer span.End()
+	return m.service.Create(ctx, userID, name, scopes)
+}
+
+func (m *tracingMiddleware) List(ctx context.Context, userID int) ([]*Token, error) {
+	ctx, span := trace.StartSpan(ctx, "apitokens.Service.List")
+	defer span.End()
+	return m.service.List(ctx, userID)
+}
+
+func (m *tracingMiddleware) Revoke(ctx context.Context, userID, tokenID int) error {
+	ctx, span := trace.StartSpan(ctx, "apitokens.Service.Revoke")
+	defer span.End()
+	return m.service.Revoke(ctx, userID, tokenID)
+}
+
+func (m *tracingMiddleware) Authenticate(ctx context.Context, secret string) (*Token, error) {
+	ctx, span := trace.StartSpan(ctx, "apitokens.Service.Authenticate")
+	defer span.End()
+	return m.service.Authenticate(ctx, secret)
+}
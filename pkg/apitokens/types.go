@@ -0,0 +1,76 @@
+package apitokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Scope is what a token is allowed to do
+type Scope string
+
+// Supported scopes
+const (
+	// ScopeRead allows reading posts and comments
+	ScopeRead Scope = "read"
+	// ScopePost allows creating, editing and deleting posts and comments
+	ScopePost Scope = "post"
+	// ScopeVote allows voting on posts and comments
+	ScopeVote Scope = "vote"
+)
+
+// Prefix starts every token so they're easy to spot in code and logs
+const Prefix = "upb_"
+
+var (
+	// ErrTokenNotFound for when a user has no such token
+	ErrTokenNotFound = errors.E(errors.NotFound, "Token not found")
+	// ErrInvalidToken is returned on authenticating with a token that doesn't exist or was revoked
+	ErrInvalidToken = errors.E(errors.Unauthorized, "Invalid token")
+	// ErrInvalidScope is returned if no scopes or an unknown scope are asked for
+	ErrInvalidScope = errors.E(errors.Invalid, "Scopes must be some of read, post and vote")
+)
+
+// Token is a named API token, the secret itself is only shown once on creation and stored hashed
+type Token struct {
+	ID       int        `json:"id"`
+	UserID   int        `json:"-"`
+	Name     string     `json:"name"`
+	Scopes   []Scope    `json:"scopes"`
+	Created  time.Time  `json:"created"`
+	LastUsed *time.Time `json:"last_used"`
+}
+
+// Has tells if the token was given a scope
+func (t *Token) Has(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Repository handles storing tokens
+type Repository interface {
+	// Create stores a token with the hash of its secret
+	Create(ctx context.Context, token *Token, hash string) (id int, err error)
+	// List returns a user's tokens that aren't revoked, newest first
+	List(ctx context.Context, userID int) ([]*Token, error)
+	// Revoke revokes a user's token, returns ErrTokenNotFound if they have no such token
+	Revoke(ctx context.Context, userID, tokenID int) error
+	// Authenticate finds the token with a hash and marks it used.
+	// Returns ErrInvalidToken if it's revoked or belongs to a deactivated user.
+	Authenticate(ctx context.Context, hash string) (*Token, error)
+}
+
+// Service manages API tokens and authenticates requests made with them
+type Service interface {
+	// Create makes a new token, the returned secret can't be recovered later
+	Create(ctx context.Context, userID int, name string, scopes []Scope) (secret string, token *Token, err error)
+	List(ctx context.Context, userID int) ([]*Token, error)
+	Revoke(ctx context.Context, userID, tokenID int) error
+	// Authenticate returns the token a secret belongs to
+	Authenticate(ctx context.Context, secret string) (*Token, error)
+}
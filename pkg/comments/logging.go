@@ -0,0 +1,111 @@
+package comments
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Create(ctx context.Context, comment *Comment) (id int, err error) {
+	id, err = m.service.Create(ctx, comment)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Create()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Comments(ctx context.Context, postID, viewerID int) (c []*Comment, err error) {
+	c, err = m.service.Comments(ctx, postID, viewerID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Comments()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Thread(ctx context.Context, q ThreadQuery) (c []*Comment, total int, err error) {
+	c, total, err = m.service.Thread(ctx, q)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Thread()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Tree(ctx context.Context, postID int, opts TreeOptions) (tree *Tree, err error) {
+	tree, err = m.service.Tree(ctx, postID, opts)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Tree()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) PostID(ctx context.Context, commentID int) (postID int, err error) {
+	postID, err = m.service.PostID(ctx, commentID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.PostID()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Delete(ctx context.Context, postID, commentID, authorID int) (err error) {
+	err = m.service.Delete(ctx, postID, commentID, authorID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Delete()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Vote(ctx context.Context, commentID, voterID, delta int) (err error) {
+	err = m.service.Vote(ctx, commentID, voterID, delta)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Vote()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unvote(ctx context.Context, commentID, voterID int) (err error) {
+	err = m.service.Unvote(ctx, commentID, voterID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Unvote()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Score(ctx context.Context, commentID int) (score int, err error) {
+	score, err = m.service.Score(ctx, commentID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from comments.Service.Score()", zap.Error(err))
+	}
+	return
+}
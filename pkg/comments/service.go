@@ -3,35 +3,106 @@ package comments
 import (
 	"context"
 
-	"github.com/microcosm-cc/bluemonday"
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/godwhoa/upboat/pkg/markdown"
 )
 
-var policy = bluemonday.StrictPolicy().AllowElements("br")
-
 type service struct {
-	repo Repository
+	repo   Repository
+	events events.Publisher
+}
+
+// NewService is a constructor for comments.Service, new and deleted comments and score changes are published to publisher
+func NewService(repo Repository, publisher events.Publisher) Service {
+	return &service{
+		repo:   repo,
+		events: publisher,
+	}
 }
 
 func (s *service) Create(ctx context.Context, comment *Comment) (int, error) {
-	comment.Body = policy.Sanitize(comment.Body)
-	return s.repo.Create(ctx, comment)
+	comment.BodyHTML = markdown.Render(comment.Body)
+	id, err := s.repo.Create(ctx, comment)
+	if err != nil {
+		return 0, err
+	}
+	s.events.Publish(ctx, events.Event{
+		Type:      events.CommentCreated,
+		PostID:    comment.PostID,
+		CommentID: id,
+		ParentID:  comment.ParentID,
+	})
+	return id, nil
 }
 
-func (s *service) Comments(ctx context.Context, postID int) ([]*Comment, error) {
-	return s.repo.Comments(ctx, postID)
+func (s *service) PostID(ctx context.Context, commentID int) (int, error) {
+	return s.repo.PostID(ctx, commentID)
 }
 
-func (s *service) Delete(ctx context.Context, commentID, authorID int) error {
-	return s.repo.Delete(ctx, commentID, authorID)
+func (s *service) Comments(ctx context.Context, postID, viewerID int) ([]*Comment, error) {
+	return s.repo.Comments(ctx, postID, viewerID)
+}
+
+func (s *service) Thread(ctx context.Context, q ThreadQuery) ([]*Comment, int, error) {
+	return s.repo.Thread(ctx, q)
+}
+
+func (s *service) Tree(ctx context.Context, postID int, opts TreeOptions) (*Tree, error) {
+	from, err := decodeCursor(postID, opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	c, total, err := s.repo.Thread(ctx, ThreadQuery{
+		PostID:   postID,
+		ParentID: from.ParentID,
+		Offset:   from.Offset,
+		Limit:    clamp(opts.Children, DefaultChildren, MaxChildren),
+		Depth:    clamp(opts.Depth, DefaultDepth, MaxDepth),
+		ViewerID: opts.ViewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(c, from, total), nil
+}
+
+func (s *service) Delete(ctx context.Context, postID, commentID, authorID int) error {
+	if err := s.repo.Delete(ctx, postID, commentID, authorID); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{Type: events.CommentDeleted, PostID: postID, CommentID: commentID})
+	return nil
 }
 
 func (s *service) Vote(ctx context.Context, commentID, voterID, delta int) error {
 	// TODO: commenter should not be able to self-vote their comment
-	return s.repo.Vote(ctx, commentID, voterID, delta)
+	if err := s.repo.Vote(ctx, commentID, voterID, delta); err != nil {
+		return err
+	}
+	s.scoreChanged(ctx, commentID)
+	return nil
 }
 
 func (s *service) Unvote(ctx context.Context, commentID, voterID int) error {
-	return s.repo.Unvote(ctx, commentID, voterID)
+	if err := s.repo.Unvote(ctx, commentID, voterID); err != nil {
+		return err
+	}
+	s.scoreChanged(ctx, commentID)
+	return nil
+}
+
+// scoreChanged publishes the comment's new score.
+// Events are best effort, the vote went through either way and the publisher logs its own failures.
+func (s *service) scoreChanged(ctx context.Context, commentID int) {
+	postID, err := s.repo.PostID(ctx, commentID)
+	if err != nil {
+		return
+	}
+	score, err := s.repo.Score(ctx, commentID)
+	if err != nil {
+		return
+	}
+	s.events.Publish(ctx, events.Event{Type: events.ScoreChanged, PostID: postID, CommentID: commentID, Score: &score})
 }
 
 func (s *service) Score(ctx context.Context, commentID int) (score int, err error) {
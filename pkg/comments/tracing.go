@@ -0,0 +1,70 @@
+package comments
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Create(ctx context.Context, comment *Comment) (id int, err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Create")
+	defer span.End()
+	return m.service.Create(ctx, comment)
+}
+
+func (m *tracingMiddleware) Comments(ctx context.Context, postID, viewerID int) ([]*Comment, error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Comments")
+	defer span.End()
+	return m.service.Comments(ctx, postID, viewerID)
+}
+
+func (m *tracingMiddleware) Thread(ctx context.Context, q ThreadQuery) ([]*Comment, int, error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Thread")
+	defer span.End()
+	return m.service.Thread(ctx, q)
+}
+
+func (m *tracingMiddleware) Tree(ctx context.Context, postID int, opts TreeOptions) (*Tree, error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Tree")
+	defer span.End()
+	return m.service.Tree(ctx, postID, opts)
+}
+
+func (m *tracingMiddleware) PostID(ctx context.Context, commentID int) (postID int, err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.PostID")
+	defer span.End()
+	return m.service.PostID(ctx, commentID)
+}
+
+func (m *tracingMiddleware) Delete(ctx context.Context, postID, commentID, authorID int) (err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Delete")
+	defer span.End()
+	return m.service.Delete(ctx, postID, commentID, authorID)
+}
+
+func (m *tracingMiddleware) Vote(ctx context.Context, commentID, voterID, delta int) (err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Vote")
+	defer span.End()
+	return m.service.Vote(ctx, commentID, voterID, delta)
+}
+
+func (m *tracingMiddleware) Unvote(ctx context.Context, commentID, voterID int) (err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Unvote")
+	defer span.End()
+	return m.service.Unvote(ctx, commentID, voterID)
+}
+
+func (m *tracingMiddleware) Score(ctx context.Context, commentID int) (score int, err error) {
+	ctx, span := trace.StartSpan(ctx, "comments.Service.Score")
+	defer span.End()
+	return m.service.Score(ctx, commentID)
+}
@@ -0,0 +1,149 @@
+package comments
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Limits for nested comment trees
+const (
+	DefaultDepth    = 5
+	MaxDepth        = 10
+	DefaultChildren = 10
+	MaxChildren     = 50
+)
+
+// TreeOptions controls how much of a comment tree is fetched.
+// Cursor comes from a previous Tree's `More` and continues where it was truncated.
+type TreeOptions struct {
+	Depth    int
+	Children int
+	Cursor   string
+	ViewerID int
+}
+
+// Tree is a (possibly truncated) tree of comments
+type Tree struct {
+	Comments []*Node `json:"comments"`
+	// More is set when some top-level comments were left out
+	More *More `json:"more,omitempty"`
+}
+
+// Node is a comment along with its (possibly truncated) replies
+type Node struct {
+	*Comment
+	Children []*Node `json:"children"`
+	// More is set when some replies were left out
+	More *More `json:"more,omitempty"`
+}
+
+// More tells how many comments were left out and the cursor to load them with
+type More struct {
+	Count  int    `json:"count"`
+	Cursor string `json:"cursor"`
+}
+
+// cursor is where a truncated subtree continues from
+type cursor struct {
+	PostID   int  `json:"p"`
+	ParentID *int `json:"c"`
+	Offset   int  `json:"o"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(postID int, s string) (cursor, error) {
+	c := cursor{PostID: postID}
+	if s == "" {
+		return c, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.PostID != postID || c.Offset < 0 {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// clamp returns def for non-positive n and max for anything above max
+func clamp(n, def, max int) int {
+	if n < 1 {
+		return def
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// buildTree nests comments (ordered by depth) under their parents starting from `from`.
+// Comments whose parent wasn't fetched are dropped, as are deleted comments without replies.
+// Subtrees which were cut short get a `More` with a cursor continuing after what was fetched.
+func buildTree(c []*Comment, from cursor, total int) *Tree {
+	tree := &Tree{Comments: []*Node{}}
+	nodes := make(map[int]*Node, len(c))
+	// number of fetched children, including the dropped ones
+	fetched := make(map[int]int, len(c))
+	roots := 0
+
+	for _, comment := range c {
+		node := &Node{Comment: comment, Children: []*Node{}}
+		if sameParent(comment.ParentID, from.ParentID) {
+			nodes[comment.ID] = node
+			roots++
+			tree.Comments = append(tree.Comments, node)
+			continue
+		}
+		parent, ok := nodes[*comment.ParentID]
+		if !ok {
+			continue
+		}
+		nodes[comment.ID] = node
+		fetched[parent.ID]++
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, node := range nodes {
+		if left := node.Replies - fetched[node.ID]; left > 0 {
+			id := node.ID
+			node.More = &More{
+				Count:  left,
+				Cursor: encodeCursor(cursor{PostID: from.PostID, ParentID: &id, Offset: fetched[node.ID]}),
+			}
+		}
+	}
+	tree.Comments = prune(tree.Comments)
+
+	if left := total - from.Offset - roots; left > 0 {
+		tree.More = &More{
+			Count:  left,
+			Cursor: encodeCursor(cursor{PostID: from.PostID, ParentID: from.ParentID, Offset: from.Offset + roots}),
+		}
+	}
+	return tree
+}
+
+// prune drops deleted, removed or blocked comments which have nothing left under them
+func prune(nodes []*Node) []*Node {
+	kept := nodes[:0]
+	for _, node := range nodes {
+		node.Children = prune(node.Children)
+		if (node.Deleted || node.Removed || node.Blocked) && len(node.Children) == 0 && node.More == nil {
+			continue
+		}
+		kept = append(kept, node)
+	}
+	return kept
+}
@@ -0,0 +1,93 @@
+package comments
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func intp(i int) *int {
+	return &i
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	s := encodeCursor(cursor{PostID: 1, ParentID: intp(5), Offset: 10})
+	cur, err := decodeCursor(1, s)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*cur.ParentID, qt.Equals, 5)
+	c.Assert(cur.Offset, qt.Equals, 10)
+
+	// cursor is bound to the post
+	_, err = decodeCursor(2, s)
+	c.Assert(err, qt.Equals, ErrInvalidCursor)
+
+	_, err = decodeCursor(1, "garbage!")
+	c.Assert(err, qt.Equals, ErrInvalidCursor)
+}
+
+func TestBuildTree(t *testing.T) {
+	c := qt.New(t)
+
+	// 1
+	// ├── 2
+	// │   └── 4 (has 3 unfetched replies)
+	// └── 3 (deleted, no replies)
+	// 5 (parent not fetched)
+	list := []*Comment{
+		{ID: 1, Replies: 2},
+		{ID: 2, ParentID: intp(1), Replies: 1},
+		{ID: 3, ParentID: intp(1), Deleted: true},
+		{ID: 4, ParentID: intp(2), Replies: 3},
+		{ID: 5, ParentID: intp(42)},
+	}
+	tree := buildTree(list, cursor{PostID: 1}, 3)
+
+	c.Assert(tree.Comments, qt.HasLen, 1)
+	root := tree.Comments[0]
+	c.Assert(root.ID, qt.Equals, 1)
+	// deleted leaf is dropped but still counted as fetched
+	c.Assert(root.Children, qt.HasLen, 1)
+	c.Assert(root.More, qt.IsNil)
+
+	leaf := root.Children[0].Children[0]
+	c.Assert(leaf.ID, qt.Equals, 4)
+	c.Assert(leaf.More, qt.Not(qt.IsNil))
+	c.Assert(leaf.More.Count, qt.Equals, 3)
+	cur, err := decodeCursor(1, leaf.More.Cursor)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*cur.ParentID, qt.Equals, 4)
+	c.Assert(cur.Offset, qt.Equals, 0)
+
+	// 2 more top-level comments are left
+	c.Assert(tree.More, qt.Not(qt.IsNil))
+	c.Assert(tree.More.Count, qt.Equals, 2)
+	cur, err = decodeCursor(1, tree.More.Cursor)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cur.ParentID, qt.IsNil)
+	c.Assert(cur.Offset, qt.Equals, 1)
+}
+
+// Blocked comments stay as placeholders only while they have replies
+func TestBuildTree_Blocked(t *testing.T) {
+	c := qt.New(t)
+	list := []*Comment{
+		{ID: 1, Replies: 1, Blocked: true},
+		{ID: 2, Blocked: true},
+		{ID: 3, ParentID: intp(1)},
+	}
+	tree := buildTree(list, cursor{PostID: 1}, 2)
+
+	c.Assert(tree.Comments, qt.HasLen, 1)
+	c.Assert(tree.Comments[0].ID, qt.Equals, 1)
+	c.Assert(tree.Comments[0].Children, qt.HasLen, 1)
+	c.Assert(tree.More, qt.IsNil)
+}
+
+func TestClamp(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(clamp(0, DefaultDepth, MaxDepth), qt.Equals, DefaultDepth)
+	c.Assert(clamp(100, DefaultDepth, MaxDepth), qt.Equals, MaxDepth)
+	c.Assert(clamp(3, DefaultDepth, MaxDepth), qt.Equals, 3)
+}
@@ -6,12 +6,26 @@ import (
 	"github.com/godwhoa/upboat/pkg/errors"
 )
 
+// Comment models a comment on a post, ParentID is nil for top-level comments.
+// Body is the Markdown source and BodyHTML is what it renders to.
 type Comment struct {
-	ID          int    `json:"id"`
-	PostID      int    `json:"post_id"`
-	ParentID    *int   `json:"parent_id"`
-	CommenterID int    `json:"author_id"`
-	Body        string `json:"body"`
+	ID          int    `json:"id" db:"id"`
+	PostID      int    `json:"post_id" db:"post_id"`
+	ParentID    *int   `json:"parent_id" db:"parent_id"`
+	CommenterID int    `json:"author_id" db:"commenter_id"`
+	Body        string `json:"body" db:"body"`
+	BodyHTML    string `json:"body_html" db:"body_html"`
+	Depth       int    `json:"depth" db:"depth"`
+	Upvotes     int    `json:"upvotes" db:"upvotes"`
+	Downvotes   int    `json:"downvotes" db:"downvotes"`
+	Score       int    `json:"score" db:"score"`
+	// Replies is the number of direct replies to the comment
+	Replies int `json:"replies" db:"replies"`
+	// Deleted and removed comments are kept in trees as placeholders so their replies stay reachable
+	Deleted bool `json:"deleted" db:"deleted"`
+	Removed bool `json:"removed" db:"removed"`
+	// Blocked comments are by someone the viewer blocked, they are kept as placeholders too
+	Blocked bool `json:"blocked" db:"blocked"`
 }
 
 var (
@@ -21,18 +35,45 @@ var (
 	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
 	// ErrUnauthorized for when a user tries to delete or edit of another user
 	ErrUnauthorized = errors.E(errors.Unauthorized, "Unauthorized to delete/edit the comment.")
+	// ErrPostLocked for when a moderator locked the post
+	ErrPostLocked = errors.E(errors.Unauthorized, "Post is locked")
+	// ErrInvalidCursor for when a continuation cursor is malformed or for another post
+	ErrInvalidCursor = errors.E(errors.Invalid, "Invalid cursor")
 )
 
-// TODO: think about depth and limit of nested comments
+// ThreadQuery selects a slice of a comment tree.
+// Offset and Limit apply to the direct children of ParentID (nil means top-level comments),
+// Limit also caps the replies fetched per comment and Depth caps how many levels are fetched.
+type ThreadQuery struct {
+	PostID   int
+	ParentID *int
+	Offset   int
+	Limit    int
+	Depth    int
+	// ViewerID hides the bodies of comments by users the viewer blocked, 0 for anonymous viewers
+	ViewerID int
+}
+
+// Repository handles storing comments and their votes
 type Repository interface {
 	Create(ctx context.Context, comment *Comment) (id int, err error)
-	Comments(ctx context.Context, postID int) ([]*Comment, error)
-	Delete(ctx context.Context, commentID, authorID int) error
+	// Comments leaves out comments by users viewerID blocked
+	Comments(ctx context.Context, postID, viewerID int) ([]*Comment, error)
+	// Thread fetches comments selected by the query ordered by depth, along with
+	// the total number of children ParentID has.
+	Thread(ctx context.Context, q ThreadQuery) (c []*Comment, total int, err error)
+	// PostID returns the post a comment is on, deleted and removed comments included
+	PostID(ctx context.Context, commentID int) (postID int, err error)
+	// Delete fails with ErrCommentNotFound unless the comment is live and on postID
+	Delete(ctx context.Context, postID, commentID, authorID int) error
 	Vote(ctx context.Context, commentID, voterID, delta int) error
 	Unvote(ctx context.Context, commentID, voterID int) error
 	Score(ctx context.Context, commentID int) (score int, err error)
 }
 
+// Service is a thin layer around Repository which renders Body
 type Service interface {
 	Repository
+	// Tree fetches comments of a post nested by their parent, within the limits of opts.
+	Tree(ctx context.Context, postID int, opts TreeOptions) (*Tree, error)
 }
@@ -0,0 +1,95 @@
+package communities
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Create(ctx context.Context, community *Community) (id int, err error) {
+	id, err = m.service.Create(ctx, community)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.Create()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Get(ctx context.Context, communityID int) (community *Community, err error) {
+	community, err = m.service.Get(ctx, communityID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.Get()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) GetByName(ctx context.Context, name string) (community *Community, err error) {
+	community, err = m.service.GetByName(ctx, name)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.GetByName()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Update(ctx context.Context, community *Community) (err error) {
+	err = m.service.Update(ctx, community)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.Update()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Join(ctx context.Context, communityID, userID int) (err error) {
+	err = m.service.Join(ctx, communityID, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.Join()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Leave(ctx context.Context, communityID, userID int) (err error) {
+	err = m.service.Leave(ctx, communityID, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.Leave()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) IsMember(ctx context.Context, communityID, userID int) (member bool, err error) {
+	member, err = m.service.IsMember(ctx, communityID, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from communities.Service.IsMember()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,35 @@
+package communities
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// Membership is a posts.Middleware which only lets members post in a community
+func Membership(cs Service) posts.Middleware {
+	return func(service posts.Service) posts.Service {
+		return &membershipMiddleware{service, cs}
+	}
+}
+
+type membershipMiddleware struct {
+	posts.Service
+	communities Service
+}
+
+func (m *membershipMiddleware) Create(ctx context.Context, post *posts.Post) (int, error) {
+	if post.CommunityID != nil {
+		if _, err := m.communities.Get(ctx, *post.CommunityID); err != nil {
+			return 0, err
+		}
+		member, err := m.communities.IsMember(ctx, *post.CommunityID, post.AuthorID)
+		if err != nil {
+			return 0, err
+		}
+		if !member {
+			return 0, ErrNotMember
+		}
+	}
+	return m.Service.Create(ctx, post)
+}
@@ -0,0 +1,61 @@
+package communities
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var policy = bluemonday.StrictPolicy().AllowElements("br")
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9_]{3,21}$`)
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for communities.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, community *Community) (int, error) {
+	if !validName.MatchString(community.Name) {
+		return 0, ErrInvalidName
+	}
+	community.Description = policy.Sanitize(community.Description)
+	community.Rules = policy.Sanitize(community.Rules)
+	return s.repo.Create(ctx, community)
+}
+
+func (s *service) Get(ctx context.Context, communityID int) (*Community, error) {
+	return s.repo.Get(ctx, communityID)
+}
+
+func (s *service) GetByName(ctx context.Context, name string) (*Community, error) {
+	if !validName.MatchString(name) {
+		return nil, ErrCommunityNotFound
+	}
+	return s.repo.GetByName(ctx, name)
+}
+
+func (s *service) Update(ctx context.Context, community *Community) error {
+	community.Description = policy.Sanitize(community.Description)
+	community.Rules = policy.Sanitize(community.Rules)
+	return s.repo.Update(ctx, community)
+}
+
+func (s *service) Join(ctx context.Context, communityID, userID int) error {
+	return s.repo.Join(ctx, communityID, userID)
+}
+
+func (s *service) Leave(ctx context.Context, communityID, userID int) error {
+	return s.repo.Leave(ctx, communityID, userID)
+}
+
+func (s *service) IsMember(ctx context.Context, communityID, userID int) (bool, error) {
+	return s.repo.IsMember(ctx, communityID, userID)
+}
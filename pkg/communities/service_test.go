@@ -0,0 +1,39 @@
+package communities
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type mockRepo struct {
+	Repository
+	created *Community
+}
+
+func (r *mockRepo) Create(ctx context.Context, community *Community) (int, error) {
+	r.created = community
+	return 1, nil
+}
+
+func TestService_Create(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	for _, name := range []string{"", "ab", "with space", "dash-ed", "waytoolongforacommunityname"} {
+		_, err := service.Create(ctx, &Community{Name: name})
+		c.Assert(err, qt.Equals, ErrInvalidName, qt.Commentf(name))
+	}
+	c.Assert(repo.created, qt.IsNil)
+
+	id, err := service.Create(ctx, &Community{
+		Name:        "Go_lang",
+		Description: "<script>alert(1)</script>All things Go",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(id, qt.Equals, 1)
+	c.Assert(repo.created.Description, qt.Equals, "All things Go")
+}
@@ -0,0 +1,58 @@
+package communities
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Create(ctx context.Context, community *Community) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.Create")
+	defer span.End()
+	return m.service.Create(ctx, community)
+}
+
+func (m *tracingMiddleware) Get(ctx context.Context, communityID int) (*Community, error) {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.Get")
+	defer span.End()
+	return m.service.Get(ctx, communityID)
+}
+
+func (m *tracingMiddleware) GetByName(ctx context.Context, name string) (*Community, error) {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.GetByName")
+	defer span.End()
+	return m.service.GetByName(ctx, name)
+}
+
+func (m *tracingMiddleware) Update(ctx context.Context, community *Community) error {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.Update")
+	defer span.End()
+	return m.service.Update(ctx, community)
+}
+
+func (m *tracingMiddleware) Join(ctx context.Context, communityID, userID int) error {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.Join")
+	defer span.End()
+	return m.service.Join(ctx, communityID, userID)
+}
+
+func (m *tracingMiddleware) Leave(ctx context.Context, communityID, userID int) error {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.Leave")
+	defer span.End()
+	return m.service.Leave(ctx, communityID, userID)
+}
+
+func (m *tracingMiddleware) IsMember(ctx context.Context, communityID, userID int) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "communities.Service.IsMember")
+	defer span.End()
+	return m.service.IsMember(ctx, communityID, userID)
+}
@@ -0,0 +1,53 @@
+package communities
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Community models a subforum posts can belong to
+type Community struct {
+	ID int `json:"id"`
+	// Name is unique regardless of case and is what communities are addressed by
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Rules       string    `json:"rules"`
+	CreatorID   int       `json:"creator_id"`
+	Members     int       `json:"members"`
+	Created     time.Time `json:"created"`
+}
+
+var (
+	// ErrCommunityNotFound for when a community is not found
+	ErrCommunityNotFound = errors.E(errors.NotFound, "Community not found")
+	// ErrNameTaken for when a community with the same name already exists
+	ErrNameTaken = errors.E(errors.Conflict, "Community name is already taken")
+	// ErrInvalidName for when a name isn't 3-21 letters, digits or underscores
+	ErrInvalidName = errors.E(errors.Invalid, "Community name must be 3-21 letters, digits or underscores")
+	// ErrAlreadyMember for when a user joins a community twice
+	ErrAlreadyMember = errors.E(errors.Conflict, "Already a member of the community")
+	// ErrNotMember for when a user leaves or posts in a community they haven't joined
+	ErrNotMember = errors.E(errors.Unauthorized, "Not a member of the community")
+	// ErrUnauthorized for when a user tries to change the settings of another user's community
+	ErrUnauthorized = errors.E(errors.Unauthorized, "Unauthorized to edit the community")
+)
+
+// Repository handles storing communities and their members
+type Repository interface {
+	// Create stores a community and makes its creator the first member and a moderator
+	Create(ctx context.Context, community *Community) (id int, err error)
+	Get(ctx context.Context, communityID int) (*Community, error)
+	GetByName(ctx context.Context, name string) (*Community, error)
+	// Update changes the settings (description and rules) of a community, only its creator can
+	Update(ctx context.Context, community *Community) error
+	Join(ctx context.Context, communityID, userID int) error
+	Leave(ctx context.Context, communityID, userID int) error
+	IsMember(ctx context.Context, communityID, userID int) (bool, error)
+}
+
+// Service is a thin layer around Repository which validates names and sanitizes settings
+type Service interface {
+	Repository
+}
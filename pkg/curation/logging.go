@@ -0,0 +1,127 @@
+package curation
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) SavePost(ctx context.Context, userID, postID int) (err error) {
+	err = m.service.SavePost(ctx, userID, postID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.SavePost()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) UnsavePost(ctx context.Context, userID, postID int) (err error) {
+	err = m.service.UnsavePost(ctx, userID, postID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.UnsavePost()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) SavedPosts(ctx context.Context, userID int, page Page) (saved *SavedPosts, err error) {
+	saved, err = m.service.SavedPosts(ctx, userID, page)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.SavedPosts()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) SaveComment(ctx context.Context, userID, commentID int) (err error) {
+	err = m.service.SaveComment(ctx, userID, commentID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.SaveComment()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) UnsaveComment(ctx context.Context, userID, commentID int) (err error) {
+	err = m.service.UnsaveComment(ctx, userID, commentID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.UnsaveComment()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) SavedComments(ctx context.Context, userID int, page Page) (saved *SavedComments, err error) {
+	saved, err = m.service.SavedComments(ctx, userID, page)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.SavedComments()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Hide(ctx context.Context, userID, postID int) (err error) {
+	err = m.service.Hide(ctx, userID, postID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.Hide()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unhide(ctx context.Context, userID, postID int) (err error) {
+	err = m.service.Unhide(ctx, userID, postID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.Unhide()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Block(ctx context.Context, userID int, username string) (err error) {
+	err = m.service.Block(ctx, userID, username)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.Block()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unblock(ctx context.Context, userID int, username string) (err error) {
+	err = m.service.Unblock(ctx, userID, username)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.Unblock()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Blocked(ctx context.Context, userID int) (blocked []*Blocked, err error) {
+	blocked, err = m.service.Blocked(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from curation.Service.Blocked()", zap.Error(err))
+	}
+	return
+}
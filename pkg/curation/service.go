@@ -0,0 +1,104 @@
+package curation
+
+import (
+	"context"
+)
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for curation.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// fetch returns the page fetching one extra to know if there are more
+func (p Page) fetch() Page {
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	if p.Limit < 1 {
+		p.Limit = DefaultLimit
+	}
+	if p.Limit > MaxLimit {
+		p.Limit = MaxLimit
+	}
+	p.Limit++
+	return p
+}
+
+func (s *service) SavePost(ctx context.Context, userID, postID int) error {
+	return s.repo.SavePost(ctx, userID, postID)
+}
+
+func (s *service) UnsavePost(ctx context.Context, userID, postID int) error {
+	return s.repo.UnsavePost(ctx, userID, postID)
+}
+
+func (s *service) SavedPosts(ctx context.Context, userID int, page Page) (*SavedPosts, error) {
+	page = page.fetch()
+	list, err := s.repo.SavedPosts(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+	saved := &SavedPosts{Posts: list}
+	if len(list) == page.Limit {
+		saved.Posts, saved.More = list[:page.Limit-1], true
+	}
+	return saved, nil
+}
+
+func (s *service) SaveComment(ctx context.Context, userID, commentID int) error {
+	return s.repo.SaveComment(ctx, userID, commentID)
+}
+
+func (s *service) UnsaveComment(ctx context.Context, userID, commentID int) error {
+	return s.repo.UnsaveComment(ctx, userID, commentID)
+}
+
+func (s *service) SavedComments(ctx context.Context, userID int, page Page) (*SavedComments, error) {
+	page = page.fetch()
+	list, err := s.repo.SavedComments(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+	saved := &SavedComments{Comments: list}
+	if len(list) == page.Limit {
+		saved.Comments, saved.More = list[:page.Limit-1], true
+	}
+	return saved, nil
+}
+
+func (s *service) Hide(ctx context.Context, userID, postID int) error {
+	return s.repo.Hide(ctx, userID, postID)
+}
+
+func (s *service) Unhide(ctx context.Context, userID, postID int) error {
+	return s.repo.Unhide(ctx, userID, postID)
+}
+
+func (s *service) Block(ctx context.Context, userID int, username string) error {
+	blockedID, err := s.repo.UserID(ctx, username)
+	if err != nil {
+		return err
+	}
+	if blockedID == userID {
+		return ErrBlockSelf
+	}
+	return s.repo.Block(ctx, userID, blockedID)
+}
+
+func (s *service) Unblock(ctx context.Context, userID int, username string) error {
+	blockedID, err := s.repo.UserID(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.repo.Unblock(ctx, userID, blockedID)
+}
+
+func (s *service) Blocked(ctx context.Context, userID int) ([]*Blocked, error) {
+	return s.repo.Blocked(ctx, userID)
+}
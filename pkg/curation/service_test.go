@@ -0,0 +1,78 @@
+package curation
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// mockRepo knows users pacninja(1) and lala(2) and has 3 saved posts
+type mockRepo struct {
+	Repository
+	blocked map[int]bool
+}
+
+func (r *mockRepo) UserID(ctx context.Context, username string) (int, error) {
+	ids := map[string]int{"pacninja": 1, "lala": 2}
+	if id, ok := ids[username]; ok {
+		return id, nil
+	}
+	return 0, ErrUserNotFound
+}
+
+func (r *mockRepo) Block(ctx context.Context, userID, blockedID int) error {
+	if r.blocked == nil {
+		r.blocked = map[int]bool{}
+	}
+	r.blocked[blockedID] = true
+	return nil
+}
+
+func (r *mockRepo) SavedPosts(ctx context.Context, userID int, page Page) ([]*posts.Post, error) {
+	saved := []*posts.Post{{ID: 3}, {ID: 2}, {ID: 1}}
+	if page.Offset > len(saved) {
+		page.Offset = len(saved)
+	}
+	saved = saved[page.Offset:]
+	if len(saved) > page.Limit {
+		saved = saved[:page.Limit]
+	}
+	return saved, nil
+}
+
+func TestService_Block(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	c.Assert(service.Block(ctx, 1, "pacninja"), qt.Equals, ErrBlockSelf)
+	c.Assert(service.Block(ctx, 1, "nobody"), qt.Equals, ErrUserNotFound)
+	c.Assert(service.Block(ctx, 1, "lala"), qt.IsNil)
+	c.Assert(repo.blocked, qt.DeepEquals, map[int]bool{2: true})
+}
+
+func TestService_SavedPosts(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	service := NewService(&mockRepo{})
+
+	saved, err := service.SavedPosts(ctx, 1, Page{Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Assert(saved.Posts, qt.HasLen, 2)
+	c.Assert(saved.More, qt.Equals, true)
+
+	saved, err = service.SavedPosts(ctx, 1, Page{Offset: 2, Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Assert(saved.Posts, qt.HasLen, 1)
+	c.Assert(saved.More, qt.Equals, false)
+}
+
+func TestPage_Fetch(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(Page{}.fetch().Limit, qt.Equals, DefaultLimit+1)
+	c.Assert(Page{Limit: MaxLimit + 1}.fetch().Limit, qt.Equals, MaxLimit+1)
+	c.Assert(Page{Offset: -1, Limit: 10}.fetch(), qt.Equals, Page{Limit: 11})
+}
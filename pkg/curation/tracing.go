@@ -0,0 +1,82 @@
+package curation
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) SavePost(ctx context.Context, userID, postID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.SavePost")
+	defer span.End()
+	return m.service.SavePost(ctx, userID, postID)
+}
+
+func (m *tracingMiddleware) UnsavePost(ctx context.Context, userID, postID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.UnsavePost")
+	defer span.End()
+	return m.service.UnsavePost(ctx, userID, postID)
+}
+
+func (m *tracingMiddleware) SavedPosts(ctx context.Context, userID int, page Page) (*SavedPosts, error) {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.SavedPosts")
+	defer span.End()
+	return m.service.SavedPosts(ctx, userID, page)
+}
+
+func (m *tracingMiddleware) SaveComment(ctx context.Context, userID, commentID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.SaveComment")
+	defer span.End()
+	return m.service.SaveComment(ctx, userID, commentID)
+}
+
+func (m *tracingMiddleware) UnsaveComment(ctx context.Context, userID, commentID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.UnsaveComment")
+	defer span.End()
+	return m.service.UnsaveComment(ctx, userID, commentID)
+}
+
+func (m *tracingMiddleware) SavedComments(ctx context.Context, userID int, page Page) (*SavedComments, error) {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.SavedComments")
+	defer span.End()
+	return m.service.SavedComments(ctx, userID, page)
+}
+
+func (m *tracingMiddleware) Hide(ctx context.Context, userID, postID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.Hide")
+	defer span.End()
+	return m.service.Hide(ctx, userID, postID)
+}
+
+func (m *tracingMiddleware) Unhide(ctx context.Context, userID, postID int) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.Unhide")
+	defer span.End()
+	return m.service.Unhide(ctx, userID, postID)
+}
+
+func (m *tracingMiddleware) Block(ctx context.Context, userID int, username string) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.Block")
+	defer span.End()
+	return m.service.Block(ctx, userID, username)
+}
+
+func (m *tracingMiddleware) Unblock(ctx context.Context, userID int, username string) error {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.Unblock")
+	defer span.End()
+	return m.service.Unblock(ctx, userID, username)
+}
+
+func (m *tracingMiddleware) Blocked(ctx context.Context, userID int) ([]*Blocked, error) {
+	ctx, span := trace.StartSpan(ctx, "curation.Service.Blocked")
+	defer span.End()
+	return m.service.Blocked(ctx, userID)
+}
@@ -0,0 +1,89 @@
+package curation
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// Limits for a page of saved, hidden or blocked things
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
+// Page selects a page of an user's list, most recently added first
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// SavedPosts is a page of saved posts, More tells if there are further pages
+type SavedPosts struct {
+	Posts []*posts.Post `json:"posts"`
+	More  bool          `json:"more"`
+}
+
+// SavedComments is a page of saved comments, More tells if there are further pages
+type SavedComments struct {
+	Comments []*comments.Comment `json:"comments"`
+	More     bool                `json:"more"`
+}
+
+// Blocked is an user someone blocked
+type Blocked struct {
+	UserID   int       `json:"user_id"`
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
+
+var (
+	// ErrPostNotFound for when saving or hiding a post that doesn't exist
+	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
+	// ErrCommentNotFound for when saving a comment that doesn't exist
+	ErrCommentNotFound = errors.E(errors.NotFound, "Comment not found")
+	// ErrUserNotFound for when blocking an user that doesn't exist
+	ErrUserNotFound = errors.E(errors.NotFound, "User not found")
+	// ErrBlockSelf for when an user tries to block themselves
+	ErrBlockSelf = errors.E(errors.Invalid, "You can't block yourself")
+)
+
+// Repository handles storing what users saved, hid and blocked.
+// Adding something twice or removing something that isn't there is not an error.
+type Repository interface {
+	SavePost(ctx context.Context, userID, postID int) error
+	UnsavePost(ctx context.Context, userID, postID int) error
+	// SavedPosts leaves out posts which were deleted or removed since
+	SavedPosts(ctx context.Context, userID int, page Page) ([]*posts.Post, error)
+	SaveComment(ctx context.Context, userID, commentID int) error
+	UnsaveComment(ctx context.Context, userID, commentID int) error
+	// SavedComments leaves out comments which were deleted or removed since
+	SavedComments(ctx context.Context, userID int, page Page) ([]*comments.Comment, error)
+	Hide(ctx context.Context, userID, postID int) error
+	Unhide(ctx context.Context, userID, postID int) error
+	// UserID looks up an active user by username
+	UserID(ctx context.Context, username string) (userID int, err error)
+	Block(ctx context.Context, userID, blockedID int) error
+	Unblock(ctx context.Context, userID, blockedID int) error
+	Blocked(ctx context.Context, userID int) ([]*Blocked, error)
+}
+
+// Service lets users keep posts and comments for later, hide posts from their feeds
+// and block users whose posts and comments they don't want to see.
+// Listings and comment trees do the filtering themselves given the viewer.
+type Service interface {
+	SavePost(ctx context.Context, userID, postID int) error
+	UnsavePost(ctx context.Context, userID, postID int) error
+	SavedPosts(ctx context.Context, userID int, page Page) (*SavedPosts, error)
+	SaveComment(ctx context.Context, userID, commentID int) error
+	UnsaveComment(ctx context.Context, userID, commentID int) error
+	SavedComments(ctx context.Context, userID int, page Page) (*SavedComments, error)
+	Hide(ctx context.Context, userID, postID int) error
+	Unhide(ctx context.Context, userID, postID int) error
+	Block(ctx context.Context, userID int, username string) error
+	Unblock(ctx context.Context, userID int, username string) error
+	Blocked(ctx context.Context, userID int) ([]*Blocked, error)
+}
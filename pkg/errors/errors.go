@@ -47,12 +47,13 @@ type Kind uint8
 
 // Various kinds of errors
 const (
-	Other        Kind = iota // Unclassified error
-	Internal                 // Internal error
-	Conflict                 // Conflict when an entity already exists
-	Invalid                  // Invalid input, validation error etc
-	NotFound                 // Entity does not exist
-	Unauthorized             // Unauthorized to perform an action
+	Other           Kind = iota // Unclassified error
+	Internal                    // Internal error
+	Conflict                    // Conflict when an entity already exists
+	Invalid                     // Invalid input, validation error etc
+	NotFound                    // Entity does not exist
+	Unauthorized                // Unauthorized to perform an action
+	TooManyRequests             // Too many attempts, try again later
 )
 
 func (k Kind) String() string {
@@ -61,12 +62,16 @@ func (k Kind) String() string {
 		return "unclassified error"
 	case Internal:
 		return "internal error"
+	case Conflict:
+		return "conflict"
 	case Invalid:
 		return "invalid input"
 	case NotFound:
 		return "entity not found"
 	case Unauthorized:
 		return "unauthorized"
+	case TooManyRequests:
+		return "too many requests"
 	default:
 		return "unknown error kind"
 	}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Buffer is how many events a subscriber can fall behind by before it misses some
+const Buffer = 32
+
+// Hub delivers events to subscribers within this process.
+// Publishing never blocks, a subscriber that can't keep up misses events rather than holding up the rest.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[int]map[chan Event]struct{}
+}
+
+// NewHub is a constructor
+func NewHub() *Hub {
+	return &Hub{subs: map[int]map[chan Event]struct{}{}}
+}
+
+func (h *Hub) Publish(ctx context.Context, e Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[e.PostID] {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *Hub) Subscribe(postID int) (<-chan Event, func()) {
+	ch := make(chan Event, Buffer)
+	h.mu.Lock()
+	if h.subs[postID] == nil {
+		h.subs[postID] = map[chan Event]struct{}{}
+	}
+	h.subs[postID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs[postID], ch)
+			if len(h.subs[postID]) == 0 {
+				delete(h.subs, postID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Subscribers is how many subscribers a post has
+func (h *Hub) Subscribers(postID int) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[postID])
+}
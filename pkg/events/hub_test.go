@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHub(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hub := NewHub()
+
+	a, cancelA := hub.Subscribe(1)
+	b, cancelB := hub.Subscribe(1)
+	other, cancelOther := hub.Subscribe(2)
+	defer cancelOther()
+	c.Assert(hub.Subscribers(1), qt.Equals, 2)
+
+	score := 5
+	e := Event{Type: ScoreChanged, PostID: 1, Score: &score}
+	c.Assert(hub.Publish(ctx, e), qt.IsNil)
+	c.Assert(<-a, qt.DeepEquals, e)
+	c.Assert(<-b, qt.DeepEquals, e)
+	// only subscribers of the post get it
+	c.Assert(other, qt.HasLen, 0)
+
+	cancelA()
+	cancelA()
+	_, open := <-a
+	c.Assert(open, qt.Equals, false)
+	c.Assert(hub.Subscribers(1), qt.Equals, 1)
+	cancelB()
+	c.Assert(hub.Subscribers(1), qt.Equals, 0)
+	c.Assert(hub.Publish(ctx, e), qt.IsNil)
+}
+
+// A subscriber that isn't reading misses events instead of blocking publishers
+func TestHub_SlowSubscriber(t *testing.T) {
+	c := qt.New(t)
+	hub := NewHub()
+	ch, cancel := hub.Subscribe(1)
+	defer cancel()
+
+	for i := 0; i < Buffer+10; i++ {
+		c.Assert(hub.Publish(context.Background(), Event{Type: CommentCreated, PostID: 1, CommentID: i}), qt.IsNil)
+	}
+	c.Assert(ch, qt.HasLen, Buffer)
+	c.Assert((<-ch).CommentID, qt.Equals, 0)
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Logging wraps a Publisher to log failed publishes.
+// Events are best effort, so callers carry on regardless and this is where failures show up.
+func Logging(p Publisher, log *zap.Logger) Publisher {
+	return &loggingPublisher{p, log}
+}
+
+type loggingPublisher struct {
+	publisher Publisher
+	log       *zap.Logger
+}
+
+func (m *loggingPublisher) Publish(ctx context.Context, e Event) (err error) {
+	err = m.publisher.Publish(ctx, e)
+	if err != nil {
+		m.log.Error("Error from events.Publisher.Publish()", zap.String("type", e.Type), zap.Error(err))
+	}
+	return
+}
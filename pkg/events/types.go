@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+)
+
+// Kinds of events, they're also the SSE event names
+const (
+	CommentCreated = "comment-created"
+	CommentDeleted = "comment-deleted"
+	ScoreChanged   = "score-changed"
+	PostDeleted    = "post-deleted"
+)
+
+// Event is something that happened in a post's thread.
+// It only carries ids and scores, clients fetch what they need to show,
+// which also keeps events under the size limit of a Postgres notification.
+type Event struct {
+	Type   string `json:"type"`
+	PostID int    `json:"post_id"`
+	// CommentID is 0 for score changes of the post itself
+	CommentID int  `json:"comment_id,omitempty"`
+	ParentID  *int `json:"parent_id,omitempty"`
+	Score     *int `json:"score,omitempty"`
+}
+
+// Publisher sends events to whoever is subscribed to the post
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Subscriber streams the events of a post until cancel is called
+type Subscriber interface {
+	Subscribe(postID int) (events <-chan Event, cancel func())
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// File writes emails to a directory as .eml files instead of sending them, it's meant for local development
+type File struct {
+	dir  string
+	from string
+	seq  uint64
+}
+
+// NewFile is a constructor, dir is created if it doesn't exist
+func NewFile(dir, from string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir, from: from}, nil
+}
+
+// Send writes msg to a new file named after the time it was sent
+func (m *File) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%d.eml", now.Format("20060102150405"), atomic.AddUint64(&m.seq, 1))
+	err := ioutil.WriteFile(filepath.Join(m.dir, name), format(m.from, msg, now), 0644)
+	if err != nil {
+		return errors.E(errors.Internal, errors.Op("mail.File.Send"), err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// header drops line breaks so values can't inject headers of their own
+var header = strings.NewReplacer("\r", "", "\n", "").Replace
+
+// format renders a message with its headers as it goes over the wire
+func format(from string, msg *Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", header(from))
+	fmt.Fprintf(&buf, "To: %s\r\n", header(msg.To))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", header(msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	return buf.Bytes()
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMemory(t *testing.T) {
+	c := qt.New(t)
+	m := NewMemory()
+	c.Assert(m.Last(), qt.IsNil)
+
+	err := m.Send(context.Background(), &Message{To: "pac@pac.com", Subject: "Hi"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(m.Sent(), qt.HasLen, 1)
+	c.Assert(m.Last().To, qt.Equals, "pac@pac.com")
+}
+
+func TestFile(t *testing.T) {
+	c := qt.New(t)
+	dir, err := ioutil.TempDir("", "mail")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	m, err := NewFile(filepath.Join(dir, "outbox"), "upboat@localhost")
+	c.Assert(err, qt.IsNil)
+	for i := 0; i < 2; i++ {
+		err = m.Send(context.Background(), &Message{To: "pac@pac.com", Subject: "Reset", Body: "token"})
+		c.Assert(err, qt.IsNil)
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "outbox"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 2)
+	raw, err := ioutil.ReadFile(filepath.Join(dir, "outbox", files[0].Name()))
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(string(raw), "From: upboat@localhost\r\nTo: pac@pac.com\r\nSubject: Reset\r\n"), qt.Equals, true)
+	c.Assert(strings.HasSuffix(string(raw), "\r\n\r\ntoken"), qt.Equals, true)
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps sent emails around, it's meant for tests
+type Memory struct {
+	mu   sync.Mutex
+	sent []*Message
+}
+
+// NewMemory is a constructor
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Send stores msg
+func (m *Memory) Send(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the emails sent so far, oldest first
+func (m *Memory) Sent() []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Message(nil), m.sent...)
+}
+
+// Last returns the latest email sent, nil if there's none
+func (m *Memory) Last() *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sent) == 0 {
+		return nil
+	}
+	return m.sent[len(m.sent)-1]
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// SMTPOptions holds information for connecting to a SMTP server
+type SMTPOptions struct {
+	// Addr is host:port of the server
+	Addr string
+	User string
+	Pass string
+	From string
+}
+
+// SMTP sends emails through a SMTP server
+type SMTP struct {
+	opts SMTPOptions
+	auth smtp.Auth
+}
+
+// NewSMTP is a constructor, it uses PLAIN auth when a user is set
+func NewSMTP(opts SMTPOptions) Mailer {
+	m := &SMTP{opts: opts}
+	if opts.User != "" {
+		host, _, _ := net.SplitHostPort(opts.Addr)
+		m.auth = smtp.PlainAuth("", opts.User, opts.Pass, host)
+	}
+	return m
+}
+
+// Send sends msg, the context is only checked before connecting since net/smtp doesn't take one
+func (m *SMTP) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := smtp.SendMail(m.opts.Addr, m.auth, m.opts.From, []string{msg.To}, format(m.opts.From, msg, time.Now()))
+	if err != nil {
+		return errors.E(errors.Internal, errors.Op("smtp.SendMail"), err)
+	}
+	return nil
+}
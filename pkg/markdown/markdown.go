@@ -0,0 +1,36 @@
+// Package markdown renders the Markdown users write posts and comments in to HTML that is safe to display.
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+)
+
+// extensions are what's rendered besides plain Markdown, raw HTML is left to the policy
+const extensions = blackfriday.NoIntraEmphasis | blackfriday.FencedCode | blackfriday.Autolink |
+	blackfriday.Strikethrough | blackfriday.SpaceHeadings | blackfriday.BackslashLineBreak
+
+// language is the class blackfriday gives fenced code blocks
+var language = regexp.MustCompile(`^language-[\w+#-]+$`)
+
+// policy allows the formatting Markdown produces and nothing else,
+// links may only be http(s) or mailto and get rel="nofollow"
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "hr", "em", "strong", "del", "blockquote", "pre", "code",
+		"ul", "ol", "li", "h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(language).OnElements("code")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowStandardURLs()
+	return p
+}
+
+// Render turns Markdown source into sanitized HTML
+func Render(source string) string {
+	html := blackfriday.Run([]byte(source), blackfriday.WithExtensions(extensions))
+	return string(policy.SanitizeBytes(html))
+}
@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRender(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		source string
+		html   string
+	}{
+		{"Hello *world*", "<p>Hello <em>world</em></p>"},
+		{"[link](https://example.com)", `<p><a href="https://example.com" rel="nofollow">link</a></p>`},
+		{"> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>"},
+		{"- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>"},
+		{"1. c\n2. d", "<ol>\n<li>c</li>\n<li>d</li>\n</ol>"},
+		{"```go\nfmt.Println(\"<hi>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>"},
+		{"line\\\nbreak", "<p>line<br/>\nbreak</p>"},
+		// raw HTML and unsafe links don't survive
+		{"<script>alert(1)</script>hi", "<p>hi</p>"},
+		{`<img src=x onerror="alert(1)">`, "<p></p>"},
+		{"[click](javascript:void)", "<p>click</p>"},
+		{`<a href="https://example.com" onclick="alert(1)">x</a>`, `<p><a href="https://example.com" rel="nofollow">x</a></p>`},
+	}
+	for _, test := range tests {
+		c.Assert(strings.TrimSpace(Render(test.source)), qt.Equals, test.html, qt.Commentf(test.source))
+	}
+}
@@ -0,0 +1,55 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Moderate(ctx context.Context, entry *Entry) (err error) {
+	err = m.service.Moderate(ctx, entry)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from moderation.Service.Moderate()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Log(ctx context.Context, q LogQuery) (page *LogPage, err error) {
+	page, err = m.service.Log(ctx, q)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from moderation.Service.Log()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var policy = bluemonday.StrictPolicy()
+
+// removals need a reason, moderator changes pick their community themselves
+var (
+	removals = map[Action]bool{RemovePost: true, RemoveComment: true}
+	scoped   = map[Action]bool{AddModerator: true, RemoveModerator: true}
+	actions  = map[Action]bool{
+		RemovePost: true, RestorePost: true, LockPost: true, UnlockPost: true,
+		RemoveComment: true, RestoreComment: true, AddModerator: true, RemoveModerator: true,
+	}
+)
+
+type service struct {
+	repo   Repository
+	events events.Publisher
+}
+
+// NewService is a constructor for moderation.Service, removals are published to publisher
+// like the author deleting the post or comment would be
+func NewService(repo Repository, publisher events.Publisher) Service {
+	return &service{
+		repo:   repo,
+		events: publisher,
+	}
+}
+
+func (s *service) Moderate(ctx context.Context, entry *Entry) error {
+	if !actions[entry.Action] {
+		return ErrUnknownAction
+	}
+	entry.Reason = policy.Sanitize(entry.Reason)
+	if removals[entry.Action] && entry.Reason == "" {
+		return ErrReasonRequired
+	}
+
+	postID := 0
+	if !scoped[entry.Action] {
+		communityID, target, err := s.repo.Target(ctx, entry.Action, entry.TargetID)
+		if err != nil {
+			return err
+		}
+		entry.CommunityID, postID = communityID, target
+	}
+	ok, err := s.repo.IsModerator(ctx, entry.ModeratorID, entry.CommunityID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotModerator
+	}
+	if err := s.repo.Act(ctx, entry); err != nil {
+		return err
+	}
+
+	switch entry.Action {
+	case RemovePost:
+		s.events.Publish(ctx, events.Event{Type: events.PostDeleted, PostID: postID})
+	case RemoveComment:
+		s.events.Publish(ctx, events.Event{Type: events.CommentDeleted, PostID: postID, CommentID: entry.TargetID})
+	}
+	return nil
+}
+
+func (s *service) Log(ctx context.Context, q LogQuery) (*LogPage, error) {
+	if q.Limit < 1 || q.Limit > MaxLimit {
+		q.Limit = DefaultLimit
+	}
+	limit := q.Limit
+	q.Limit++ // one extra to know if there's a next page
+
+	entries, err := s.repo.Log(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &LogPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.Next = page.Entries[limit-1].ID
+	}
+	return page, nil
+}
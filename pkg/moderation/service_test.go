@@ -0,0 +1,116 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/events"
+)
+
+// mockRepo has user 1 as a global moderator and user 2 moderating community 10,
+// post 100 is in community 10 and post 200 in community 20, comment 1001 is on post 100
+type mockRepo struct {
+	acted []*Entry
+}
+
+type mockPublisher struct {
+	published []events.Event
+}
+
+func (p *mockPublisher) Publish(ctx context.Context, e events.Event) error {
+	p.published = append(p.published, e)
+	return nil
+}
+
+func (r *mockRepo) IsModerator(ctx context.Context, userID int, communityID *int) (bool, error) {
+	if userID == 1 {
+		return true, nil
+	}
+	return userID == 2 && communityID != nil && *communityID == 10, nil
+}
+
+func (r *mockRepo) Target(ctx context.Context, action Action, targetID int) (*int, int, error) {
+	postID := targetID
+	if action == RemoveComment || action == RestoreComment {
+		postID = targetID / 10
+	}
+	community := postID / 10
+	return &community, postID, nil
+}
+
+func (r *mockRepo) Act(ctx context.Context, entry *Entry) error {
+	r.acted = append(r.acted, entry)
+	return nil
+}
+
+func (r *mockRepo) Log(ctx context.Context, q LogQuery) ([]*Entry, error) {
+	entries := []*Entry{}
+	for id := 10; id > 0 && len(entries) < q.Limit; id-- {
+		entries = append(entries, &Entry{ID: id})
+	}
+	return entries, nil
+}
+
+func TestService_Moderate(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo, &mockPublisher{})
+
+	err := service.Moderate(ctx, &Entry{ModeratorID: 2, Action: "ban", TargetID: 100})
+	c.Assert(err, qt.Equals, ErrUnknownAction)
+
+	err = service.Moderate(ctx, &Entry{ModeratorID: 2, Action: RemovePost, TargetID: 100, Reason: "<b></b>"})
+	c.Assert(err, qt.Equals, ErrReasonRequired)
+
+	// community moderators only moderate their community
+	err = service.Moderate(ctx, &Entry{ModeratorID: 2, Action: LockPost, TargetID: 200})
+	c.Assert(err, qt.Equals, ErrNotModerator)
+	err = service.Moderate(ctx, &Entry{ModeratorID: 2, Action: AddModerator, TargetID: 3})
+	c.Assert(err, qt.Equals, ErrNotModerator)
+	c.Assert(repo.acted, qt.HasLen, 0)
+
+	err = service.Moderate(ctx, &Entry{ModeratorID: 2, Action: RemovePost, TargetID: 100, Reason: "spam"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*repo.acted[0].CommunityID, qt.Equals, 10)
+
+	// global moderators moderate everything
+	err = service.Moderate(ctx, &Entry{ModeratorID: 1, Action: LockPost, TargetID: 200})
+	c.Assert(err, qt.IsNil)
+	err = service.Moderate(ctx, &Entry{ModeratorID: 1, Action: AddModerator, TargetID: 3})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.acted, qt.HasLen, 3)
+	c.Assert(repo.acted[2].CommunityID, qt.IsNil)
+}
+
+// Removals reach thread subscribers like deletes by the author do
+func TestService_Moderate_Events(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	publisher := &mockPublisher{}
+	service := NewService(&mockRepo{}, publisher)
+
+	c.Assert(service.Moderate(ctx, &Entry{ModeratorID: 2, Action: RemoveComment, TargetID: 1001, Reason: "spam"}), qt.IsNil)
+	c.Assert(service.Moderate(ctx, &Entry{ModeratorID: 2, Action: LockPost, TargetID: 100}), qt.IsNil)
+	c.Assert(service.Moderate(ctx, &Entry{ModeratorID: 2, Action: RemovePost, TargetID: 100, Reason: "spam"}), qt.IsNil)
+	c.Assert(publisher.published, qt.DeepEquals, []events.Event{
+		{Type: events.CommentDeleted, PostID: 100, CommentID: 1001},
+		{Type: events.PostDeleted, PostID: 100},
+	})
+}
+
+func TestService_Log(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{}, &mockPublisher{})
+
+	page, err := service.Log(context.Background(), LogQuery{Limit: 4})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Entries, qt.HasLen, 4)
+	c.Assert(page.Next, qt.Equals, 7)
+
+	page, err = service.Log(context.Background(), LogQuery{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Entries, qt.HasLen, 10)
+	c.Assert(page.Next, qt.Equals, 0)
+}
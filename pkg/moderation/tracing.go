@@ -0,0 +1,28 @@
+package moderation
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Moderate(ctx context.Context, entry *Entry) error {
+	ctx, span := trace.StartSpan(ctx, "moderation.Service.Moderate")
+	defer span.End()
+	return m.service.Moderate(ctx, entry)
+}
+
+func (m *tracingMiddleware) Log(ctx context.Context, q LogQuery) (*LogPage, error) {
+	ctx, span := trace.StartSpan(ctx, "moderation.Service.Log")
+	defer span.End()
+	return m.service.Log(ctx, q)
+}
@@ -0,0 +1,97 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Action is something a moderator did
+type Action string
+
+// Moderation actions, TargetID of an Entry is a post, comment or user ID depending on the action
+const (
+	RemovePost      Action = "remove_post"
+	RestorePost     Action = "restore_post"
+	LockPost        Action = "lock_post"
+	UnlockPost      Action = "unlock_post"
+	RemoveComment   Action = "remove_comment"
+	RestoreComment  Action = "restore_comment"
+	AddModerator    Action = "add_moderator"
+	RemoveModerator Action = "remove_moderator"
+)
+
+// Limits for a page of the moderation log
+const (
+	DefaultLimit = 50
+	MaxLimit     = 100
+)
+
+// Entry is a record of an action in the moderation log.
+// CommunityID is nil for actions outside of any community, like managing global moderators.
+// ModeratorID is 0 for actions taken from the command line.
+type Entry struct {
+	ID          int       `json:"id"`
+	ModeratorID int       `json:"moderator_id"`
+	CommunityID *int      `json:"community_id"`
+	Action      Action    `json:"action"`
+	TargetID    int       `json:"target_id"`
+	Reason      string    `json:"reason"`
+	Created     time.Time `json:"created"`
+}
+
+var (
+	// ErrNotModerator for when a user acts on something they don't moderate
+	ErrNotModerator = errors.E(errors.Unauthorized, "Not a moderator")
+	// ErrUnknownAction for when an action isn't one of the moderation actions
+	ErrUnknownAction = errors.E(errors.Invalid, "Unknown moderation action")
+	// ErrReasonRequired for when content is removed without a reason
+	ErrReasonRequired = errors.E(errors.Invalid, "A reason is required for removals")
+	// ErrNoChange for when the target is already in the state the action would put it in
+	ErrNoChange = errors.E(errors.Conflict, "Nothing to do, the action was already applied")
+	// ErrPostNotFound for when post is not found.
+	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
+	// ErrCommentNotFound for when comment is not found.
+	ErrCommentNotFound = errors.E(errors.NotFound, "Comment not found")
+	// ErrUserNotFound for when a moderator is added for a user that doesn't exist
+	ErrUserNotFound = errors.E(errors.NotFound, "User not found")
+	// ErrCommunityNotFound for when a moderator is added to a community that doesn't exist
+	ErrCommunityNotFound = errors.E(errors.NotFound, "Community not found")
+)
+
+// LogQuery selects a page of the moderation log, newest first.
+// CommunityID and ModeratorID filter entries when they're non-zero,
+// Before is the ID of the last entry on the previous page, 0 for the first page.
+type LogQuery struct {
+	CommunityID int
+	ModeratorID int
+	Before      int
+	Limit       int
+}
+
+// LogPage is a page of the moderation log, Next is passed as Before to fetch the following page
+type LogPage struct {
+	Entries []*Entry `json:"entries"`
+	Next    int      `json:"next,omitempty"`
+}
+
+// Repository handles moderators, the moderation log and applying actions
+type Repository interface {
+	// IsModerator checks if a user moderates a community, nil for global moderators.
+	// Global moderators moderate every community.
+	IsModerator(ctx context.Context, userID int, communityID *int) (bool, error)
+	// Target fetches the community of the post or comment an action targets, along with the post's ID
+	Target(ctx context.Context, action Action, targetID int) (communityID *int, postID int, err error)
+	// Act applies an action and appends it to the log in the same transaction
+	Act(ctx context.Context, entry *Entry) error
+	Log(ctx context.Context, q LogQuery) ([]*Entry, error)
+}
+
+// Service checks that moderators only act on what they moderate
+type Service interface {
+	// Moderate applies an action taken by entry.ModeratorID, CommunityID is filled in from the target
+	// except for adding or removing moderators where it picks the community.
+	Moderate(ctx context.Context, entry *Entry) error
+	Log(ctx context.Context, q LogQuery) (*LogPage, error)
+}
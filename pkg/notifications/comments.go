@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"go.uber.org/zap"
+)
+
+// OnComment is a comments.Middleware which notifies about a comment right after it's created.
+// Failing to notify doesn't fail the request, it gets logged instead.
+func OnComment(ns Service, log *zap.Logger) comments.Middleware {
+	return func(service comments.Service) comments.Service {
+		return &commentsMiddleware{service, ns, log}
+	}
+}
+
+type commentsMiddleware struct {
+	comments.Service
+	notifications Service
+	log           *zap.Logger
+}
+
+func (m *commentsMiddleware) Create(ctx context.Context, comment *comments.Comment) (id int, err error) {
+	id, err = m.Service.Create(ctx, comment)
+	if err == nil {
+		created := *comment
+		created.ID = id
+		if err := m.notifications.Commented(ctx, &created); err != nil {
+			m.log.Error("Failed to notify about comment", zap.Int("comment_id", id), zap.Error(err))
+		}
+	}
+	return
+}
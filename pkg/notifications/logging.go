@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Commented(ctx context.Context, comment *comments.Comment) (err error) {
+	err = m.service.Commented(ctx, comment)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Commented()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Posted(ctx context.Context, post *posts.Post) (err error) {
+	err = m.service.Posted(ctx, post)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Posted()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Inbox(ctx context.Context, q InboxQuery) (inbox *Inbox, err error) {
+	inbox, err = m.service.Inbox(ctx, q)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Inbox()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unread(ctx context.Context, userID int) (count int, err error) {
+	count, err = m.service.Unread(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Unread()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) MarkRead(ctx context.Context, userID int, notificationID int) (err error) {
+	err = m.service.MarkRead(ctx, userID, notificationID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.MarkRead()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) MarkAllRead(ctx context.Context, userID int) (err error) {
+	err = m.service.MarkAllRead(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.MarkAllRead()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Mutes(ctx context.Context, userID int) (kinds []Kind, err error) {
+	kinds, err = m.service.Mutes(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Mutes()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Mute(ctx context.Context, userID int, kind Kind) (err error) {
+	err = m.service.Mute(ctx, userID, kind)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Mute()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unmute(ctx context.Context, userID int, kind Kind) (err error) {
+	err = m.service.Unmute(ctx, userID, kind)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from notifications.Service.Unmute()", zap.Error(err))
+	}
+	return
+}
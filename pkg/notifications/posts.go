@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/posts"
+	"go.uber.org/zap"
+)
+
+// OnPost is a posts.Middleware which notifies users mentioned in a post right after it's created.
+// Failing to notify doesn't fail the request, it gets logged instead.
+func OnPost(ns Service, log *zap.Logger) posts.Middleware {
+	return func(service posts.Service) posts.Service {
+		return &postsMiddleware{service, ns, log}
+	}
+}
+
+type postsMiddleware struct {
+	posts.Service
+	notifications Service
+	log           *zap.Logger
+}
+
+func (m *postsMiddleware) Create(ctx context.Context, post *posts.Post) (id int, err error) {
+	id, err = m.Service.Create(ctx, post)
+	if err == nil {
+		created := *post
+		created.ID = id
+		if err := m.notifications.Posted(ctx, &created); err != nil {
+			m.log.Error("Failed to notify about post", zap.Int("post_id", id), zap.Error(err))
+		}
+	}
+	return
+}
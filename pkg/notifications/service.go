@@ -0,0 +1,166 @@
+package notifications
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// mention is an @username not preceded by a word character, so emails don't count.
+// Registration limits usernames to the same charset.
+var mention = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_-]+)`)
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for notifications.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// mentions returns the distinct usernames mentioned in text, up to MaxMentions
+func mentions(text string) []string {
+	seen := map[string]bool{}
+	usernames := []string{}
+	for _, m := range mention.FindAllStringSubmatch(text, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		usernames = append(usernames, m[1])
+		if len(usernames) == MaxMentions {
+			break
+		}
+	}
+	return usernames
+}
+
+// mentioned builds mention notifications for users in text, except the actor and those in skip
+func (s *service) mentioned(ctx context.Context, text string, actorID int, skip int) ([]*Notification, error) {
+	usernames := mentions(text)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	userIDs, err := s.repo.Users(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	notifications := []*Notification{}
+	for _, userID := range userIDs {
+		if userID == actorID || userID == skip {
+			continue
+		}
+		notifications = append(notifications, &Notification{UserID: userID, Kind: Mention, ActorID: actorID})
+	}
+	return notifications, nil
+}
+
+func (s *service) Commented(ctx context.Context, comment *comments.Comment) error {
+	kind := PostReply
+	var recipient int
+	var err error
+	if comment.ParentID == nil {
+		recipient, err = s.repo.PostAuthor(ctx, comment.PostID)
+	} else {
+		kind = CommentReply
+		recipient, err = s.repo.CommentAuthor(ctx, *comment.ParentID)
+	}
+	if err != nil {
+		return err
+	}
+
+	notifications := []*Notification{}
+	if recipient != comment.CommenterID {
+		notifications = append(notifications, &Notification{UserID: recipient, Kind: kind, ActorID: comment.CommenterID})
+	}
+	// whoever is being replied to gets only the reply, even when mentioned
+	mentioned, err := s.mentioned(ctx, comment.Body, comment.CommenterID, recipient)
+	if err != nil {
+		return err
+	}
+	notifications = append(notifications, mentioned...)
+	if len(notifications) == 0 {
+		return nil
+	}
+	for _, n := range notifications {
+		n.PostID = comment.PostID
+		n.CommentID = comment.ID
+	}
+	return s.repo.Create(ctx, notifications)
+}
+
+func (s *service) Posted(ctx context.Context, post *posts.Post) error {
+	notifications, err := s.mentioned(ctx, post.Title+"\n"+post.Body, post.AuthorID, 0)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+	for _, n := range notifications {
+		n.PostID = post.ID
+	}
+	return s.repo.Create(ctx, notifications)
+}
+
+func (s *service) Inbox(ctx context.Context, q InboxQuery) (*Inbox, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	limit := q.Limit
+	// fetch one extra to know if there are more
+	q.Limit++
+	notifications, err := s.repo.Inbox(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.Unread(ctx, q.UserID)
+	if err != nil {
+		return nil, err
+	}
+	inbox := &Inbox{Notifications: notifications, Unread: unread}
+	if len(notifications) > limit {
+		inbox.Notifications = notifications[:limit]
+		inbox.More = true
+	}
+	return inbox, nil
+}
+
+func (s *service) Unread(ctx context.Context, userID int) (int, error) {
+	return s.repo.Unread(ctx, userID)
+}
+
+func (s *service) MarkRead(ctx context.Context, userID int, notificationID int) error {
+	return s.repo.MarkRead(ctx, userID, notificationID)
+}
+
+func (s *service) MarkAllRead(ctx context.Context, userID int) error {
+	return s.repo.MarkAllRead(ctx, userID)
+}
+
+func (s *service) Mutes(ctx context.Context, userID int) ([]Kind, error) {
+	return s.repo.Mutes(ctx, userID)
+}
+
+func validKind(kind Kind) bool {
+	return kind == PostReply || kind == CommentReply || kind == Mention
+}
+
+func (s *service) Mute(ctx context.Context, userID int, kind Kind) error {
+	if !validKind(kind) {
+		return ErrInvalidKind
+	}
+	return s.repo.SetMuted(ctx, userID, kind, true)
+}
+
+func (s *service) Unmute(ctx context.Context, userID int, kind Kind) error {
+	if !validKind(kind) {
+		return ErrInvalidKind
+	}
+	return s.repo.SetMuted(ctx, userID, kind, false)
+}
@@ -0,0 +1,154 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// mockRepo has post 1 by user 1, comment 2 by user 2 and users alice(1), bob(2) and carol(3)
+type mockRepo struct {
+	Repository
+	created []*Notification
+	inbox   []*Notification
+	muted   map[Kind]bool
+}
+
+func (r *mockRepo) Create(ctx context.Context, notifications []*Notification) error {
+	r.created = append(r.created, notifications...)
+	return nil
+}
+
+func (r *mockRepo) PostAuthor(ctx context.Context, postID int) (int, error) {
+	return 1, nil
+}
+
+func (r *mockRepo) CommentAuthor(ctx context.Context, commentID int) (int, error) {
+	return 2, nil
+}
+
+func (r *mockRepo) Users(ctx context.Context, usernames []string) ([]int, error) {
+	ids := map[string]int{"alice": 1, "bob": 2, "carol": 3}
+	userIDs := []int{}
+	for _, username := range usernames {
+		if id, ok := ids[username]; ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *mockRepo) Inbox(ctx context.Context, q InboxQuery) ([]*Notification, error) {
+	if len(r.inbox) > q.Limit {
+		return r.inbox[:q.Limit], nil
+	}
+	return r.inbox, nil
+}
+
+func (r *mockRepo) Unread(ctx context.Context, userID int) (int, error) {
+	return len(r.inbox), nil
+}
+
+func (r *mockRepo) SetMuted(ctx context.Context, userID int, kind Kind, muted bool) error {
+	if r.muted == nil {
+		r.muted = map[Kind]bool{}
+	}
+	r.muted[kind] = muted
+	return nil
+}
+
+func recipients(notifications []*Notification) map[int]Kind {
+	r := map[int]Kind{}
+	for _, n := range notifications {
+		r[n.UserID] = n.Kind
+	}
+	return r
+}
+
+func TestMentions(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(mentions("@alice and @bob, not bob@example.com or @@carol. @alice again"), qt.DeepEquals, []string{"alice", "bob"})
+	c.Assert(mentions("no one"), qt.HasLen, 0)
+
+	many := ""
+	for i := 0; i < MaxMentions+5; i++ {
+		many += " @user" + string(rune('a'+i))
+	}
+	c.Assert(mentions(many), qt.HasLen, MaxMentions)
+}
+
+func TestService_Commented(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	// a top-level comment notifies the post's author
+	repo := &mockRepo{}
+	service := NewService(repo)
+	err := service.Commented(ctx, &comments.Comment{ID: 5, PostID: 1, CommenterID: 3, Body: "hi"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.created, qt.HasLen, 1)
+	c.Assert(*repo.created[0], qt.DeepEquals, Notification{UserID: 1, Kind: PostReply, ActorID: 3, PostID: 1, CommentID: 5})
+
+	// a reply notifies the parent's author, who isn't notified again for being mentioned
+	repo = &mockRepo{}
+	service = NewService(repo)
+	parent := 2
+	err = service.Commented(ctx, &comments.Comment{ID: 6, PostID: 1, ParentID: &parent, CommenterID: 3, Body: "@bob @alice @carol @nobody"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(recipients(repo.created), qt.DeepEquals, map[int]Kind{2: CommentReply, 1: Mention})
+	c.Assert(repo.created, qt.HasLen, 2)
+
+	// replying to yourself notifies no one
+	repo = &mockRepo{}
+	service = NewService(repo)
+	err = service.Commented(ctx, &comments.Comment{ID: 7, PostID: 1, CommenterID: 1, Body: "@alice"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.created, qt.HasLen, 0)
+}
+
+func TestService_Posted(t *testing.T) {
+	c := qt.New(t)
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	err := service.Posted(context.Background(), &posts.Post{ID: 4, AuthorID: 1, Title: "For @bob", Body: "and @carol"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(recipients(repo.created), qt.DeepEquals, map[int]Kind{2: Mention, 3: Mention})
+	c.Assert(repo.created[0].PostID, qt.Equals, 4)
+	c.Assert(repo.created[0].CommentID, qt.Equals, 0)
+}
+
+func TestService_Inbox(t *testing.T) {
+	c := qt.New(t)
+	repo := &mockRepo{}
+	for i := 0; i < 3; i++ {
+		repo.inbox = append(repo.inbox, &Notification{ID: 3 - i})
+	}
+	service := NewService(repo)
+
+	inbox, err := service.Inbox(context.Background(), InboxQuery{UserID: 1, Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox.Notifications, qt.HasLen, 2)
+	c.Assert(inbox.More, qt.Equals, true)
+	c.Assert(inbox.Unread, qt.Equals, 3)
+
+	inbox, err = service.Inbox(context.Background(), InboxQuery{UserID: 1})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox.Notifications, qt.HasLen, 3)
+	c.Assert(inbox.More, qt.Equals, false)
+}
+
+func TestService_Mute(t *testing.T) {
+	c := qt.New(t)
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	c.Assert(service.Mute(context.Background(), 1, "everything"), qt.Equals, ErrInvalidKind)
+	c.Assert(service.Mute(context.Background(), 1, Mention), qt.IsNil)
+	c.Assert(repo.muted[Mention], qt.Equals, true)
+	c.Assert(service.Unmute(context.Background(), 1, Mention), qt.IsNil)
+	c.Assert(repo.muted[Mention], qt.Equals, false)
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Commented(ctx context.Context, comment *comments.Comment) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Commented")
+	defer span.End()
+	return m.service.Commented(ctx, comment)
+}
+
+func (m *tracingMiddleware) Posted(ctx context.Context, post *posts.Post) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Posted")
+	defer span.End()
+	return m.service.Posted(ctx, post)
+}
+
+func (m *tracingMiddleware) Inbox(ctx context.Context, q InboxQuery) (*Inbox, error) {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Inbox")
+	defer span.End()
+	return m.service.Inbox(ctx, q)
+}
+
+func (m *tracingMiddleware) Unread(ctx context.Context, userID int) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Unread")
+	defer span.End()
+	return m.service.Unread(ctx, userID)
+}
+
+func (m *tracingMiddleware) MarkRead(ctx context.Context, userID int, notificationID int) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.MarkRead")
+	defer span.End()
+	return m.service.MarkRead(ctx, userID, notificationID)
+}
+
+func (m *tracingMiddleware) MarkAllRead(ctx context.Context, userID int) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.MarkAllRead")
+	defer span.End()
+	return m.service.MarkAllRead(ctx, userID)
+}
+
+func (m *tracingMiddleware) Mutes(ctx context.Context, userID int) ([]Kind, error) {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Mutes")
+	defer span.End()
+	return m.service.Mutes(ctx, userID)
+}
+
+func (m *tracingMiddleware) Mute(ctx context.Context, userID int, kind Kind) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Mute")
+	defer span.End()
+	return m.service.Mute(ctx, userID, kind)
+}
+
+func (m *tracingMiddleware) Unmute(ctx context.Context, userID int, kind Kind) error {
+	ctx, span := trace.StartSpan(ctx, "notifications.Service.Unmute")
+	defer span.End()
+	return m.service.Unmute(ctx, userID, kind)
+}
@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// Kind is what a notification is about, each kind can be muted separately
+type Kind string
+
+// Kinds of notifications
+const (
+	// PostReply is for a top-level comment on your post
+	PostReply Kind = "post_reply"
+	// CommentReply is for a reply to your comment
+	CommentReply Kind = "comment_reply"
+	// Mention is for an @username in a post or comment
+	Mention Kind = "mention"
+)
+
+// Limits for a page of the inbox
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
+// MaxMentions is how many users one post or comment can notify by mentioning them
+const MaxMentions = 10
+
+// Notification tells UserID that ActorID did something, CommentID is 0 for mentions in a post
+type Notification struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	Kind      Kind      `json:"kind"`
+	ActorID   int       `json:"actor_id"`
+	Actor     string    `json:"actor"`
+	PostID    int       `json:"post_id"`
+	CommentID int       `json:"comment_id,omitempty"`
+	Read      bool      `json:"read"`
+	Created   time.Time `json:"created"`
+}
+
+// InboxQuery selects a page of an user's notifications, newest first.
+// Before continues from the last notification of the previous page, 0 starts from the newest.
+type InboxQuery struct {
+	UserID int
+	Unread bool
+	Before int
+	Limit  int
+}
+
+// Inbox is a page of notifications along with how many are unread overall, More tells if there are further pages
+type Inbox struct {
+	Notifications []*Notification `json:"notifications"`
+	Unread        int             `json:"unread"`
+	More          bool            `json:"more"`
+}
+
+var (
+	// ErrNotificationNotFound for when a notification doesn't exist or isn't the user's
+	ErrNotificationNotFound = errors.E(errors.NotFound, "Notification not found")
+	// ErrInvalidKind for when muting something that isn't a kind of notification
+	ErrInvalidKind = errors.E(errors.Invalid, "Invalid kind, must be one of post_reply, comment_reply or mention")
+)
+
+// Repository handles storing notifications and mute preferences
+type Repository interface {
+	// Create stores notifications, leaving out the ones whose recipient muted their kind
+	Create(ctx context.Context, notifications []*Notification) error
+	// PostAuthor is who wrote a post
+	PostAuthor(ctx context.Context, postID int) (userID int, err error)
+	// CommentAuthor is who wrote a comment
+	CommentAuthor(ctx context.Context, commentID int) (userID int, err error)
+	// Users looks up the ids of active users by username, unknown usernames are left out
+	Users(ctx context.Context, usernames []string) (userIDs []int, err error)
+	Inbox(ctx context.Context, q InboxQuery) ([]*Notification, error)
+	Unread(ctx context.Context, userID int) (count int, err error)
+	// MarkRead returns ErrNotificationNotFound if the notification isn't the user's
+	MarkRead(ctx context.Context, userID int, notificationID int) error
+	MarkAllRead(ctx context.Context, userID int) error
+	Mutes(ctx context.Context, userID int) ([]Kind, error)
+	SetMuted(ctx context.Context, userID int, kind Kind, muted bool) error
+}
+
+// Service creates notifications for new posts and comments and manages the inbox
+type Service interface {
+	// Commented notifies the author of what a comment replies to and the users it mentions
+	Commented(ctx context.Context, comment *comments.Comment) error
+	// Posted notifies the users a post mentions
+	Posted(ctx context.Context, post *posts.Post) error
+	Inbox(ctx context.Context, q InboxQuery) (*Inbox, error)
+	Unread(ctx context.Context, userID int) (count int, err error)
+	MarkRead(ctx context.Context, userID int, notificationID int) error
+	MarkAllRead(ctx context.Context, userID int) error
+	Mutes(ctx context.Context, userID int) ([]Kind, error)
+	Mute(ctx context.Context, userID int, kind Kind) error
+	Unmute(ctx context.Context, userID int, kind Kind) error
+}
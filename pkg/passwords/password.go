@@ -0,0 +1,145 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrMismatch is returned if a password doesn't match a hash
+	ErrMismatch = errors.E(errors.Unauthorized, "Password doesn't match")
+	// ErrUnknownHash is returned if a hash isn't in a format any Hasher makes
+	ErrUnknownHash = errors.E(errors.Internal, "Unknown password hash format")
+)
+
+// Hasher hashes passwords into strings that carry their algorithm and parameters,
+// so hashes from older settings keep working after the settings change.
+type Hasher interface {
+	// Hash hashes a password with a new salt
+	Hash(password string) (string, error)
+	// Verify checks password against a hash from any supported algorithm, returns ErrMismatch if it doesn't match.
+	// rehash is whether the hash is from other settings than this Hasher's and should be replaced.
+	Verify(hash, password string) (rehash bool, err error)
+}
+
+// Verify checks password against a hash from any supported algorithm
+func Verify(hash, password string) error {
+	switch {
+	case hash == "":
+		// erased accounts have no hash, nothing matches it
+		return ErrMismatch
+	case strings.HasPrefix(hash, "$2"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			if err == bcrypt.ErrMismatchedHashAndPassword {
+				return ErrMismatch
+			}
+			return errors.E(errors.Internal, errors.Op("bcrypt.CompareHashAndPassword"), err)
+		}
+		return nil
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return err
+		}
+		derived := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+		if subtle.ConstantTimeCompare(derived, key) != 1 {
+			return ErrMismatch
+		}
+		return nil
+	}
+	return ErrUnknownHash
+}
+
+// Bcrypt hashes with bcrypt at Cost
+type Bcrypt struct {
+	Cost int
+}
+
+func (b Bcrypt) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", errors.E(errors.Internal, errors.Op("bcrypt.GenerateFromPassword"), err)
+	}
+	return string(hashed), nil
+}
+
+func (b Bcrypt) Verify(hash, password string) (bool, error) {
+	if err := Verify(hash, password); err != nil {
+		return false, err
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.Cost, nil
+}
+
+// Argon2id hashes with Argon2id, encoded the same way as the reference implementation:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+type Argon2id struct {
+	// Memory is in KiB
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	SaltLen int
+	KeyLen  uint32
+}
+
+// DefaultArgon2id are the parameters RFC 9106 recommends when memory is constrained
+var DefaultArgon2id = Argon2id{
+	Memory:  64 * 1024,
+	Time:    3,
+	Threads: 4,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+var b64 = base64.RawStdEncoding
+
+func (a Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.E(errors.Internal, errors.Op("rand.Read"), err)
+	}
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.Memory, a.Time, a.Threads, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (a Argon2id) Verify(hash, password string) (bool, error) {
+	if err := Verify(hash, password); err != nil {
+		return false, err
+	}
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true, nil
+	}
+	params.SaltLen, params.KeyLen = len(salt), uint32(len(key))
+	return params != a, nil
+}
+
+func decodeArgon2id(hash string) (params Argon2id, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHash
+	}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if salt, err = b64.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if key, err = b64.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,77 @@
+package passwords
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// small parameters keep the tests fast
+var testArgon2id = Argon2id{Memory: 1024, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func TestBcrypt(t *testing.T) {
+	c := qt.New(t)
+	h := Bcrypt{Cost: bcrypt.MinCost}
+	hash, err := h.Hash("password")
+	c.Assert(err, qt.IsNil)
+
+	rehash, err := h.Verify(hash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, false)
+	_, err = h.Verify(hash, "wrong")
+	c.Assert(err, qt.Equals, ErrMismatch)
+
+	// raising the cost outdates older hashes
+	rehash, err = Bcrypt{Cost: bcrypt.MinCost + 1}.Verify(hash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, true)
+}
+
+func TestArgon2id(t *testing.T) {
+	c := qt.New(t)
+	hash, err := testArgon2id.Hash("password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), qt.Equals, true)
+
+	rehash, err := testArgon2id.Verify(hash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, false)
+	_, err = testArgon2id.Verify(hash, "wrong")
+	c.Assert(err, qt.Equals, ErrMismatch)
+
+	// salts are random
+	other, err := testArgon2id.Hash("password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(other, qt.Not(qt.Equals), hash)
+
+	stronger := testArgon2id
+	stronger.Time = 2
+	rehash, err = stronger.Verify(hash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, true)
+}
+
+// Hashes from one algorithm verify under the other and get marked for rehashing
+func TestVerify_AcrossAlgorithms(t *testing.T) {
+	c := qt.New(t)
+	bhash, err := Bcrypt{Cost: bcrypt.MinCost}.Hash("password")
+	c.Assert(err, qt.IsNil)
+	rehash, err := testArgon2id.Verify(bhash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, true)
+
+	ahash, err := testArgon2id.Hash("password")
+	c.Assert(err, qt.IsNil)
+	rehash, err = Bcrypt{Cost: bcrypt.MinCost}.Verify(ahash, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rehash, qt.Equals, true)
+}
+
+func TestVerify_Unknown(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(Verify("plaintext", "plaintext"), qt.Equals, ErrUnknownHash)
+	c.Assert(Verify("$argon2id$v=19$m=1024$bad", "password"), qt.Equals, ErrUnknownHash)
+	c.Assert(Verify("$argon2id$v=16$m=1024,t=1,p=1$c2FsdHNhbHQ$a2V5", "password"), qt.Equals, ErrUnknownHash)
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// APITokenRepository implements `apitokens.Repository` interface
+type APITokenRepository struct {
+	db *sqlx.DB
+}
+
+// NewAPITokenRepository is a constructor
+func NewAPITokenRepository(db *sql.DB) apitokens.Repository {
+	return &APITokenRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *APITokenRepository) Create(ctx context.Context, token *apitokens.Token, hash string) (id int, err error) {
+	op := errors.Op("apitokens.Repository.Create")
+	stmt := `
+	INSERT INTO api_tokens(user_id, name, scopes, hash)
+	VALUES($1, $2, $3, $4) RETURNING id, created`
+
+	err = repo.db.QueryRowContext(ctx, stmt, token.UserID, token.Name, pq.Array(token.Scopes), hash).
+		Scan(&id, &token.Created)
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return id, nil
+}
+
+func (repo *APITokenRepository) List(ctx context.Context, userID int) ([]*apitokens.Token, error) {
+	op := errors.Op("apitokens.Repository.List")
+	query := `
+	SELECT id, user_id, name, scopes, created, last_used FROM api_tokens
+	WHERE user_id = $1 AND revoked IS NULL ORDER BY id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	tokens := []*apitokens.Token{}
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return tokens, nil
+}
+
+func (repo *APITokenRepository) Revoke(ctx context.Context, userID, tokenID int) error {
+	op := errors.Op("apitokens.Repository.Revoke")
+	stmt := `UPDATE api_tokens SET revoked = now() WHERE id = $1 AND user_id = $2 AND revoked IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, stmt, tokenID, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return apitokens.ErrTokenNotFound
+	}
+	return nil
+}
+
+func (repo *APITokenRepository) Authenticate(ctx context.Context, hash string) (*apitokens.Token, error) {
+	op := errors.Op("apitokens.Repository.Authenticate")
+	stmt := `
+	UPDATE api_tokens t SET last_used = now()
+	FROM users u
+	WHERE t.hash = $1 AND t.revoked IS NULL AND u.id = t.user_id AND u.deleted IS NULL
+	RETURNING t.id, t.user_id, t.name, t.scopes, t.created, t.last_used`
+
+	token, err := scanToken(repo.db.QueryRowContext(ctx, stmt, hash))
+	if err == sql.ErrNoRows {
+		return nil, apitokens.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return token, nil
+}
+
+// scanner is either *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanToken(row scanner) (*apitokens.Token, error) {
+	token := &apitokens.Token{}
+	var scopes []string
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, pq.Array(&scopes), &token.Created, &token.LastUsed)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range scopes {
+		token.Scopes = append(token.Scopes, apitokens.Scope(scope))
+	}
+	return token, nil
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestAPITokenRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+
+	repo := NewAPITokenRepository(db)
+
+	// Create
+	tokenID, err := repo.Create(ctx, &apitokens.Token{
+		UserID: user.ID,
+		Name:   "bot",
+		Scopes: []apitokens.Scope{apitokens.ScopeRead, apitokens.ScopeVote},
+	}, "hash")
+	c.Assert(err, qt.IsNil)
+
+	// List
+	tokens, err := repo.List(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tokens, qt.HasLen, 1)
+	c.Assert(tokens[0].Scopes, qt.DeepEquals, []apitokens.Scope{apitokens.ScopeRead, apitokens.ScopeVote})
+	c.Assert(tokens[0].LastUsed, qt.IsNil)
+
+	// Authenticate marks it used
+	token, err := repo.Authenticate(ctx, "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(token.ID, qt.Equals, tokenID)
+	c.Assert(token.LastUsed, qt.Not(qt.IsNil))
+	_, err = repo.Authenticate(ctx, "other")
+	c.Assert(err, qt.Equals, apitokens.ErrInvalidToken)
+
+	// Revoke
+	err = repo.Revoke(ctx, user.ID+1, tokenID)
+	c.Assert(err, qt.Equals, apitokens.ErrTokenNotFound)
+	err = repo.Revoke(ctx, user.ID, tokenID)
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Authenticate(ctx, "hash")
+	c.Assert(err, qt.Equals, apitokens.ErrInvalidToken)
+	tokens, err = repo.List(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tokens, qt.HasLen, 0)
+
+	// A password change revokes the remaining tokens
+	_, err = repo.Create(ctx, &apitokens.Token{UserID: user.ID, Name: "other bot", Scopes: []apitokens.Scope{apitokens.ScopeRead}}, "other_hash")
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Authenticate(ctx, "other_hash")
+	c.Assert(err, qt.IsNil)
+	_, err = userrepo.SetHash(ctx, user.ID, "new_bcrypt_hash")
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Authenticate(ctx, "other_hash")
+	c.Assert(err, qt.Equals, apitokens.ErrInvalidToken)
+	tokens, err = repo.List(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tokens, qt.HasLen, 0)
+}
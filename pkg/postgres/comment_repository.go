@@ -8,6 +8,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// CommentRepository implements `comments.Repository` interface
 type CommentRepository struct {
 	db *sqlx.DB
 }
@@ -20,66 +21,151 @@ func NewCommentRepository(db *sql.DB) comments.Repository {
 }
 
 func (r *CommentRepository) Create(ctx context.Context, comment *comments.Comment) (id int, err error) {
+	// FOR SHARE keeps the post from getting locked until the comment is in
+	query := `
+	SELECT locked IS NOT NULL FROM posts
+	WHERE id = $1 AND deleted IS NULL AND removed IS NULL FOR SHARE`
+	// parent has to be a live comment on the same post
 	stmt := `
-	INSERT INTO comments(post_id, parent_id, commenter_id, depth, body) 
-	VALUES($1, $2, $3, calculate_depth($2), $4) RETURNING id`
-	err = r.db.QueryRowContext(ctx, stmt,
-		comment.PostID, comment.ParentID, comment.CommenterID, comment.Body).
-		Scan(&id)
+	INSERT INTO comments(post_id, parent_id, commenter_id, depth, body, body_html)
+	SELECT $1, $2, $3, calculate_depth($2), $4, $5
+	WHERE $2::integer IS NULL OR EXISTS(
+		SELECT 1 FROM comments WHERE id = $2 AND post_id = $1 AND deleted IS NULL AND removed IS NULL
+	) RETURNING id`
+
+	err = transact(ctx, r.db, func(tx *sqlx.Tx) error {
+		var locked bool
+		err := tx.QueryRowContext(ctx, query, comment.PostID).
+			Scan(&locked)
+		if err == sql.ErrNoRows {
+			return comments.ErrPostNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if locked {
+			return comments.ErrPostLocked
+		}
+
+		err = tx.QueryRowContext(ctx, stmt,
+			comment.PostID, comment.ParentID, comment.CommenterID, comment.Body, comment.BodyHTML).
+			Scan(&id)
+		if err == sql.ErrNoRows {
+			return comments.ErrCommentNotFound
+		}
+		return err
+	})
+	return
+}
+
+func (r *CommentRepository) Comments(ctx context.Context, postID, viewerID int) (c []*comments.Comment, err error) {
+	query := `SELECT id, post_id, parent_id, commenter_id, body, body_html, depth, upvotes, downvotes, score
+	FROM comments c WHERE post_id = $1 AND deleted IS NULL AND removed IS NULL
+		AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $2 AND b.blocked_id = c.commenter_id);`
+	err = r.db.SelectContext(ctx, &c, query, postID, viewerID)
+	if err == sql.ErrNoRows {
+		err = comments.ErrCommentNotFound
+	}
+	return
+}
+
+func (r *CommentRepository) Thread(ctx context.Context, q comments.ThreadQuery) (c []*comments.Comment, total int, err error) {
+	// Walks down from the parent a level at a time, taking at most `Limit` replies per comment.
+	// Deleted and removed comments are walked through so their replies stay reachable.
+	query := `
+	WITH RECURSIVE tree AS (
+		(SELECT id, post_id, parent_id, commenter_id, depth, body, body_html, deleted, removed, upvotes, downvotes, score, 1 AS level
+		FROM comments
+		WHERE post_id = $1 AND parent_id IS NOT DISTINCT FROM $2
+		ORDER BY id OFFSET $3 LIMIT $4)
+		UNION ALL
+		SELECT r.id, r.post_id, r.parent_id, r.commenter_id, r.depth, r.body, r.body_html, r.deleted, r.removed,
+			r.upvotes, r.downvotes, r.score, t.level + 1
+		FROM tree t, LATERAL (
+			SELECT * FROM comments WHERE parent_id = t.id ORDER BY id LIMIT $4
+		) r
+		WHERE t.level < $5
+	)
+	SELECT id, post_id, parent_id, commenter_id, depth, upvotes, downvotes, score,
+		CASE WHEN deleted IS NOT NULL THEN '[deleted]' WHEN removed IS NOT NULL THEN '[removed]'
+			WHEN b.blocked_id IS NOT NULL THEN '[blocked]' ELSE body END AS body,
+		CASE WHEN deleted IS NOT NULL THEN '<p>[deleted]</p>' WHEN removed IS NOT NULL THEN '<p>[removed]</p>'
+			WHEN b.blocked_id IS NOT NULL THEN '<p>[blocked]</p>' ELSE body_html END AS body_html,
+		deleted IS NOT NULL AS deleted,
+		removed IS NOT NULL AS removed,
+		b.blocked_id IS NOT NULL AS blocked,
+		(SELECT COUNT(*) FROM comments WHERE parent_id = tree.id) AS replies
+	FROM tree LEFT JOIN blocks b ON b.blocker_id = $6 AND b.blocked_id = tree.commenter_id
+	ORDER BY depth, id;`
+	err = r.db.SelectContext(ctx, &c, query, q.PostID, q.ParentID, q.Offset, q.Limit, q.Depth, q.ViewerID)
+	if err != nil {
+		return
+	}
+
+	query = `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND parent_id IS NOT DISTINCT FROM $2`
+	err = r.db.QueryRowContext(ctx, query, q.PostID, q.ParentID).
+		Scan(&total)
 	return
 }
 
-func (r *CommentRepository) Comments(ctx context.Context, postID int) (c []*comments.Comment, err error) {
-	query := `SELECT id, post_id, parent_id, commenter_id, body 
-	FROM comments WHERE post_id = $1 AND deleted IS NULL;`
-	err = r.db.SelectContext(ctx, &c, query, postID)
+func (r *CommentRepository) PostID(ctx context.Context, commentID int) (postID int, err error) {
+	query := `SELECT post_id FROM comments WHERE id = $1`
+
+	err = r.db.QueryRowContext(ctx, query, commentID).
+		Scan(&postID)
 	if err == sql.ErrNoRows {
 		err = comments.ErrCommentNotFound
 	}
 	return
 }
 
-func (r *CommentRepository) Delete(ctx context.Context, commentID int, commenterID int) error {
-	stmt := `UPDATE comments SET deleted = now() WHERE id = $1 AND commenter_id = $2`
+func (r *CommentRepository) Delete(ctx context.Context, postID int, commentID int, commenterID int) error {
+	stmt := `UPDATE comments SET deleted = now()
+	WHERE id = $1 AND post_id = $2 AND commenter_id = $3 AND deleted IS NULL`
+
+	result, err := r.db.ExecContext(ctx, stmt, commentID, postID, commenterID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return nil
+	}
 
-	result, err := r.db.ExecContext(ctx, stmt, commentID, commenterID)
-	affected, _ := result.RowsAffected()
-	if IsForeignKeyViolation(err) {
+	// nothing was deleted, tell apart a missing comment from someone else's
+	var owner int
+	query := `SELECT commenter_id FROM comments WHERE id = $1 AND post_id = $2 AND deleted IS NULL`
+	err = r.db.QueryRowContext(ctx, query, commentID, postID).Scan(&owner)
+	if err == sql.ErrNoRows {
 		return comments.ErrCommentNotFound
 	}
-	if err == nil && affected < 1 {
-		return comments.ErrUnauthorized
+	if err != nil {
+		return err
 	}
-	return err
+	return comments.ErrUnauthorized
 }
 
 func (r *CommentRepository) Vote(ctx context.Context, commentID int, voterID int, delta int) error {
-	stmt := `
-	INSERT INTO comment_votes(comment_id, voter_id, delta) 
-	VALUES 
-	($1, $2, $3)
-	ON CONFLICT (voter_id, comment_id) DO
-	UPDATE SET delta = $3 WHERE comment_votes.comment_id = $1 AND comment_votes.voter_id = $2;
-	`
-	_, err := r.db.ExecContext(ctx, stmt, commentID, voterID, delta)
-	if IsForeignKeyViolation(err) {
+	err := transact(ctx, r.db, func(tx *sqlx.Tx) error {
+		return commentCounter.vote(ctx, tx, commentID, voterID, delta)
+	})
+	if err == sql.ErrNoRows {
 		return comments.ErrCommentNotFound
 	}
 	return err
 }
 
 func (r *CommentRepository) Unvote(ctx context.Context, commentID int, voterID int) error {
-	stmt := `DELETE FROM comment_votes WHERE comment_id = $1 AND voter_id = $2`
-
-	_, err := r.db.ExecContext(ctx, stmt, commentID, voterID)
-	if IsForeignKeyViolation(err) {
+	err := transact(ctx, r.db, func(tx *sqlx.Tx) error {
+		return commentCounter.unvote(ctx, tx, commentID, voterID)
+	})
+	if err == sql.ErrNoRows {
 		return comments.ErrCommentNotFound
 	}
 	return err
 }
 
 func (r *CommentRepository) Score(ctx context.Context, commentID int) (score int, err error) {
-	query := `SELECT COALESCE(SUM(delta), 0) FROM comment_votes WHERE comment_id = $1`
+	query := `SELECT score FROM comments WHERE id = $1 AND deleted IS NULL`
 
 	err = r.db.QueryRowContext(ctx, query, commentID).
 		Scan(&score)
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestCommentRepository_Delete(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	postID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Post", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	otherPostID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Other", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+
+	repo := NewCommentRepository(db)
+	commentID, err := repo.Create(ctx, &comments.Comment{PostID: postID, CommenterID: user.ID, Body: "Comment"})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(repo.Delete(ctx, otherPostID, commentID, user.ID), qt.Equals, comments.ErrCommentNotFound)
+	c.Assert(repo.Delete(ctx, postID, commentID, user2.ID), qt.Equals, comments.ErrUnauthorized)
+	c.Assert(repo.Delete(ctx, postID, 4242, user.ID), qt.Equals, comments.ErrCommentNotFound)
+	c.Assert(repo.Delete(ctx, postID, commentID, user.ID), qt.IsNil)
+	// deleting again doesn't move the timestamp
+	c.Assert(repo.Delete(ctx, postID, commentID, user.ID), qt.Equals, comments.ErrCommentNotFound)
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+// CommunityRepository implements `communities.Repository` interface
+type CommunityRepository struct {
+	db *sqlx.DB
+}
+
+// NewCommunityRepository is a constructor
+func NewCommunityRepository(db *sql.DB) communities.Repository {
+	return &CommunityRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *CommunityRepository) Create(ctx context.Context, community *communities.Community) (id int, err error) {
+	op := errors.Op("communities.Repository.Create")
+	stmt := `
+	INSERT INTO communities(name, description, rules, creator_id)
+	VALUES($1, $2, $3, $4) RETURNING id`
+
+	err = transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, stmt,
+			community.Name, community.Description, community.Rules, community.CreatorID).
+			Scan(&id)
+		if err != nil {
+			return err
+		}
+		// creators moderate their community
+		_, err = tx.ExecContext(ctx, `INSERT INTO moderators(user_id, community_id) VALUES($1, $2)`,
+			community.CreatorID, id)
+		if err != nil {
+			return err
+		}
+		return join(ctx, tx, id, community.CreatorID)
+	})
+	if IsUniqueKeyViolation(err) {
+		return 0, communities.ErrNameTaken
+	}
+	if err != nil {
+		return 0, internal(op, err)
+	}
+	return id, nil
+}
+
+const communityColumns = `id, name, description, rules, creator_id, members, created`
+
+func scanCommunity(op errors.Op, row *sql.Row) (*communities.Community, error) {
+	c := &communities.Community{}
+	err := row.Scan(&c.ID, &c.Name, &c.Description, &c.Rules, &c.CreatorID, &c.Members, &c.Created)
+	if err == sql.ErrNoRows {
+		return nil, communities.ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return c, nil
+}
+
+func (repo *CommunityRepository) Get(ctx context.Context, communityID int) (*communities.Community, error) {
+	op := errors.Op("communities.Repository.Get")
+	query := `SELECT ` + communityColumns + ` FROM communities WHERE id = $1`
+	return scanCommunity(op, repo.db.QueryRowContext(ctx, query, communityID))
+}
+
+func (repo *CommunityRepository) GetByName(ctx context.Context, name string) (*communities.Community, error) {
+	op := errors.Op("communities.Repository.GetByName")
+	query := `SELECT ` + communityColumns + ` FROM communities WHERE lower(name) = lower($1)`
+	return scanCommunity(op, repo.db.QueryRowContext(ctx, query, name))
+}
+
+func (repo *CommunityRepository) Update(ctx context.Context, community *communities.Community) error {
+	op := errors.Op("communities.Repository.Update")
+	stmt := `UPDATE communities SET description = $1, rules = $2 WHERE id = $3 AND creator_id = $4`
+
+	result, err := repo.db.ExecContext(ctx, stmt,
+		community.Description, community.Rules, community.ID, community.CreatorID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return communities.ErrUnauthorized
+	}
+	return nil
+}
+
+// join adds a member and bumps the member counter in the same transaction
+func join(ctx context.Context, tx *sqlx.Tx, communityID, userID int) error {
+	stmt := `
+	INSERT INTO community_members(community_id, user_id) VALUES($1, $2)
+	ON CONFLICT DO NOTHING`
+
+	result, err := tx.ExecContext(ctx, stmt, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return communities.ErrAlreadyMember
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE communities SET members = members + 1 WHERE id = $1`, communityID)
+	return err
+}
+
+func (repo *CommunityRepository) Join(ctx context.Context, communityID, userID int) error {
+	op := errors.Op("communities.Repository.Join")
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		return join(ctx, tx, communityID, userID)
+	})
+	if IsForeignKeyViolation(err) {
+		return communities.ErrCommunityNotFound
+	}
+	return internal(op, err)
+}
+
+func (repo *CommunityRepository) Leave(ctx context.Context, communityID, userID int) error {
+	op := errors.Op("communities.Repository.Leave")
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		stmt := `DELETE FROM community_members WHERE community_id = $1 AND user_id = $2`
+
+		result, err := tx.ExecContext(ctx, stmt, communityID, userID)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected < 1 {
+			return communities.ErrNotMember
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE communities SET members = members - 1 WHERE id = $1`, communityID)
+		return err
+	})
+	return internal(op, err)
+}
+
+func (repo *CommunityRepository) IsMember(ctx context.Context, communityID, userID int) (bool, error) {
+	op := errors.Op("communities.Repository.IsMember")
+	query := `SELECT EXISTS(SELECT 1 FROM community_members WHERE community_id = $1 AND user_id = $2)`
+
+	var member bool
+	err := repo.db.QueryRowContext(ctx, query, communityID, userID).
+		Scan(&member)
+	if err != nil {
+		return false, errors.E(errors.Internal, op, err)
+	}
+	return member, nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestCommunityRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	// setup users
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	repo := NewCommunityRepository(db)
+
+	// Create makes the creator a member
+	communityID, err := repo.Create(ctx, &communities.Community{
+		Name:        "golang",
+		Description: "All things Go",
+		CreatorID:   user.ID,
+	})
+	c.Assert(err, qt.IsNil)
+	member, err := repo.IsMember(ctx, communityID, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(member, qt.Equals, true)
+
+	// Names are unique regardless of case
+	_, err = repo.Create(ctx, &communities.Community{Name: "GoLang", CreatorID: user2.ID})
+	c.Assert(err, qt.Equals, communities.ErrNameTaken)
+
+	// Get
+	community, err := repo.GetByName(ctx, "GOLANG")
+	c.Assert(err, qt.IsNil)
+	c.Assert(community.ID, qt.Equals, communityID)
+	c.Assert(community.Members, qt.Equals, 1)
+	_, err = repo.GetByName(ctx, "rust")
+	c.Assert(err, qt.Equals, communities.ErrCommunityNotFound)
+
+	// Only the creator can update settings
+	community.Rules = "Be nice"
+	community.CreatorID = user2.ID
+	err = repo.Update(ctx, community)
+	c.Assert(err, qt.Equals, communities.ErrUnauthorized)
+	community.CreatorID = user.ID
+	err = repo.Update(ctx, community)
+	c.Assert(err, qt.IsNil)
+
+	// Join/Leave
+	err = repo.Join(ctx, communityID, user2.ID)
+	c.Assert(err, qt.IsNil)
+	err = repo.Join(ctx, communityID, user2.ID)
+	c.Assert(err, qt.Equals, communities.ErrAlreadyMember)
+	community, err = repo.Get(ctx, communityID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(community.Members, qt.Equals, 2)
+	c.Assert(community.Rules, qt.Equals, "Be nice")
+	err = repo.Leave(ctx, communityID, user2.ID)
+	c.Assert(err, qt.IsNil)
+	err = repo.Leave(ctx, communityID, user2.ID)
+	c.Assert(err, qt.Equals, communities.ErrNotMember)
+
+	// Per-community listing
+	postrepo := NewPostRepository(db)
+	_, err = postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Global", Body: "body"})
+	c.Assert(err, qt.IsNil)
+	postID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, CommunityID: &communityID, Title: "Go", Body: "body"})
+	c.Assert(err, qt.IsNil)
+	list, err := postrepo.List(ctx, posts.ListQuery{
+		Sort:        posts.SortNew,
+		AsOf:        community.Created.Add(time.Hour),
+		Limit:       10,
+		CommunityID: communityID,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, postID)
+	c.Assert(*list[0].CommunityID, qt.Equals, communityID)
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/curation"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/jmoiron/sqlx"
+)
+
+// CurationRepository implements `curation.Repository` interface
+type CurationRepository struct {
+	db *sqlx.DB
+}
+
+// NewCurationRepository is a constructor
+func NewCurationRepository(db *sql.DB) curation.Repository {
+	return &CurationRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+// add runs an insert which does nothing if the row is already there, mapping missing references to notFound
+func (repo *CurationRepository) add(ctx context.Context, op errors.Op, stmt string, notFound error, args ...interface{}) error {
+	_, err := repo.db.ExecContext(ctx, stmt, args...)
+	if IsForeignKeyViolation(err) {
+		return notFound
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// remove runs a delete which does nothing if the row isn't there
+func (repo *CurationRepository) remove(ctx context.Context, op errors.Op, stmt string, args ...interface{}) error {
+	_, err := repo.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+func (repo *CurationRepository) SavePost(ctx context.Context, userID, postID int) error {
+	stmt := `INSERT INTO saved_posts(user_id, post_id) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	return repo.add(ctx, "curation.Repository.SavePost", stmt, curation.ErrPostNotFound, userID, postID)
+}
+
+func (repo *CurationRepository) UnsavePost(ctx context.Context, userID, postID int) error {
+	stmt := `DELETE FROM saved_posts WHERE user_id = $1 AND post_id = $2`
+	return repo.remove(ctx, "curation.Repository.UnsavePost", stmt, userID, postID)
+}
+
+func (repo *CurationRepository) SavedPosts(ctx context.Context, userID int, page curation.Page) ([]*posts.Post, error) {
+	op := errors.Op("curation.Repository.SavedPosts")
+	query := `
+	SELECT p.id, p.author_id, p.community_id, p.title, p.body, p.body_html, COALESCE(p.url, ''), COALESCE(p.domain, ''),
+		p.locked IS NOT NULL, p.created, p.upvotes, p.downvotes, p.score
+	FROM saved_posts s JOIN posts p ON p.id = s.post_id
+	WHERE s.user_id = $1 AND p.deleted IS NULL AND p.removed IS NULL
+	ORDER BY s.created DESC, p.id DESC OFFSET $2 LIMIT $3`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID, page.Offset, page.Limit)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	list := []*posts.Post{}
+	for rows.Next() {
+		post := &posts.Post{}
+		err := rows.Scan(&post.ID, &post.AuthorID, &post.CommunityID, &post.Title, &post.Body, &post.BodyHTML, &post.URL, &post.Domain,
+			&post.Locked, &post.Created, &post.Upvotes, &post.Downvotes, &post.Score)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		list = append(list, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return list, nil
+}
+
+func (repo *CurationRepository) SaveComment(ctx context.Context, userID, commentID int) error {
+	stmt := `INSERT INTO saved_comments(user_id, comment_id) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	return repo.add(ctx, "curation.Repository.SaveComment", stmt, curation.ErrCommentNotFound, userID, commentID)
+}
+
+func (repo *CurationRepository) UnsaveComment(ctx context.Context, userID, commentID int) error {
+	stmt := `DELETE FROM saved_comments WHERE user_id = $1 AND comment_id = $2`
+	return repo.remove(ctx, "curation.Repository.UnsaveComment", stmt, userID, commentID)
+}
+
+func (repo *CurationRepository) SavedComments(ctx context.Context, userID int, page curation.Page) ([]*comments.Comment, error) {
+	op := errors.Op("curation.Repository.SavedComments")
+	query := `
+	SELECT c.id, c.post_id, c.parent_id, c.commenter_id, c.body, c.body_html, c.depth, c.upvotes, c.downvotes, c.score
+	FROM saved_comments s JOIN comments c ON c.id = s.comment_id
+	WHERE s.user_id = $1 AND c.deleted IS NULL AND c.removed IS NULL
+	ORDER BY s.created DESC, c.id DESC OFFSET $2 LIMIT $3`
+
+	c := []*comments.Comment{}
+	err := repo.db.SelectContext(ctx, &c, query, userID, page.Offset, page.Limit)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return c, nil
+}
+
+func (repo *CurationRepository) Hide(ctx context.Context, userID, postID int) error {
+	stmt := `INSERT INTO hidden_posts(user_id, post_id) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	return repo.add(ctx, "curation.Repository.Hide", stmt, curation.ErrPostNotFound, userID, postID)
+}
+
+func (repo *CurationRepository) Unhide(ctx context.Context, userID, postID int) error {
+	stmt := `DELETE FROM hidden_posts WHERE user_id = $1 AND post_id = $2`
+	return repo.remove(ctx, "curation.Repository.Unhide", stmt, userID, postID)
+}
+
+func (repo *CurationRepository) UserID(ctx context.Context, username string) (int, error) {
+	op := errors.Op("curation.Repository.UserID")
+	query := `SELECT id FROM users WHERE username = $1 AND deleted IS NULL`
+
+	var userID int
+	err := repo.db.QueryRowContext(ctx, query, username).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, curation.ErrUserNotFound
+	}
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return userID, nil
+}
+
+func (repo *CurationRepository) Block(ctx context.Context, userID, blockedID int) error {
+	stmt := `INSERT INTO blocks(blocker_id, blocked_id) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	return repo.add(ctx, "curation.Repository.Block", stmt, curation.ErrUserNotFound, userID, blockedID)
+}
+
+func (repo *CurationRepository) Unblock(ctx context.Context, userID, blockedID int) error {
+	stmt := `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`
+	return repo.remove(ctx, "curation.Repository.Unblock", stmt, userID, blockedID)
+}
+
+func (repo *CurationRepository) Blocked(ctx context.Context, userID int) ([]*curation.Blocked, error) {
+	op := errors.Op("curation.Repository.Blocked")
+	query := `
+	SELECT u.id, u.username, b.created FROM blocks b JOIN users u ON u.id = b.blocked_id
+	WHERE b.blocker_id = $1 ORDER BY b.created DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	blocked := []*curation.Blocked{}
+	for rows.Next() {
+		b := &curation.Blocked{}
+		if err := rows.Scan(&b.UserID, &b.Username, &b.Since); err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		blocked = append(blocked, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return blocked, nil
+}
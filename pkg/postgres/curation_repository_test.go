@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/curation"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/profiles"
+	"github.com/godwhoa/upboat/pkg/search"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestCurationRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	mine, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Mine", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	theirs, err := postrepo.Create(ctx, &posts.Post{AuthorID: user2.ID, Title: "Theirs", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	commentrepo := NewCommentRepository(db)
+	commentID, err := commentrepo.Create(ctx, &comments.Comment{PostID: mine, CommenterID: user2.ID, Body: "Reply"})
+	c.Assert(err, qt.IsNil)
+
+	repo := NewCurationRepository(db)
+	page := curation.Page{Limit: 10}
+
+	// Saving
+	c.Assert(repo.SavePost(ctx, user.ID, theirs), qt.IsNil)
+	c.Assert(repo.SavePost(ctx, user.ID, theirs), qt.IsNil)
+	c.Assert(repo.SavePost(ctx, user.ID, 4242), qt.Equals, curation.ErrPostNotFound)
+	saved, err := repo.SavedPosts(ctx, user.ID, page)
+	c.Assert(err, qt.IsNil)
+	c.Assert(saved, qt.HasLen, 1)
+	c.Assert(saved[0].ID, qt.Equals, theirs)
+	c.Assert(repo.UnsavePost(ctx, user.ID, theirs), qt.IsNil)
+	saved, err = repo.SavedPosts(ctx, user.ID, page)
+	c.Assert(err, qt.IsNil)
+	c.Assert(saved, qt.HasLen, 0)
+
+	c.Assert(repo.SaveComment(ctx, user.ID, commentID), qt.IsNil)
+	c.Assert(repo.SaveComment(ctx, user.ID, 4242), qt.Equals, curation.ErrCommentNotFound)
+	savedComments, err := repo.SavedComments(ctx, user.ID, page)
+	c.Assert(err, qt.IsNil)
+	c.Assert(savedComments, qt.HasLen, 1)
+	c.Assert(savedComments[0].Body, qt.Equals, "Reply")
+
+	list := func(viewerID int) []*posts.Post {
+		list, err := postrepo.List(ctx, posts.ListQuery{Sort: posts.SortNew, AsOf: time.Now().UTC(), Limit: 10, ViewerID: viewerID})
+		c.Assert(err, qt.IsNil)
+		return list
+	}
+
+	// Hiding leaves a post out of the viewer's listings only
+	c.Assert(repo.Hide(ctx, user2.ID, mine), qt.IsNil)
+	c.Assert(list(user2.ID), qt.HasLen, 1)
+	c.Assert(list(user.ID), qt.HasLen, 2)
+	c.Assert(list(0), qt.HasLen, 2)
+	c.Assert(repo.Unhide(ctx, user2.ID, mine), qt.IsNil)
+	c.Assert(list(user2.ID), qt.HasLen, 2)
+
+	// Blocking leaves out posts and hides comments by the blocked user
+	userID, err := repo.UserID(ctx, "lala")
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, user2.ID)
+	_, err = repo.UserID(ctx, "nobody")
+	c.Assert(err, qt.Equals, curation.ErrUserNotFound)
+	c.Assert(repo.Block(ctx, user.ID, user2.ID), qt.IsNil)
+	blocked, err := repo.Blocked(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(blocked, qt.HasLen, 1)
+	c.Assert(blocked[0].Username, qt.Equals, "lala")
+	c.Assert(list(user.ID), qt.HasLen, 1)
+	c.Assert(list(user.ID)[0].ID, qt.Equals, mine)
+
+	thread, _, err := commentrepo.Thread(ctx, comments.ThreadQuery{PostID: mine, Limit: 10, Depth: 5, ViewerID: user.ID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(thread, qt.HasLen, 1)
+	c.Assert(thread[0].Blocked, qt.Equals, true)
+	c.Assert(thread[0].Body, qt.Equals, "[blocked]")
+	thread, _, err = commentrepo.Thread(ctx, comments.ThreadQuery{PostID: mine, Limit: 10, Depth: 5})
+	c.Assert(err, qt.IsNil)
+	c.Assert(thread[0].Blocked, qt.Equals, false)
+	c.Assert(thread[0].Body, qt.Equals, "Reply")
+
+	// and from the flat comment list, the blocked user's profile and search
+	flat, err := commentrepo.Comments(ctx, mine, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(flat, qt.HasLen, 0)
+	flat, err = commentrepo.Comments(ctx, mine, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(flat, qt.HasLen, 1)
+	profilerepo := NewProfileRepository(db)
+	authored, err := profilerepo.Posts(ctx, user2.ID, profiles.Page{Limit: 10, ViewerID: user.ID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(authored, qt.HasLen, 0)
+	authoredComments, err := profilerepo.Comments(ctx, user2.ID, profiles.Page{Limit: 10, ViewerID: user.ID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(authoredComments, qt.HasLen, 0)
+	authored, err = profilerepo.Posts(ctx, user2.ID, profiles.Page{Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(authored, qt.HasLen, 1)
+	searchrepo := NewSearchRepository(db)
+	results, err := searchrepo.Search(ctx, search.Query{Text: "reply", Limit: 10, ViewerID: user.ID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 0)
+	results, err = searchrepo.Search(ctx, search.Query{Text: "reply", Limit: 10, ViewerID: user2.ID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 1)
+
+	c.Assert(repo.Unblock(ctx, user.ID, user2.ID), qt.IsNil)
+	c.Assert(list(user.ID), qt.HasLen, 2)
+}
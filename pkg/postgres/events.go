@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/lib/pq"
+)
+
+// EventChannel is the channel events are sent over between instances
+const EventChannel = "upboat_events"
+
+// EventPublisher implements `events.Publisher` with NOTIFY,
+// every instance listening with ListenEvents gets the event, the one publishing included.
+type EventPublisher struct {
+	db *sql.DB
+}
+
+// NewEventPublisher is a constructor
+func NewEventPublisher(db *sql.DB) events.Publisher {
+	return &EventPublisher{db: db}
+}
+
+func (p *EventPublisher) Publish(ctx context.Context, e events.Event) error {
+	op := errors.Op("events.Publisher.Publish")
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if _, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, EventChannel, string(payload)); err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// ListenEvents relays events from EventChannel to hub until ctx is done.
+// It opens its own connection, reconnecting as needed; events sent while disconnected are missed.
+// Problems with the connection or payloads are passed to report.
+func ListenEvents(ctx context.Context, connInfo string, hub events.Publisher, report func(error)) error {
+	listener := pq.NewListener(connInfo, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			report(err)
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(EventChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// nil after reconnecting
+			if n == nil {
+				continue
+			}
+			e := events.Event{}
+			if err := json.Unmarshal([]byte(n.Extra), &e); err != nil {
+				report(err)
+				continue
+			}
+			hub.Publish(ctx, e)
+		case <-time.After(90 * time.Second):
+			// make sure the connection is still there
+			go listener.Ping()
+		}
+	}
+}
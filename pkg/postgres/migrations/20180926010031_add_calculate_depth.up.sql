@@ -1,6 +1,6 @@
 CREATE OR REPLACE FUNCTION calculate_depth(parent_id integer) 
 RETURNS integer AS $$
-DECLARE parent_depth INTEGER
+DECLARE parent_depth INTEGER;
 BEGIN
         IF parent_id IS NULL THEN
             RETURN 0;
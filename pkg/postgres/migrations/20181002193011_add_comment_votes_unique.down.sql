@@ -0,0 +1 @@
+ALTER TABLE comment_votes DROP CONSTRAINT IF EXISTS comment_votes_voter_id_comment_id_key;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE comment_votes ADD CONSTRAINT comment_votes_voter_id_comment_id_key UNIQUE(voter_id, comment_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+CREATE INDEX comments_post_id_parent_id_idx ON comments(post_id, parent_id, id);
+CREATE INDEX comments_parent_id_idx ON comments(parent_id, id);
\ No newline at end of file
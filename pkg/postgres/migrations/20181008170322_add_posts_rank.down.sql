@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS posts_rank_idx;
+ALTER TABLE posts DROP COLUMN IF EXISTS ranked;
+ALTER TABLE posts DROP COLUMN IF EXISTS rank;
\ No newline at end of file
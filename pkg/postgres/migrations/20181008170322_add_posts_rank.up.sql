@@ -0,0 +1,4 @@
+ALTER TABLE posts ADD COLUMN rank DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN ranked TIMESTAMP NOT NULL DEFAULT now();
+UPDATE posts SET ranked = created;
+CREATE INDEX posts_rank_idx ON posts(rank DESC, id DESC) WHERE deleted IS NULL;
\ No newline at end of file
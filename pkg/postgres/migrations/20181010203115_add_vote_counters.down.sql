@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS posts_score_idx;
+ALTER TABLE comments DROP COLUMN upvotes, DROP COLUMN downvotes, DROP COLUMN score;
+ALTER TABLE posts DROP COLUMN upvotes, DROP COLUMN downvotes, DROP COLUMN score;
\ No newline at end of file
@@ -0,0 +1,25 @@
+ALTER TABLE posts
+    ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments
+    ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
+
+UPDATE posts p SET upvotes = v.up, downvotes = v.down, score = v.up - v.down
+FROM (
+    SELECT post_id,
+        COUNT(*) FILTER (WHERE delta > 0) AS up,
+        COUNT(*) FILTER (WHERE delta < 0) AS down
+    FROM post_votes GROUP BY post_id
+) v WHERE p.id = v.post_id;
+UPDATE comments c SET upvotes = v.up, downvotes = v.down, score = v.up - v.down
+FROM (
+    SELECT comment_id,
+        COUNT(*) FILTER (WHERE delta > 0) AS up,
+        COUNT(*) FILTER (WHERE delta < 0) AS down
+    FROM comment_votes GROUP BY comment_id
+) v WHERE c.id = v.comment_id;
+
+CREATE INDEX posts_score_idx ON posts(score DESC, id DESC) WHERE deleted IS NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS comments_search_idx;
+DROP INDEX IF EXISTS posts_search_idx;
+ALTER TABLE comments DROP COLUMN IF EXISTS search;
+ALTER TABLE posts DROP COLUMN IF EXISTS search;
\ No newline at end of file
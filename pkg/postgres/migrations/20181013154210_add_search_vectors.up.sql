@@ -0,0 +1,9 @@
+ALTER TABLE posts ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(body, '')), 'B')
+) STORED;
+ALTER TABLE comments ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('english', coalesce(body, ''))
+) STORED;
+CREATE INDEX posts_search_idx ON posts USING GIN(search);
+CREATE INDEX comments_search_idx ON comments USING GIN(search);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS posts_domain_idx;
+DROP INDEX IF EXISTS posts_url_idx;
+ALTER TABLE posts DROP COLUMN IF EXISTS domain;
+ALTER TABLE posts DROP COLUMN IF EXISTS url;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE posts ADD COLUMN url TEXT NULL;
+ALTER TABLE posts ADD COLUMN domain TEXT NULL;
+CREATE INDEX posts_url_idx ON posts(url, created DESC) WHERE url IS NOT NULL;
+CREATE INDEX posts_domain_idx ON posts(domain, id) WHERE domain IS NOT NULL;
\ No newline at end of file
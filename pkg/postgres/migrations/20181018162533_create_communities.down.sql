@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS community_id;
+DROP TABLE IF EXISTS community_members;
+DROP TABLE IF EXISTS communities;
\ No newline at end of file
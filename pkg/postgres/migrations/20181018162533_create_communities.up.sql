@@ -0,0 +1,18 @@
+CREATE TABLE communities(
+    id serial PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rules TEXT NOT NULL DEFAULT '',
+    creator_id INTEGER REFERENCES users(id),
+    members INTEGER NOT NULL DEFAULT 0,
+    created TIMESTAMP DEFAULT now()
+);
+CREATE UNIQUE INDEX communities_name_idx ON communities(lower(name));
+CREATE TABLE community_members(
+    community_id INTEGER REFERENCES communities(id),
+    user_id INTEGER REFERENCES users(id),
+    joined TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(community_id, user_id)
+);
+ALTER TABLE posts ADD COLUMN community_id INTEGER NULL REFERENCES communities(id);
+CREATE INDEX posts_community_id_idx ON posts(community_id, id) WHERE community_id IS NOT NULL;
\ No newline at end of file
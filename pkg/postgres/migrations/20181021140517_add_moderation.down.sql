@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS mod_log;
+DROP FUNCTION IF EXISTS mod_log_append_only();
+DROP TABLE IF EXISTS moderators;
+ALTER TABLE comments DROP COLUMN IF EXISTS removed;
+ALTER TABLE posts DROP COLUMN IF EXISTS locked;
+ALTER TABLE posts DROP COLUMN IF EXISTS removed;
\ No newline at end of file
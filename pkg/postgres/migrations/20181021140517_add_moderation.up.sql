@@ -0,0 +1,30 @@
+ALTER TABLE posts ADD COLUMN removed TIMESTAMP NULL;
+ALTER TABLE posts ADD COLUMN locked TIMESTAMP NULL;
+ALTER TABLE comments ADD COLUMN removed TIMESTAMP NULL;
+CREATE TABLE moderators(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    community_id INTEGER NULL REFERENCES communities(id),
+    added TIMESTAMP DEFAULT now()
+);
+CREATE UNIQUE INDEX moderators_user_id_community_id_idx ON moderators(user_id, COALESCE(community_id, 0));
+INSERT INTO moderators(user_id, community_id) SELECT creator_id, id FROM communities WHERE creator_id IS NOT NULL;
+CREATE TABLE mod_log(
+    id serial PRIMARY KEY,
+    moderator_id INTEGER NULL REFERENCES users(id),
+    community_id INTEGER NULL REFERENCES communities(id),
+    action TEXT NOT NULL,
+    target_id INTEGER NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP DEFAULT now()
+);
+CREATE INDEX mod_log_community_id_idx ON mod_log(community_id, id);
+CREATE INDEX mod_log_moderator_id_idx ON mod_log(moderator_id, id);
+CREATE OR REPLACE FUNCTION mod_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'mod_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER mod_log_no_update BEFORE UPDATE OR DELETE ON mod_log
+    FOR EACH ROW EXECUTE PROCEDURE mod_log_append_only();
+CREATE TRIGGER mod_log_no_truncate BEFORE TRUNCATE ON mod_log
+    FOR EACH STATEMENT EXECUTE PROCEDURE mod_log_append_only();
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE reports(
+    id serial PRIMARY KEY,
+    reporter_id INTEGER NOT NULL REFERENCES users(id),
+    kind TEXT NOT NULL,
+    target_id INTEGER NOT NULL,
+    community_id INTEGER NULL REFERENCES communities(id),
+    reason TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    closed_by INTEGER NULL REFERENCES users(id),
+    closed TIMESTAMP NULL,
+    created TIMESTAMP DEFAULT now(),
+    UNIQUE(reporter_id, kind, target_id)
+);
+CREATE INDEX reports_open_idx ON reports(kind, target_id) WHERE status = 'open';
+CREATE INDEX reports_community_id_idx ON reports(community_id) WHERE status = 'open';
\ No newline at end of file
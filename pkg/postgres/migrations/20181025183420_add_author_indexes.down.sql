@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS comment_votes_comment_id_idx;
+DROP INDEX IF EXISTS post_votes_post_id_idx;
+DROP INDEX IF EXISTS comments_commenter_id_idx;
+DROP INDEX IF EXISTS posts_author_id_idx;
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE INDEX posts_author_id_idx ON posts(author_id, id);
+CREATE INDEX comments_commenter_id_idx ON comments(commenter_id, id);
+CREATE INDEX post_votes_post_id_idx ON post_votes(post_id);
+CREATE INDEX comment_votes_comment_id_idx ON comment_votes(comment_id);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE user_tokens(
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    kind TEXT NOT NULL,
+    hash TEXT NOT NULL UNIQUE,
+    expires TIMESTAMP NOT NULL,
+    used TIMESTAMP NULL,
+    created TIMESTAMP DEFAULT now()
+);
+CREATE INDEX user_tokens_user_id_idx ON user_tokens(user_id, kind) WHERE used IS NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+UPDATE users SET email_verified_at = created;
\ No newline at end of file
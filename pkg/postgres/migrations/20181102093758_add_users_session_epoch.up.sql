@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN session_epoch INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM users WHERE uid = '00000000-0000-0000-0000-000000000000';
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO users(uid, username, email, hash, deleted)
+VALUES('00000000-0000-0000-0000-000000000000', '[deleted]', '[deleted]', '', now());
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE api_tokens(
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    name TEXT NOT NULL,
+    scopes TEXT[] NOT NULL,
+    hash TEXT NOT NULL UNIQUE,
+    created TIMESTAMP DEFAULT now(),
+    last_used TIMESTAMP NULL,
+    revoked TIMESTAMP NULL
+);
+CREATE INDEX api_tokens_user_id_idx ON api_tokens(user_id, id) WHERE revoked IS NULL;
\ No newline at end of file
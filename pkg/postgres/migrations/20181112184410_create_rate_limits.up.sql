@@ -0,0 +1,6 @@
+CREATE TABLE rate_limits(
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated TIMESTAMP NOT NULL
+);
+CREATE INDEX rate_limits_updated_idx ON rate_limits(updated);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE login_failures;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE login_failures(
+    key TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL,
+    last_failed TIMESTAMP NOT NULL
+);
\ No newline at end of file
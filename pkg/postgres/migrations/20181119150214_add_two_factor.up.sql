@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN totp_secret TEXT NULL;
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP NULL;
+ALTER TABLE users ADD COLUMN totp_step BIGINT NOT NULL DEFAULT 0;
+CREATE TABLE recovery_codes(
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    hash TEXT NOT NULL,
+    used TIMESTAMP NULL,
+    created TIMESTAMP DEFAULT now()
+);
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes(user_id) WHERE used IS NULL;
\ No newline at end of file
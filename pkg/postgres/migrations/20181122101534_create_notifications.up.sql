@@ -0,0 +1,17 @@
+CREATE TABLE notifications(
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    kind TEXT NOT NULL,
+    actor_id INTEGER NOT NULL REFERENCES users(id),
+    post_id INTEGER NOT NULL REFERENCES posts(id),
+    comment_id INTEGER NULL REFERENCES comments(id),
+    read_at TIMESTAMP NULL,
+    created TIMESTAMP DEFAULT now()
+);
+CREATE INDEX notifications_user_id_idx ON notifications(user_id, id DESC);
+CREATE INDEX notifications_unread_idx ON notifications(user_id) WHERE read_at IS NULL;
+CREATE TABLE notification_mutes(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    kind TEXT NOT NULL,
+    PRIMARY KEY(user_id, kind)
+);
\ No newline at end of file
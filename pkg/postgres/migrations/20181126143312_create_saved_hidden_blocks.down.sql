@@ -0,0 +1,4 @@
+DROP TABLE blocks;
+DROP TABLE hidden_posts;
+DROP TABLE saved_comments;
+DROP TABLE saved_posts;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE saved_posts(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    post_id INTEGER NOT NULL REFERENCES posts(id),
+    created TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(user_id, post_id)
+);
+CREATE TABLE saved_comments(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    comment_id INTEGER NOT NULL REFERENCES comments(id),
+    created TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(user_id, comment_id)
+);
+CREATE TABLE hidden_posts(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    post_id INTEGER NOT NULL REFERENCES posts(id),
+    created TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(user_id, post_id)
+);
+CREATE TABLE blocks(
+    blocker_id INTEGER NOT NULL REFERENCES users(id),
+    blocked_id INTEGER NOT NULL REFERENCES users(id),
+    created TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(blocker_id, blocked_id)
+);
+CREATE INDEX blocks_blocked_id_idx ON blocks(blocked_id);
\ No newline at end of file
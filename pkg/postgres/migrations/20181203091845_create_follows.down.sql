@@ -0,0 +1,3 @@
+DROP INDEX posts_author_id_score_idx;
+DROP INDEX posts_author_id_rank_idx;
+DROP TABLE follows;
\ No newline at end of file
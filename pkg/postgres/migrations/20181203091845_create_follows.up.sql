@@ -0,0 +1,10 @@
+CREATE TABLE follows(
+    follower_id INTEGER NOT NULL REFERENCES users(id),
+    followed_id INTEGER NOT NULL REFERENCES users(id),
+    created TIMESTAMP DEFAULT now(),
+    PRIMARY KEY(follower_id, followed_id),
+    CHECK(follower_id <> followed_id)
+);
+CREATE INDEX follows_followed_id_idx ON follows(followed_id);
+CREATE INDEX posts_author_id_rank_idx ON posts(author_id, rank DESC, id DESC) WHERE deleted IS NULL;
+CREATE INDEX posts_author_id_score_idx ON posts(author_id, score DESC, id DESC) WHERE deleted IS NULL;
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS comments_legacy_idx;
+DROP INDEX IF EXISTS posts_legacy_idx;
+ALTER TABLE comments DROP COLUMN legacy;
+ALTER TABLE comments DROP COLUMN body_html;
+ALTER TABLE posts DROP COLUMN legacy;
+ALTER TABLE posts DROP COLUMN body_html;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE posts ADD COLUMN body_html TEXT NOT NULL DEFAULT '';
+-- existing bodies predate Markdown, postgres.New renders them and clears the flag
+ALTER TABLE posts ADD COLUMN legacy BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE posts ALTER COLUMN legacy SET DEFAULT false;
+ALTER TABLE comments ADD COLUMN body_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN legacy BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE comments ALTER COLUMN legacy SET DEFAULT false;
+-- keeps the check postgres.New makes on every start from scanning the tables once they're rendered
+CREATE INDEX posts_legacy_idx ON posts(id) WHERE legacy;
+CREATE INDEX comments_legacy_idx ON comments(id) WHERE legacy;
\ No newline at end of file
@@ -0,0 +1 @@
+-- the www. that was stripped from urls isn't known anymore, canonical urls without it are just as valid
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE posts SET url = regexp_replace(url, '^(https?://)www\.', '\1') WHERE url ~ '^https?://www\.';
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX posts_author_id_created_idx;
+DROP INDEX posts_score_idx;
+DROP INDEX posts_created_idx;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- hot listings with hn ranking take recent posts by age and older ones by score
+CREATE INDEX posts_created_idx ON posts(created DESC) WHERE deleted IS NULL;
+CREATE INDEX posts_score_idx ON posts(score DESC, id DESC) WHERE deleted IS NULL;
+CREATE INDEX posts_author_id_created_idx ON posts(author_id, created DESC) WHERE deleted IS NULL;
\ No newline at end of file
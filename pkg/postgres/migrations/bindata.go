@@ -14,7 +14,54 @@
 // 20180926010031_add_calculate_depth.up.sql
 // 20180926010233_create_comment_votes_table.down.sql
 // 20180926010233_create_comment_votes_table.up.sql
-// bindata.go
+// 20181002193011_add_comment_votes_unique.down.sql
+// 20181002193011_add_comment_votes_unique.up.sql
+// 20181004214507_add_comments_tree_indexes.down.sql
+// 20181004214507_add_comments_tree_indexes.up.sql
+// 20181008170322_add_posts_rank.down.sql
+// 20181008170322_add_posts_rank.up.sql
+// 20181010203115_add_vote_counters.down.sql
+// 20181010203115_add_vote_counters.up.sql
+// 20181013154210_add_search_vectors.down.sql
+// 20181013154210_add_search_vectors.up.sql
+// 20181015191842_add_posts_url.down.sql
+// 20181015191842_add_posts_url.up.sql
+// 20181018162533_create_communities.down.sql
+// 20181018162533_create_communities.up.sql
+// 20181021140517_add_moderation.down.sql
+// 20181021140517_add_moderation.up.sql
+// 20181023201104_create_reports.down.sql
+// 20181023201104_create_reports.up.sql
+// 20181025183420_add_author_indexes.down.sql
+// 20181025183420_add_author_indexes.up.sql
+// 20181028110932_create_user_tokens.down.sql
+// 20181028110932_create_user_tokens.up.sql
+// 20181030174215_add_users_email_verified.down.sql
+// 20181030174215_add_users_email_verified.up.sql
+// 20181102093758_add_users_session_epoch.down.sql
+// 20181102093758_add_users_session_epoch.up.sql
+// 20181106152047_add_deleted_user.down.sql
+// 20181106152047_add_deleted_user.up.sql
+// 20181109201536_create_api_tokens.down.sql
+// 20181109201536_create_api_tokens.up.sql
+// 20181112184410_create_rate_limits.down.sql
+// 20181112184410_create_rate_limits.up.sql
+// 20181115123059_create_login_failures.down.sql
+// 20181115123059_create_login_failures.up.sql
+// 20181119150214_add_two_factor.down.sql
+// 20181119150214_add_two_factor.up.sql
+// 20181122101534_create_notifications.down.sql
+// 20181122101534_create_notifications.up.sql
+// 20181126143312_create_saved_hidden_blocks.down.sql
+// 20181126143312_create_saved_hidden_blocks.up.sql
+// 20181203091845_create_follows.down.sql
+// 20181203091845_create_follows.up.sql
+// 20181210160427_add_body_html.down.sql
+// 20181210160427_add_body_html.up.sql
+// 20181212093514_strip_www_from_urls.down.sql
+// 20181212093514_strip_www_from_urls.up.sql
+// 20181214101200_index_posts_by_age_and_score.down.sql
+// 20181214101200_index_posts_by_age_and_score.up.sql
 // DO NOT EDIT!
 
 package migrations
@@ -82,7 +129,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var __20180827021436_users_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x2d\x4e\x2d\x2a\xb6\x06\x04\x00\x00\xff\xff\xc8\x3d\x4e\x55\x1b\x00\x00\x00")
+var __20180827021436_users_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1b\x00\xe4\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x73\x3b\x03\x00\xc8\x3d\x4e\x55\x1b\x00\x00\x00")
 
 func _20180827021436_users_tableDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -97,12 +144,12 @@ func _20180827021436_users_tableDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180827021436_users_table.down.sql", size: 27, mode: os.FileMode(420), modTime: time.Unix(1535316351, 0)}
+	info := bindataFileInfo{name: "20180827021436_users_table.down.sql", size: 27, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180827021436_users_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcc\xb1\xca\xc2\x30\x14\xc5\xf1\xbd\x4f\x71\xc6\x16\xbe\x37\xf8\xa6\xa8\x57\x28\xa6\xb5\xc6\x1b\xb0\x63\x30\x17\x1a\x48\x2b\x24\x16\x5f\x5f\xb4\x2e\x5d\xba\x9e\xdf\x9f\xb3\x37\xa4\x98\xc0\x6a\xa7\x09\x73\x96\x94\xcb\x02\x00\x82\x47\x96\x14\x5c\x44\x67\xea\x46\x99\x1e\x27\xea\xff\xbe\x34\x07\x0f\xa6\x1b\xc3\xb6\xf5\xc5\x12\xda\x33\xa3\xb5\x5a\xff\x34\x4b\x9a\xdc\x28\x1b\x89\x8c\x2e\xc4\x0d\x1f\x5c\x1e\x16\x5e\xef\xf7\x24\xee\x29\x1e\x5c\x37\x74\x65\xd5\x74\x38\xd0\x51\x59\xcd\x98\x1e\xaf\xb2\x5a\x22\x2f\x51\xd6\xd1\xe7\xa0\xa8\xfe\xdf\x01\x00\x00\xff\xff\x4f\x3e\xd3\x4e\xea\x00\x00\x00")
+var __20180827021436_users_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xca\xb1\x0a\xc2\x30\x14\x46\xe1\xbd\x4f\xf1\x8f\x2d\xf8\x06\x4e\x51\xaf\x50\x4c\x63\xad\x37\x60\xc7\x60\x2e\x34\x90\x56\x48\x2c\xbe\xbe\x60\x5d\xba\x74\x3d\xdf\x39\x76\xa4\x98\xc0\xea\xa0\x09\x73\x96\x94\xcb\x02\x00\x82\x47\x96\x14\x5c\x44\xdb\xd5\x8d\xea\x7a\x5c\xa8\xdf\xfd\x68\x0e\x1e\x4c\x0f\x86\x35\xf5\xcd\x12\xcc\x95\x61\xac\xd6\x7f\xcd\x92\x26\x37\xca\xc6\x22\xa3\x0b\x71\xc3\x07\x97\x87\x85\xd7\xfd\x99\xc4\xbd\xc5\x83\xeb\x86\xee\xac\x9a\x16\x27\x3a\x2b\xab\x19\xd3\xeb\x53\x56\xcb\xe4\x25\xca\x7a\x32\x56\xeb\xa2\xda\x7f\x07\x00\x4f\x3e\xd3\x4e\xea\x00\x00\x00")
 
 func _20180827021436_users_tableUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -117,12 +164,12 @@ func _20180827021436_users_tableUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180827021436_users_table.up.sql", size: 234, mode: os.FileMode(420), modTime: time.Unix(1535316298, 0)}
+	info := bindataFileInfo{name: "20180827021436_users_table.up.sql", size: 234, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180828183535_set_utc_timezoneDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2a\x4e\x2d\x51\x28\xc9\xcc\x4d\xad\xca\xcf\x4b\x55\x08\xf1\x57\x50\xcf\xc9\x4f\x4e\xcc\x01\x89\xa8\x5b\x03\x02\x00\x00\xff\xff\xfc\x5b\x1f\x11\x1c\x00\x00\x00")
+var __20180828183535_set_utc_timezoneDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1c\x00\xe3\xff\x73\x65\x74\x20\x74\x69\x6d\x65\x7a\x6f\x6e\x65\x20\x54\x4f\x20\x27\x6c\x6f\x63\x61\x6c\x74\x69\x6d\x65\x27\x3b\x03\x00\xfc\x5b\x1f\x11\x1c\x00\x00\x00")
 
 func _20180828183535_set_utc_timezoneDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -137,12 +184,12 @@ func _20180828183535_set_utc_timezoneDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180828183535_set_utc_timezone.down.sql", size: 28, mode: os.FileMode(420), modTime: time.Unix(1535461567, 0)}
+	info := bindataFileInfo{name: "20180828183535_set_utc_timezone.down.sql", size: 28, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180828183535_set_utc_timezoneUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2a\x4e\x2d\x51\x28\xc9\xcc\x4d\xad\xca\xcf\x4b\x55\x08\xf1\x57\x50\x0f\x0d\x71\x56\xb7\x06\x04\x00\x00\xff\xff\x01\xf6\x08\xbb\x16\x00\x00\x00")
+var __20180828183535_set_utc_timezoneUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x16\x00\xe9\xff\x73\x65\x74\x20\x74\x69\x6d\x65\x7a\x6f\x6e\x65\x20\x54\x4f\x20\x27\x55\x54\x43\x27\x3b\x03\x00\x01\xf6\x08\xbb\x16\x00\x00\x00")
 
 func _20180828183535_set_utc_timezoneUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -157,12 +204,12 @@ func _20180828183535_set_utc_timezoneUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180828183535_set_utc_timezone.up.sql", size: 22, mode: os.FileMode(420), modTime: time.Unix(1535461551, 0)}
+	info := bindataFileInfo{name: "20180828183535_set_utc_timezone.up.sql", size: 22, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180905005007_create_posts_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\xb6\x06\x04\x00\x00\xff\xff\x09\xa2\x6c\xd1\x1b\x00\x00\x00")
+var __20180905005007_create_posts_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1b\x00\xe4\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x73\x74\x73\x3b\x03\x00\x09\xa2\x6c\xd1\x1b\x00\x00\x00")
 
 func _20180905005007_create_posts_tableDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -177,12 +224,12 @@ func _20180905005007_create_posts_tableDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180905005007_create_posts_table.down.sql", size: 27, mode: os.FileMode(420), modTime: time.Unix(1536088831, 0)}
+	info := bindataFileInfo{name: "20180905005007_create_posts_table.down.sql", size: 27, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180905005007_create_posts_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8c\xb1\x0a\xc2\x30\x18\x06\xf7\x3e\xc5\x37\xb6\xe0\x1b\x38\xc5\xfa\x57\x8a\x69\x2c\xe9\x5f\xb0\x93\x54\x13\x30\x50\x8c\x24\x29\xe2\xdb\x8b\xd6\x45\x70\xbd\x3b\xae\xd4\x24\x98\xc0\x62\x23\x09\x77\x1f\x53\xcc\x33\x00\x70\x06\xd1\x06\x37\x4e\x68\x75\xdd\x08\x3d\x60\x4f\xc3\xea\xa3\xc6\x39\x5d\x7d\x38\x39\x83\x5a\x31\xed\x48\x43\x53\x45\x9a\x54\x49\x1d\xe6\x68\x43\xcc\x9d\x29\x96\x36\xb9\x34\x59\x30\x1d\x19\xea\xc0\x50\xbd\x94\x8b\x38\x7b\xf3\xfc\xc7\x2f\xc1\x8e\xc9\x1a\x70\xdd\x50\xc7\xa2\x69\xb1\xa5\x4a\xf4\x92\x71\xf3\x8f\xfc\x7b\x35\x76\xb2\xbf\xd1\x7b\x90\x15\xeb\x57\x00\x00\x00\xff\xff\x13\xa4\x58\xae\xce\x00\x00\x00")
+var __20180905005007_create_posts_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\xc1\x8a\xc2\x30\x14\x85\xe1\x7d\x9f\xe2\x2c\x5b\x98\x37\x98\x55\xa6\x73\x3b\x94\x49\x63\x49\x6f\xc1\xae\xa4\x9a\x0b\x06\x8a\x91\x24\x45\x7c\x7b\xc1\xba\x11\xdc\x9e\xff\xe3\xd4\x96\x14\x13\x58\xfd\x68\xc2\x35\xa4\x9c\xca\x02\x00\xbc\x43\x92\xe8\xe7\x05\xbd\x6d\x3b\x65\x27\xfc\xd3\xf4\xf5\x4c\xf3\x9a\xcf\x21\x1e\xbc\x43\x6b\x98\xfe\xc8\xc2\x52\x43\x96\x4c\x4d\x03\xd6\x24\x31\x95\xde\x55\x9b\xcd\x3e\x2f\x02\xa6\x3d\xc3\xec\x18\x66\xd4\x7a\x0b\xc7\xe0\xee\x9f\xf6\x53\x94\x39\x8b\x03\xb7\x1d\x0d\xac\xba\x1e\xbf\xd4\xa8\x51\x33\x2e\xe1\x56\xbe\x5e\x9d\x2c\xf2\x8e\xcc\xa8\x75\x51\x7d\x3f\x06\x00\x13\xa4\x58\xae\xce\x00\x00\x00")
 
 func _20180905005007_create_posts_tableUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -197,12 +244,12 @@ func _20180905005007_create_posts_tableUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180905005007_create_posts_table.up.sql", size: 206, mode: os.FileMode(420), modTime: time.Unix(1536088816, 0)}
+	info := bindataFileInfo{name: "20180905005007_create_posts_table.up.sql", size: 206, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180905005048_create_post_votes_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x89\x2f\xcb\x2f\x49\x2d\xb6\x06\x04\x00\x00\xff\xff\x2f\xd5\x5f\x4a\x20\x00\x00\x00")
+var __20180905005048_create_post_votes_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x20\x00\xdf\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x73\x74\x5f\x76\x6f\x74\x65\x73\x3b\x03\x00\x2f\xd5\x5f\x4a\x20\x00\x00\x00")
 
 func _20180905005048_create_post_votes_tableDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -217,12 +264,12 @@ func _20180905005048_create_post_votes_tableDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180905005048_create_post_votes_table.down.sql", size: 32, mode: os.FileMode(420), modTime: time.Unix(1536088878, 0)}
+	info := bindataFileInfo{name: "20180905005048_create_post_votes_table.down.sql", size: 32, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180905005048_create_post_votes_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\xb1\xce\x82\x30\x00\xc4\xf1\x9d\xa7\xb8\xb1\xcd\xc7\x37\x30\x3b\x61\x73\x2a\x01\xab\xd6\x32\x30\x11\x92\x76\x68\x42\x82\xa1\xe8\xf3\x1b\x50\x8c\x83\xf3\xff\x77\xa7\x0c\x73\x4b\xd8\x7c\x5b\x11\xb7\x21\x4e\xed\x63\x98\x7c\x14\x09\x00\x04\x87\xe8\xc7\xd0\xf5\x38\x9b\xe2\x98\x9b\x06\x25\x9b\x74\x49\xb3\x1a\xdb\xe0\x50\x68\xcb\x3d\x0d\x0c\x77\x34\xd4\x8a\x57\xdc\xa3\x1f\xa3\x08\x4e\xbe\xe8\xf2\xfa\x5b\xce\xe9\x4b\xd6\xba\xb8\xd4\x14\xeb\x77\xba\x4e\xdf\xd9\xf9\x7e\xea\x3e\x37\xfa\x64\xa1\xeb\xaa\x82\x3a\x50\x95\x62\x8d\x10\xff\x59\x8a\xbf\x4c\xca\x44\x6e\x9e\x01\x00\x00\xff\xff\xed\x55\x93\xc9\xde\x00\x00\x00")
+var __20180905005048_create_post_votes_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\xb1\xce\x82\x30\x00\xc4\xf1\x9d\xa7\xb8\xb1\xcd\xc7\x37\x30\x3b\x61\x73\x2a\x01\xab\xd6\x32\x30\x11\x92\x76\x68\x42\x82\xa1\xe8\xf3\x1b\x50\x8c\x83\xf3\xff\x77\xa7\x0c\x73\x4b\xd8\x7c\x5b\x11\xb7\x21\x4e\xed\x63\x98\x7c\x14\x09\x00\x04\x87\xe8\xc7\xd0\xf5\x38\x9b\xe2\x98\x9b\x06\x25\x9b\x74\x49\xb3\x1a\xdb\xe0\x50\x68\xcb\x3d\x0d\x0c\x77\x34\xd4\x8a\x57\xdc\xa3\x1f\xa3\x08\x4e\xbe\xe8\xf2\xfa\x5b\xce\xe9\x4b\xd6\xba\xb8\xd4\x14\xeb\x77\xba\x4e\xdf\xd9\xf9\x7e\xea\x3e\x37\xfa\x64\xa1\xeb\xaa\x82\x3a\x50\x95\x62\x8d\x10\xff\x59\x8a\xbf\x4c\xca\x44\x6e\x9e\x03\x00\xed\x55\x93\xc9\xde\x00\x00\x00")
 
 func _20180905005048_create_post_votes_tableUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -237,12 +284,12 @@ func _20180905005048_create_post_votes_tableUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180905005048_create_post_votes_table.up.sql", size: 222, mode: os.FileMode(420), modTime: time.Unix(1536088865, 0)}
+	info := bindataFileInfo{name: "20180905005048_create_post_votes_table.up.sql", size: 222, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926005608_create_comments_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\xb6\x06\x04\x00\x00\xff\xff\x0f\x45\x42\x5b\x1e\x00\x00\x00")
+var __20180926005608_create_comments_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1e\x00\xe1\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x3b\x03\x00\x0f\x45\x42\x5b\x1e\x00\x00\x00")
 
 func _20180926005608_create_comments_tableDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -257,12 +304,12 @@ func _20180926005608_create_comments_tableDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926005608_create_comments_table.down.sql", size: 30, mode: os.FileMode(420), modTime: time.Unix(1537903644, 0)}
+	info := bindataFileInfo{name: "20180926005608_create_comments_table.down.sql", size: 30, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926005608_create_comments_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcd\x41\x4b\xc4\x30\x10\x05\xe0\xfb\xfe\x8a\x77\xec\x82\xff\xc0\x53\x5c\x67\xa5\x98\xc6\x25\x3b\x05\x7b\x92\xda\x0c\x18\x68\x9b\x92\x44\xc4\x7f\x2f\xd6\x12\x44\xec\xf5\xbd\x6f\xde\x9c\x2c\x29\x26\xb0\xba\xd3\x84\x21\x4c\x93\xcc\x39\x55\x07\x00\xf0\x0e\x49\xa2\xef\x47\x5c\x6c\xdd\x28\xdb\xe1\x91\xba\x9b\xb5\x5a\x42\xca\x2f\xde\xa1\x36\x4c\x0f\x64\x61\xe9\x4c\x96\xcc\x89\xae\x6b\x95\x2a\xef\x8e\x9b\xec\xa3\xcc\x7b\xb6\xfc\x2b\x7c\x4b\x24\xee\x5c\xbc\x27\x89\xbf\xb8\x93\x25\xbf\x15\x67\x9e\x18\xa6\xd5\xfa\xa7\x7b\x0d\xee\x13\x4c\xcf\xfc\x27\x1f\xa2\xf4\x59\x1c\xb8\x6e\xe8\xca\xaa\xb9\xe0\x9e\xce\xaa\xd5\x8c\x39\x7c\x54\x65\x78\x94\xff\xd1\xf7\xd0\xe1\x78\xfb\x15\x00\x00\xff\xff\x04\x50\x15\xca\x38\x01\x00\x00")
+var __20180926005608_create_comments_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcd\x41\x4b\xc4\x30\x10\x05\xe0\xfb\xfe\x8a\x77\xec\x82\xff\xc0\x53\x5c\x67\xa5\x98\xc6\x25\x3b\x05\x7b\x92\xda\x0c\x18\x68\x9b\x92\x44\xc4\x7f\x2f\xd4\x12\x44\xb6\xd7\xf7\xbe\x99\x77\xb2\xa4\x98\xc0\xea\x41\x13\x86\x30\x4d\x32\xe7\x54\x1d\x00\xc0\x3b\x24\x89\xbe\x1f\x71\xb1\x75\xa3\x6c\x87\x67\xea\xee\xd6\x6a\x09\x29\xbf\x79\x87\xda\x30\x3d\x91\x85\xa5\x33\x59\x32\x27\xba\xae\x55\xaa\xbc\x3b\x6e\xb2\x8f\x32\xef\xd9\xb2\x57\xf8\x96\x48\xdc\xb9\xf8\x4c\x12\xff\x70\x27\x4b\xfe\x28\xce\xbc\x30\x4c\xab\xf5\xef\xf2\x7b\x70\xdf\x60\x7a\xe5\x7f\xf9\x10\xa5\xcf\xe2\xc0\x75\x43\x57\x56\xcd\x05\x8f\x74\x56\xad\x66\xcc\xe1\xab\x2a\x8f\x47\xb9\x8d\x4c\xab\xf5\xe1\x78\xff\x33\x00\x04\x50\x15\xca\x38\x01\x00\x00")
 
 func _20180926005608_create_comments_tableUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -277,12 +324,12 @@ func _20180926005608_create_comments_tableUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926005608_create_comments_table.up.sql", size: 312, mode: os.FileMode(420), modTime: time.Unix(1537903624, 0)}
+	info := bindataFileInfo{name: "20180926005608_create_comments_table.up.sql", size: 312, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926010031_add_calculate_depthDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x70\x0b\xf5\x73\x0e\xf1\xf4\xf7\x53\x48\x4e\xcc\x49\x2e\xcd\x49\x2c\x49\x8d\x4f\x49\x2d\x28\xc9\xb0\x06\x04\x00\x00\xff\xff\xb4\x50\xf3\x71\x1e\x00\x00\x00")
+var __20180926010031_add_calculate_depthDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1e\x00\xe1\xff\x44\x52\x4f\x50\x20\x46\x55\x4e\x43\x54\x49\x4f\x4e\x20\x63\x61\x6c\x63\x75\x6c\x61\x74\x65\x5f\x64\x65\x70\x74\x68\x3b\x03\x00\xb4\x50\xf3\x71\x1e\x00\x00\x00")
 
 func _20180926010031_add_calculate_depthDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -297,12 +344,12 @@ func _20180926010031_add_calculate_depthDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926010031_add_calculate_depth.down.sql", size: 30, mode: os.FileMode(420), modTime: time.Unix(1537903904, 0)}
+	info := bindataFileInfo{name: "20180926010031_add_calculate_depth.down.sql", size: 30, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926010031_add_calculate_depthUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8d\x41\x4f\x83\x40\x14\x84\xef\xfb\x2b\xe6\xc0\x41\xe3\x45\xcf\xc4\xc3\x0a\x03\xdd\x64\x5d\xcc\x63\x89\xc7\x86\xc0\xa6\x92\x50\xc4\x76\xfd\xff\x46\xa5\xdd\x74\x6e\x6f\xf2\xbe\xf9\x0a\xa1\xf6\x44\x23\x10\xbe\x59\x5d\x10\x55\xe7\x0a\x6f\x1a\x87\xa1\x9f\x87\xef\xb9\x8f\x61\x3f\x86\x35\x7e\xdc\xad\xfd\x29\x2c\x71\x3f\x8d\x98\x96\x18\x0e\xe1\x74\x0f\x25\xf4\x9d\xb8\xf6\xd2\x40\xb7\xc8\x32\x55\xb2\xb0\x5a\x88\x8d\xf8\xc3\x61\x9c\x67\x4d\x51\x2f\xac\x8d\x53\xd8\x62\x2a\xa4\x5d\xd3\xc2\x75\xd6\xc2\xef\x98\x3e\x7e\xf3\xaf\xc1\x63\x7e\x6d\xe9\x4a\x98\x2a\xdd\x2d\x2d\x0b\x8f\xab\xaa\xb9\x95\x57\xd2\xbc\x62\xf8\x3c\x1e\xc3\x12\xcf\x78\xdf\x51\x88\x69\xc4\x73\x92\xa7\xa9\x4d\x76\xc3\x3f\xe0\x29\x57\x74\x65\xae\xb2\x0c\x56\xbb\xba\xd3\x35\xb1\xce\xeb\xe1\xfc\x35\xe7\x3f\x01\x00\x00\xff\xff\x11\x7e\x5b\xf2\x45\x01\x00\x00")
+var __20180926010031_add_calculate_depthUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8d\x31\x4f\xc3\x30\x14\x84\x77\xff\x8a\x1b\x32\x80\x58\x60\xb6\x18\x4c\x72\x49\x2d\x19\x07\xbd\x38\x62\xac\xa2\xc4\x2a\x91\xd2\x10\x5a\xf3\xff\x11\xd0\x36\x6a\xdf\x9b\xee\x74\x9f\xbe\x5c\x68\x02\x51\x0b\x84\x6f\xce\xe4\x44\xd9\xfa\x3c\xd8\xda\xa3\xef\xa6\xfe\x7b\xea\x52\xdc\x0e\x71\x49\x1f\x77\x4b\x77\x88\x73\xda\x8e\x03\xc6\x39\xc5\x5d\x3c\xdc\x43\x09\x43\x2b\xbe\x39\x37\x30\x0d\xb2\x4c\x15\xcc\x9d\x11\xe2\x44\xfc\xe1\xb0\x3e\xb0\xa2\x68\xf5\xc2\xca\x7a\x85\xd3\xd9\xf2\x3c\x1b\x07\xd8\x06\xbe\x75\x0e\x61\xc3\x75\xf1\xfb\xff\x1e\x3c\xea\x4b\x4b\x5f\xc0\x96\x6b\x6e\xe8\x98\x07\x5c\x5c\xf5\xb5\xbd\x94\xfa\x15\xfd\xe7\x7e\x1f\xe7\x74\xc4\xfb\x86\x42\x8c\x03\x9e\x57\xb9\x56\x37\xb2\x2b\xfe\x01\x4f\x5a\xd1\x17\x5a\x65\x19\x9c\xf1\x55\x6b\x2a\x62\x99\x96\xdd\xf1\x6b\xd2\x3f\x03\x00\x85\x3f\x5d\x32\x46\x01\x00\x00")
 
 func _20180926010031_add_calculate_depthUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -317,12 +364,12 @@ func _20180926010031_add_calculate_depthUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926010031_add_calculate_depth.up.sql", size: 325, mode: os.FileMode(420), modTime: time.Unix(1537903917, 0)}
+	info := bindataFileInfo{name: "20180926010031_add_calculate_depth.up.sql", size: 326, mode: os.FileMode(420), modTime: time.Unix(1792220332, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926010233_create_comment_votes_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x89\x2f\xcb\x2f\x49\x2d\xb6\x06\x04\x00\x00\xff\xff\x25\x42\x08\x7d\x23\x00\x00\x00")
+var __20180926010233_create_comment_votes_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x23\x00\xdc\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x76\x6f\x74\x65\x73\x3b\x03\x00\x25\x42\x08\x7d\x23\x00\x00\x00")
 
 func _20180926010233_create_comment_votes_tableDownSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -337,12 +384,12 @@ func _20180926010233_create_comment_votes_tableDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926010233_create_comment_votes_table.down.sql", size: 35, mode: os.FileMode(420), modTime: time.Unix(1537903982, 0)}
+	info := bindataFileInfo{name: "20180926010233_create_comment_votes_table.down.sql", size: 35, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __20180926010233_create_comment_votes_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\xbd\x0e\x82\x30\x14\x47\xf1\x9d\xa7\xf8\x8f\x6d\xc4\x81\xd9\x09\x9b\xab\x12\xb0\x9a\x6b\x1d\x98\x08\xb1\x1d\x9a\x80\x24\x6d\xf5\xf9\x8d\x1f\x18\x07\xe7\xf3\xcb\x51\x4c\xa5\x21\x98\x72\xdd\x10\x2e\xd3\x38\xba\x6b\xea\xee\x53\x72\x51\x64\x00\xe0\x2d\xa2\x0b\xbe\x1f\x70\xe4\x6a\x5f\x72\x8b\x9a\xda\xfc\x95\x9e\x2a\x74\xde\xa2\xd2\x86\xb6\xc4\x60\xda\x10\x93\x56\x74\xc2\x2d\xba\x10\x85\xb7\xf2\x4d\xe7\xf1\x7f\xfc\xa9\x3f\xde\xba\x21\xf5\x5f\xaa\x0f\x06\xfa\xdc\x34\x50\x3b\x52\xb5\x98\x23\xc4\xb2\xc8\xb1\x28\xa4\xcc\xe4\xea\x11\x00\x00\xff\xff\xfa\x71\x82\x6d\xc8\x00\x00\x00")
+var __20180926010233_create_comment_votes_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\xbd\x0e\x82\x30\x14\x47\xf1\x9d\xa7\xf8\x8f\x6d\xc4\x81\xd9\x09\x9b\xab\x12\xb0\x9a\x6b\x1d\x98\x08\xb1\x1d\x9a\x80\x24\x6d\xf5\xf9\x8d\x1f\x18\x07\xe7\xf3\xcb\x51\x4c\xa5\x21\x98\x72\xdd\x10\x2e\xd3\x38\xba\x6b\xea\xee\x53\x72\x51\x64\x00\xe0\x2d\xa2\x0b\xbe\x1f\x70\xe4\x6a\x5f\x72\x8b\x9a\xda\xfc\x95\x9e\x2a\x74\xde\xa2\xd2\x86\xb6\xc4\x60\xda\x10\x93\x56\x74\xc2\x2d\xba\x10\x85\xb7\xf2\x4d\xe7\xf1\x7f\xfc\xa9\x3f\xde\xba\x21\xf5\x5f\xaa\x0f\x06\xfa\xdc\x34\x50\x3b\x52\xb5\x98\x23\xc4\xb2\xc8\xb1\x28\xa4\xcc\xe4\xea\x31\x00\xfa\x71\x82\x6d\xc8\x00\x00\x00")
 
 func _20180926010233_create_comment_votes_tableUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -357,27 +404,967 @@ func _20180926010233_create_comment_votes_tableUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20180926010233_create_comment_votes_table.up.sql", size: 200, mode: os.FileMode(420), modTime: time.Unix(1537904034, 0)}
+	info := bindataFileInfo{name: "20180926010233_create_comment_votes_table.up.sql", size: 200, mode: os.FileMode(420), modTime: time.Unix(1537914708, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _bindataGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x5b\xdf\x6f\xe3\x36\x12\x7e\xb6\xfe\x0a\x35\x40\x0b\xfb\x90\xcb\x4a\xa2\x7e\x50\x01\xf6\xa5\xdd\x1e\xd0\x87\x6b\x81\x6b\xfb\x74\x3c\x04\x14\x49\xa5\xc6\x25\x56\x6a\x3b\x2d\x77\x17\xfb\xbf\x1f\x3e\xce\x28\xf1\x66\x6d\x4b\x76\x9c\xa0\x07\x6c\xd6\xb6\x2c\x0e\x67\x46\xfc\x66\xbe\x19\xd2\x6f\xde\xc4\xdf\x75\xd6\xc5\xd7\x6e\xe1\x96\x7a\xed\x6c\xdc\xbc\x8f\xaf\xbb\xbf\x37\xf3\x85\xd5\x6b\x7d\x11\xbd\x79\x13\xaf\xba\xfb\xa5\x71\xab\x4b\xbc\xcf\x92\x54\x26\x32\xab\x92\x2c\xcd\x45\x79\x75\xbf\x72\xcb\xd5\xd5\x5a\x37\x37\xee\xc2\x76\x7f\x2e\x2e\x56\xbf\xdf\x0c\xdc\x76\x7f\xf7\xe4\x26\x99\x4a\x51\x88\xe2\x6a\xe5\xd6\x57\xf7\x6b\x73\xb5\x9e\xdf\xba\x0f\xdd\x62\xab\xc0\x9d\xf7\x3e\x91\x5a\x27\x45\x82\x7f\xd5\x95\x59\x3a\xbd\x76\x57\x77\xdd\x6a\xbd\x53\xd1\xbd\x77\x6f\x97\x9c\xcb\xcd\x7b\xaf\xfe\xe8\xd6\x6e\x48\xfc\x9e\x21\x4f\xe7\xc8\xca\x24\x29\xca\xe4\x61\x80\xe9\x6e\x6f\xdd\x62\x8f\x01\x03\x03\xb6\xc8\x4f\x93\x44\xa4\x57\xda\xda\x2b\xa3\x6f\xcc\xfd\x0d\x46\x59\x77\xb7\xfe\x6d\xbb\xf4\xdd\xb7\x6f\x95\x9d\x09\xf1\x44\x95\x01\x17\x8d\x19\xf5\x38\x53\xbf\x3a\xaf\x3b\x7c\x7a\xf7\x53\xfc\xe3\x4f\xbf\xc4\xdf\xbf\xfb\xe1\x97\xaf\xa2\xe8\x4e\x9b\xff\xea\x6b\x17\xdf\xce\xaf\x97\x7a\x3d\xef\x16\xab\x28\x9a\xdf\xde\x75\xcb\x75\x3c\x8d\x26\x67\xcd\xfb\xb5\x5b\x9d\x45\x93\x33\xd3\xdd\xde\x2d\xdd\x6a\xf5\xe6\xfa\xc3\xfc\x0e\x17\xda\xdb\x35\x5e\xe6\x1d\xfd\xff\x66\xde\xdd\xaf\xe7\x37\xf8\xd0\x85\x01\x77\x7a\xfd\xdb\x9b\x76\x7e\xe3\xf0\x06\x17\x56\xeb\xe5\x7c\x71\x1d\xbe\xc3\x3a\x3c\x8b\x66\x51\xd4\xde\x2f\x4c\xaf\xde\xbf\x9c\xb6\x53\xbc\x89\xff\xfd\x1f\x4c\x7b\x1e\x2f\xf4\xad\x8b\x69\xd8\x2c\x9e\xf6\x57\xdd\x72\xd9\x2d\x67\xf1\xc7\x68\x72\xfd\x21\x7c\x8a\x2f\xdf\xc6\xd0\xea\xe2\x47\xf7\x27\x84\xb8\xe5\x34\xa8\x8d\xcf\xdf\xde\xb7\xad\x5b\x06\xb1\xb3\x59\x34\x99\xb7\x61\xc0\x57\x6f\xe3\xc5\xfc\x06\x22\x26\x4b\xb7\xbe\x5f\x2e\xf0\xf1\x3c\x6e\x6f\xd7\x17\xdf\x43\x7a\x3b\x3d\x83\xa0\xf8\xeb\xdf\x2f\xe3\xaf\xff\x38\x23\x4d\xc2\x5c\xb3\x68\xf2\x29\x8a\x26\x7f\xe8\x65\xdc\xdc\xb7\x31\xcd\x43\x93\x44\x93\x2b\x52\xe7\x6d\x3c\xef\x2e\xbe\xeb\xee\xde\x4f\xbf\x69\xee\xdb\xf3\xf8\xfa\xc3\x2c\x9a\x98\x9b\xef\x7b\x4d\x2f\xbe\xbb\xe9\x56\x6e\x3a\x8b\x4e\xa5\x0f\xc4\x90\xfc\x1d\x82\xdc\x72\x49\x7a\xf3\xc5\xe6\xbe\xbd\xf8\x16\xaa\x4f\x67\xe7\xb8\x23\xfa\x14\x45\xeb\xf7\x77\x2e\xd6\xab\x95\x5b\xc3\xe5\xf7\x66\x0d\x29\xc1\x3e\x7e\x1e\xd1\x64\xbe\x68\xbb\x38\xee\x56\x17\xff\x98\xdf\xb8\x1f\x16\x6d\xf7\x30\x8e\x1f\x61\x7f\x7d\x43\x42\x78\x86\x71\xcc\x8f\x31\x9a\xac\xe6\x1f\xc2\xe7\xf9\x62\x5d\xe6\xd1\xe4\x16\xd1\x34\x7e\x10\xfa\xcf\xce\xba\x70\xf1\x97\xf9\xad\x8b\xb1\x4c\x2e\xf0\x0e\xf3\x84\xa5\x32\x6d\xe7\x4f\xe7\x9a\xc5\x3f\xea\x5b\x37\x9d\xf1\x0c\x98\x93\xad\x6c\xe7\x17\x98\x3d\xfa\xb4\x67\xec\xcf\xf3\x0f\x18\x1b\xb4\xf9\x7c\x28\x14\xdd\x3b\x14\xba\x4e\x67\x9b\x9a\x7f\x2e\x00\xa6\x0d\x09\x80\x71\xd3\xd9\xa3\xa1\x5f\x48\x60\xeb\x77\x0b\xf9\x61\xf5\x6e\xbe\x9c\xce\xe2\xa6\xeb\x6e\x36\x47\xeb\x9b\xd5\x80\xe5\xef\x57\x64\xb8\x5b\xb6\xda\xb8\x8f\x9f\x36\x46\xf3\x92\xc0\x2a\xbf\xba\xda\x9d\x9c\xde\x75\x7f\x2e\x7e\xfe\xfd\x26\x7e\xcb\x2b\x64\x7a\xa6\x7c\xda\x2a\x2f\x1b\xe5\x13\xa9\x7c\x92\x6c\xff\x6b\x5b\xe5\xab\x4c\xf9\xa4\x56\xbe\xc5\x6b\xab\x7c\x91\xd0\x98\x2a\x55\xbe\xca\xe9\x3a\xde\x17\x85\xf2\x6d\x42\xd7\x0a\xa1\x7c\x95\x28\x2f\x2d\x5d\x4b\x8c\xf2\x89\x23\x39\x45\xa9\x7c\x26\x95\xcf\xac\xf2\xb9\xa3\xd7\x4c\x2b\xdf\x94\xca\x27\xf8\xcb\x3f\x9f\x1f\x7f\x46\x2a\x2f\xf8\x7e\xcc\x93\x36\x9b\x7a\x9e\xf5\xf1\x69\xd8\x03\x8c\xa5\x6d\x31\xaa\x47\xdc\x46\x8c\x8b\x26\x93\x11\x5e\x3d\x8f\x26\x93\xb3\x11\xfc\xe1\xec\x3c\x9a\xcc\x1e\x00\x32\x2c\x17\x6a\xfe\x2d\xe0\x7c\x53\xcd\x00\xf4\x87\x68\x3a\xda\xe2\xa1\x08\xf6\x10\x78\x42\xe8\xb8\x7c\xfb\x74\x19\x7e\x04\x40\x2f\xe3\x71\x76\xc6\x80\xe4\x65\x9c\x55\xe7\x31\xb0\x75\xb9\x09\xbd\x69\x9e\x25\xb3\x70\x1d\x88\xb9\x24\x44\xfd\xba\x98\xfb\x69\x5a\x88\x42\xa4\xa5\x28\xd2\xf3\x38\x99\x7d\x8a\x26\x1a\x7a\x7c\x13\x3c\xf0\x31\x98\x7d\x19\xb3\xf5\x50\xf2\x32\xfc\xff\xe9\xe1\xc1\xe9\xf3\xb1\x68\xf8\xf5\xee\x68\x2c\x18\xe5\x8d\x51\xbe\x49\x95\x37\x5a\x79\x93\x29\x2f\x12\xe5\xd3\x5c\x79\x83\xf5\x9f\x2a\xdf\x60\x9d\xb6\x84\x09\x53\x2a\x9f\x96\xca\x37\x4e\x79\x51\x29\xdf\x4a\xe5\x75\xa9\xbc\x96\xca\x17\x15\xe1\x00\x9f\x9b\x82\xef\x6d\x94\x6f\x12\xe5\x4b\xc1\x72\x2b\xe5\x53\xad\x7c\x0e\xbc\x34\xca\x67\x39\xc9\x2b\x5a\xfa\x6b\x72\xe5\x33\x60\xc2\x2a\xdf\x68\xe5\x6b\xa7\xbc\x6d\x95\xaf\xf1\x9d\xa0\x39\x75\xae\x7c\x2d\x95\x37\x90\xab\x95\xd7\x15\xe1\xb0\x12\xca\xd7\xa5\xf2\x35\x74\x87\xed\x19\xd9\x29\x33\xe5\xf3\x8a\xbe\x83\x5d\x85\x51\x3e\xcf\x95\x2f\x2b\xe5\x1d\x74\xc1\x98\x5a\xf9\x14\xd8\xe5\x6b\xf0\x4d\xb0\x11\xd8\xad\x28\x4e\x68\xb6\xc7\x08\xc2\x76\x5b\x90\x8f\xd2\x4c\x79\xab\x95\x17\x42\x79\x2d\xc8\xf6\x82\x65\x60\x7c\xde\x28\x5f\x6b\xe5\xad\x21\xff\x40\x86\xac\x95\x97\x86\x6c\x35\x98\xc3\xd2\x33\x83\x6e\xd0\x23\xf8\xc4\x29\xef\x10\x2f\x2a\xf2\x93\x83\x7e\xac\x27\xee\x83\x2f\x10\x9b\xca\x42\x79\x5b\xd0\x7b\x21\x95\xb7\x89\xf2\x05\xe2\x57\xad\xbc\xb1\xe4\x2b\x8c\xd1\xb0\x29\x23\xdd\xb2\x4c\xf9\xac\xa5\xfb\x6c\xa9\xbc\x4d\x95\x77\xf0\x6d\x42\xcf\xb2\x65\xbf\x27\xe9\x97\xb1\x0b\x6b\x41\xe0\x7b\x41\xf1\x0b\xfe\x3a\x2c\x76\x85\xf5\x7a\xd2\xc8\x15\x24\x0e\xc5\x2d\xa2\xa5\xa3\xa3\x56\x90\xf9\xcc\x98\xb5\x69\xe9\x2b\x44\xac\xde\xc2\x3e\x5e\x89\xfc\x98\x80\x95\xd5\xf2\x94\x01\x6b\x57\x29\xf8\x9c\x1c\x8e\xfc\xda\xe7\x5a\xac\x61\xe0\xca\xd4\x14\xcb\x72\xab\xbc\xb6\x1c\xcf\x5a\xc2\x1f\x72\x2c\x64\x22\x9e\x21\x4e\x21\xe7\xe3\x3b\x8c\xc1\x7a\x86\x2c\x8c\xc5\x7a\x07\x36\x43\x3c\x83\x1e\xe2\x31\x8e\x6c\x62\xa0\x35\xf4\x3d\xf4\x4d\x53\xe5\x53\xb3\x17\x03\x03\x2e\x38\x1e\x0a\x03\x82\x37\x11\x31\x58\xbd\x6f\x07\xc6\xc0\x0c\x87\xe0\x63\x9c\x17\x4e\x0c\x93\x11\x66\xf7\x68\x91\x07\x83\x25\x2f\xd3\xa2\xac\x5e\x03\x2c\xc7\xa7\xf8\x53\x40\x05\xe9\x0f\x29\x2a\xd0\x62\xa4\xf7\x6a\x37\xb5\x05\x84\xda\x92\x64\x34\x0d\xa5\xb2\x23\xa0\xf1\xcc\x1c\xb1\x57\xec\x28\x58\xec\xcb\x16\x7b\xa5\x9f\x02\x12\x2f\x98\x37\x06\x0d\xee\xe1\x90\x1d\x05\x87\x53\x92\xdd\x7d\xed\xbe\xbf\x62\x05\x88\xaa\x0e\xac\x2a\x63\xa6\xb6\xaf\x02\xc4\x38\x9d\x29\x5f\x1a\x62\x5f\x7b\x2b\xc0\x11\x8e\x38\x16\x2a\x23\x44\x3f\xc2\x65\x54\xb7\x76\x1b\x64\x46\xcc\x32\x1e\x36\xe3\xbd\x71\x52\xe8\x8c\x34\xfe\xe8\x5a\xb1\x4c\xa4\x94\xe2\x95\xe0\x73\x7c\x3e\xc1\x82\x95\x5c\x32\x26\x9b\x25\xa3\xa4\xc5\x8e\x72\x05\xe5\x01\x4a\x23\x94\x28\x00\x81\x4b\x68\x81\xa3\x3c\x09\x65\xa5\xa6\xdc\x22\xb5\xf2\x65\xad\x7c\x66\x94\x77\x35\x97\x80\x89\xf2\xb5\x50\xbe\x40\x69\xc8\x65\x23\x00\x1a\xca\xa5\x9c\x80\xe5\x50\x72\x35\xa4\x2f\xca\x97\xbc\x20\x70\xa2\x54\x15\x8d\xf2\x1a\xe5\x49\x4e\xf7\x3f\x94\x8a\x28\x79\x04\x97\x8a\x15\x97\x5b\x82\xf2\x20\x4a\x37\xd8\x07\x19\x09\x97\x43\x78\x85\xfe\xc8\x9d\x25\x82\x03\xca\x2c\x4b\xfe\x11\x56\xf9\x32\x21\xea\x88\x72\x10\x25\x10\x4a\x3f\x94\xbc\xa2\xa6\xf2\xb5\xb2\x64\x2f\x3e\x4b\x41\x65\x97\x40\x99\x65\xa9\xfc\xcd\x05\xcd\x0f\xdd\x51\x1e\xc2\xde\x1c\xe5\x9d\x55\xde\x95\x34\x67\xce\xfa\xd5\x96\xec\x46\x09\x2b\x90\x7f\x6b\x2a\x2d\x51\xe2\x05\xdf\xa3\x7c\xac\x49\x0f\xc3\xfe\x82\x2f\x50\x0a\x4b\xf6\x7b\xd5\x28\xdf\x0a\xa2\xae\xa6\xa2\x40\x65\x40\x77\x1d\xf1\x01\x94\xe5\xb0\x1f\x36\x06\x7a\x5c\x71\x80\xaa\xe9\x59\xeb\x42\xf9\x1c\x65\x2d\x7c\xca\x01\x12\xf2\x24\xd3\x61\xc8\x17\x78\x0e\x25\x95\x99\x4d\x4b\x7e\xc4\xf5\x1a\x3a\x16\xca\xbb\x86\x9e\xfb\xd3\x35\x85\x3f\x3c\x6b\x94\xf6\x85\xa4\xe7\x67\xdc\x91\x01\xf1\x59\xcc\x61\x50\xf0\xc8\x60\xb8\x9b\x3d\x0c\xce\x70\x9a\x40\xf8\x62\x0c\x62\x94\xd9\x7d\x10\x4c\xca\xa3\xa2\x60\x5a\x9e\x3c\x0a\xee\xde\xd4\xfb\xab\x33\x09\xd4\xa3\x01\xac\x0d\xbd\x22\x48\x80\xcc\xef\x63\x17\xb8\xcf\x16\x14\x50\x01\xda\x2c\x19\x06\xd3\xa0\x87\x9e\x89\xa9\x41\xf9\x5f\x40\x6b\xc4\xde\xed\x1e\x84\x0d\xce\x77\x30\xd0\xc6\x7a\xe8\x25\xf0\x36\xca\x17\x0c\x3b\x71\x30\x75\x0f\xa8\xab\x4e\xd8\xf6\x19\xd4\xfa\x79\x04\xe4\xa1\x67\xed\xa8\xbf\x2b\xf8\x7b\x93\x53\xe1\x8a\x84\xa9\x41\x3e\x24\xdf\x67\x29\xa1\x21\xa1\xe3\x5e\x90\x84\x32\xa5\x9e\x31\x0a\x64\x14\xad\x9a\xc9\x84\xe8\x09\x4d\xca\x89\xae\x4f\xc6\x19\xcd\xa5\x91\xc4\x25\x25\x3f\x90\x9a\x9e\x9c\x20\xc9\x87\x04\xdb\x12\xc1\x08\x7d\x69\x43\x73\xa0\xb0\xb6\x92\xfa\xed\xa1\x6f\x94\x52\x11\x9d\xa5\xdc\x43\xb5\xd4\x23\xaf\xf9\x9e\x34\xa7\x78\x10\xec\x46\x12\xaf\x68\x4e\xd8\x60\x13\xea\x3d\x23\xa9\xd7\x0d\x91\xa1\x9a\xdf\x23\x1e\x64\x05\xbd\xaf\x98\x50\x34\x82\x92\x3b\x08\x93\x63\x72\x00\x7b\x10\x4f\x40\x64\x50\xd8\x07\x3d\x2b\x22\x15\x20\x4e\x92\x09\x9a\x35\x44\x6c\xf0\x6c\xf0\x8a\x67\x03\x7d\x1b\xc7\x3e\xc8\x98\xcc\x35\xf4\x2c\x40\xe2\x72\xf6\x63\xa3\xc9\xff\x90\x07\x7b\x90\xfc\x31\x47\xc3\x0d\x09\x8b\x18\x85\xd8\x09\x42\xc6\x7d\x64\xd1\xef\x2b\x80\x18\xe6\xec\x6b\x10\x3a\xcd\xcf\x59\x93\xfe\x75\x41\x84\x0e\x31\x34\xe5\x75\x01\x32\x04\xbd\x41\x3c\x72\x43\x4d\x8d\xd0\xe7\x77\xb4\x8f\xb0\xad\x97\x0d\xbf\x23\x36\x83\x70\x82\x04\xd9\x11\xa4\x63\x60\x4d\xbf\x58\x94\xdc\x4e\x3f\x06\x0f\xab\x1c\x15\x21\x8f\x23\x22\xe3\x3c\xf3\xca\xd1\xf1\x8b\xb6\xc6\x71\xc1\xb1\x2c\x4e\x18\x1c\xf7\x9f\x02\xfa\xab\x11\x12\x54\x2b\x00\x77\x68\x98\x5b\xea\x1a\xe2\x35\x6c\xe0\x0d\xb5\x39\x5a\xaa\x70\x10\x3c\x43\xe0\xdb\x0b\xb0\x51\x7e\x39\x1a\x60\xa3\xa4\x6f\x00\x6c\xe4\xe1\xae\xad\x00\x1b\x35\xd7\x01\x00\x3b\xc4\x33\xa7\x05\xd8\x68\x2f\xf4\xe4\x23\x39\x14\x5f\x55\x9d\x88\x32\xcf\x5f\x0d\x5f\xcf\xd8\x2e\xcf\x19\x01\x29\xa5\x39\xd0\x8d\x40\x15\x70\x4f\x41\xe9\xb5\x6d\x68\xfb\x14\xa9\x08\xe9\xce\x19\x4a\x5d\xe1\xd8\x47\x42\x68\x2b\x0c\x6d\x3f\xa3\xa6\x0e\xfd\x09\x4e\xdb\xa0\x25\x90\x13\x6a\x66\xde\x56\x06\x1a\x53\x49\x29\xbb\xe6\xeb\x48\x6b\x98\xbb\xea\xa9\x7e\x49\xdb\xd0\xb8\x8e\xf9\x40\x0f\x50\xff\x97\x2d\xa5\xb4\xda\x50\x8f\x05\x68\xcd\x4a\xea\xb1\x20\x0d\x5b\xd4\xef\x39\xd3\x10\xc3\x69\xd0\x70\x0f\xa3\xe0\xad\xef\x84\xa3\x82\x23\x3a\xa1\x33\xda\x96\xce\x2b\xb6\xc3\x50\xcf\x00\x65\x4b\xa0\x18\xa0\x4d\x29\x6f\xdf\x37\x8f\xdb\xe1\x88\x00\x48\xc9\x19\xeb\x84\xd4\x2e\x4a\x9a\x17\x51\x02\xe9\x1e\x94\x2c\x50\x08\x43\xbd\x0e\xe8\x12\xb6\xe3\x9c\xf2\x65\x43\x69\x1f\x74\x0d\xf3\x4b\xc7\xf4\x87\x29\x8a\xe1\x1e\x44\xd8\x9a\x37\x14\x9d\x40\xa5\xf2\xe6\x71\xfb\x1c\xfa\x36\x86\xb6\xf9\x21\x17\x54\x01\x14\x05\x76\xc3\xff\xf8\x9c\x16\xf4\x6c\x40\x19\x52\x3e\xce\x60\xb9\x67\x05\x4a\x87\x39\x40\x99\x20\x2f\x15\x4c\x35\xb8\x07\x92\x71\x5f\x09\x3e\x6a\xb9\x3f\x93\x1a\x9a\xa3\x64\xca\x04\x1f\x80\xce\x34\x35\xad\x97\x9a\x7b\x1d\xb8\x86\x79\x24\xfb\x3f\x50\x45\xde\xde\xaf\x24\xf5\x72\x5a\xee\xa9\xb4\x4c\x03\xe1\x6b\x7c\x87\x35\x07\xbd\xbf\x88\xbe\x39\x65\x00\x7c\x87\x79\x41\x19\x1f\x69\xd0\x61\xd1\xf7\x79\xe4\x66\x84\xec\xf1\x91\x77\x0f\xb1\x19\x31\xcf\xc9\xa2\xee\xcb\x91\x9a\x91\xf6\xf7\x11\x37\x3d\x98\xd2\x84\x90\x9b\x9d\x38\xe4\xee\x3c\x7a\x7c\x6a\x42\x13\xda\xb4\x0d\x85\x39\x54\x57\x08\x4d\xa8\xfa\x00\x39\x40\x23\xb4\x55\xe5\xe3\xae\x7e\xe8\x98\x38\x0e\xdd\xdc\x6e\xc6\xab\xe4\x93\x4d\x7d\x47\xa5\xdf\x1a\x6d\x92\xdd\x84\xa6\x61\x48\xa1\xd2\x03\x91\x1a\x24\x34\x43\x5e\x79\x0e\xa4\x86\x64\x7f\x0e\xa9\xe1\x93\xe4\xbb\x20\x35\x34\xcf\x61\x90\x1a\xe9\x91\x93\x43\x6a\x8c\xfd\xcf\x22\x31\x75\xf2\x5a\x88\x3a\x9e\xc2\x20\xa5\xc8\x9e\xc2\xb4\xd4\xb5\xc8\xf9\xc4\x1f\xa8\x40\x38\x71\xd6\x50\xfa\x74\x25\x51\x16\xdc\xeb\x78\xab\x22\x14\x01\x39\x6d\x7b\x24\x9a\x4e\xc8\x20\xfd\x23\x7d\x17\x96\xf0\x56\x0a\x4a\xaf\x06\x69\x96\x65\x20\x8d\x82\xba\x14\xdc\xa5\xa9\x90\xa6\x2d\x9f\x28\x2b\x89\x0a\x41\x4e\xc5\x15\x3b\xa8\x0b\x30\xdf\x38\xea\x10\x60\x2e\xcd\x47\x0b\x40\x73\x32\x41\xb4\x0b\xdf\x17\xbc\xe5\x82\xcf\xa0\x2d\xae\xa2\xfb\x21\x23\xd5\xd4\x3d\xc1\xd8\xba\xe5\x4e\x4a\x4b\xe9\x57\xb6\x44\x39\x44\x4b\x7a\x86\xed\x0b\xc7\x5d\x0f\x4b\xfa\x65\x1c\x2f\xaa\xfe\x3e\x3e\x51\x17\x4e\x13\x4a\x8a\x3d\x8e\x8b\x2b\xc4\x27\xd0\x88\xb0\x45\x62\x29\xed\x43\x5f\x9b\x91\x8f\x9b\x8a\x4f\xfe\x66\xa4\x67\xd8\x26\x49\x88\x1e\x49\xc9\x05\x98\x24\xdf\x42\xaf\x9a\x69\x62\xde\x1f\xdd\x00\xb5\x30\x74\x1f\x62\x9c\x95\xbc\xad\xa5\x79\xeb\xc4\x12\xa5\x33\x19\x6f\x57\x95\xf4\x1e\xf6\x42\x67\xe1\xc8\x3e\xc4\x2e\xcd\xdb\x3f\x78\x56\xb8\x56\x5a\xa6\x5e\x9a\xee\x85\xcc\x40\xeb\xf8\x0f\xf1\x16\xfe\xd2\xcd\x23\x7d\x01\x1d\x0b\x5d\xa2\x8c\x68\x15\x74\x81\xfe\x82\x4f\x31\xc2\x0e\xd0\x52\xac\x19\xd0\x14\xcb\x27\x0e\x61\x6b\x59\xf3\x3a\x10\xb4\x2e\x40\xad\x74\x4d\xb6\x62\x6d\x60\x2c\xfc\x0d\x8a\x84\x67\x50\x6c\x9c\x76\x04\x1d\x84\xef\x40\x8d\xc3\x71\x94\xe6\x91\xce\xe2\x19\xf6\x9d\x41\xc7\x74\x14\xd4\x4c\xf0\xba\x10\x3b\x4e\x35\xa6\x29\xf9\x01\x45\x2a\xd6\x1d\xd6\xfa\x5e\xaa\xb4\x1f\x9b\x2f\x12\xd5\xb7\xd0\xa4\xa1\x9f\xfb\x1c\x1c\xd1\x8f\xa0\x48\xa3\x3c\xf1\x8a\xd1\xfc\x29\x3d\xca\x8a\xa3\x82\x79\x7a\xc2\x83\x5d\x63\x7e\x36\xf5\xff\xd4\xf6\x39\x6a\x4f\x8a\xdb\x40\x41\x2f\xcb\x9b\xf1\xfb\x99\xd3\x28\x87\x3d\x0f\x6a\xa3\xa6\x78\x8a\xb9\x91\xbf\x9a\xdb\x0d\xbe\x51\xb3\x1e\x8a\xc2\x43\xbc\xf5\x02\x70\x1c\xed\x94\x1e\x97\xc7\xc1\x52\x66\xaf\x0b\xcb\x13\x6c\x54\x59\x02\x96\xdc\xf8\x71\x45\x5e\x7d\xbe\x51\x85\xb4\x19\xe8\x88\xa5\xb4\x28\x53\xda\x1c\x01\x18\xeb\x86\xd2\x6e\xca\x74\xa1\xd6\xd4\x0d\x49\x99\x8a\x24\xbc\xc9\x15\x3e\x83\x4a\x24\xd4\xf1\x80\x2c\x94\x48\x2d\x97\x3a\xe1\xb0\xb2\xa4\xae\x0e\x52\x21\x68\x00\x00\x5c\x70\xf7\x07\x14\x2c\x4b\x79\x13\x2a\xa3\xf4\x0f\x4a\x95\xf1\x61\x7f\x21\x29\xcd\x62\x6e\xa7\xb9\xa3\x22\xe8\x5e\xc8\x28\x19\xf4\x21\x65\x5b\xea\x82\x80\x36\x80\xa2\x61\x6e\x04\x1b\x97\xd3\x8f\x36\x0a\x0e\x5a\xb2\xff\x71\x84\xa6\x34\x0d\x4a\x51\x3b\xa2\x1f\x08\x52\xa1\x53\x94\x11\xc5\x00\x45\x43\x80\x81\x7f\xca\x84\xc6\xa4\xfd\x69\xa1\x92\xbb\x73\x19\x07\x22\xfe\x4e\x16\x44\xbb\x42\x4a\xb7\x6c\x77\x4a\x9d\x33\xcc\x07\xca\x21\xb8\x23\x85\x31\xb0\xbf\xe5\x8d\x73\xd0\x9c\x70\xf2\xb5\xa4\xee\x0f\x68\xa1\xe0\xf2\x4f\xc0\x6f\x19\x75\xb7\x02\x5d\x12\xa4\x17\x68\x89\xe1\xe7\x11\x7e\x0c\x93\xd3\x1a\x80\xdd\xf0\x59\xba\x85\x7a\x40\x36\x82\x33\xd6\x47\x78\xfe\xf2\x99\x81\xf1\x04\x0c\x64\xc4\x04\x07\x06\xc5\x21\x3e\x32\x62\xc6\xd3\x06\xc4\x17\x66\x27\x23\xdd\xf1\x70\x52\x66\xa0\xe2\xfc\x5f\x00\x00\x00\xff\xff\x01\xce\xa9\xdb\x00\x40\x00\x00")
+var __20181002193011_add_comment_votes_uniqueDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5a\x00\xa5\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x76\x6f\x74\x65\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4e\x53\x54\x52\x41\x49\x4e\x54\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x76\x6f\x74\x65\x73\x5f\x76\x6f\x74\x65\x72\x5f\x69\x64\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x69\x64\x5f\x6b\x65\x79\x3b\x03\x00\x21\x50\x4c\xc9\x5a\x00\x00\x00")
 
-func bindataGoBytes() ([]byte, error) {
+func _20181002193011_add_comment_votes_uniqueDownSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_bindataGo,
-		"bindata.go",
+		__20181002193011_add_comment_votes_uniqueDownSql,
+		"20181002193011_add_comment_votes_unique.down.sql",
 	)
 }
 
-func bindataGo() (*asset, error) {
-	bytes, err := bindataGoBytes()
+func _20181002193011_add_comment_votes_uniqueDownSql() (*asset, error) {
+	bytes, err := _20181002193011_add_comment_votes_uniqueDownSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "bindata.go", size: 28672, mode: os.FileMode(420), modTime: time.Unix(1537904308, 0)}
+	info := bindataFileInfo{name: "20181002193011_add_comment_votes_unique.down.sql", size: 90, mode: os.FileMode(420), modTime: time.Unix(1792220332, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181002193011_add_comment_votes_uniqueUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x76\x6f\x74\x65\x73\x20\x41\x44\x44\x20\x43\x4f\x4e\x53\x54\x52\x41\x49\x4e\x54\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x76\x6f\x74\x65\x73\x5f\x76\x6f\x74\x65\x72\x5f\x69\x64\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x69\x64\x5f\x6b\x65\x79\x20\x55\x4e\x49\x51\x55\x45\x28\x76\x6f\x74\x65\x72\x5f\x69\x64\x2c\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x69\x64\x29\x3b\x03\x00\x12\x8b\x62\x59\x6c\x00\x00\x00")
+
+func _20181002193011_add_comment_votes_uniqueUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181002193011_add_comment_votes_uniqueUpSql,
+		"20181002193011_add_comment_votes_unique.up.sql",
+	)
+}
+
+func _20181002193011_add_comment_votes_uniqueUpSql() (*asset, error) {
+	bytes, err := _20181002193011_add_comment_votes_uniqueUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181002193011_add_comment_votes_unique.up.sql", size: 108, mode: os.FileMode(420), modTime: time.Unix(1792220332, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181004214507_add_comments_tree_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x61\x00\x9e\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x5f\x70\x6f\x73\x74\x5f\x69\x64\x5f\x70\x61\x72\x65\x6e\x74\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x5f\x70\x61\x72\x65\x6e\x74\x5f\x69\x64\x5f\x69\x64\x78\x3b\x03\x00\xc8\xdd\x38\x38\x61\x00\x00\x00")
+
+func _20181004214507_add_comments_tree_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181004214507_add_comments_tree_indexesDownSql,
+		"20181004214507_add_comments_tree_indexes.down.sql",
+	)
+}
+
+func _20181004214507_add_comments_tree_indexesDownSql() (*asset, error) {
+	bytes, err := _20181004214507_add_comments_tree_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181004214507_add_comments_tree_indexes.down.sql", size: 97, mode: os.FileMode(420), modTime: time.Unix(1792220423, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181004214507_add_comments_tree_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\x8e\x2f\xc8\x2f\x2e\x89\xcf\x4c\x89\x2f\x48\x2c\x4a\xcd\x03\xb3\x32\x53\x2a\x14\xfc\xfd\xe0\x2a\x34\xa0\x2a\x74\x14\xe0\x4a\x74\x14\x32\x53\x34\xad\xb9\x70\x18\x88\xdb\x20\x54\xfd\x80\x01\x00\xab\x4b\x28\x62\x90\x00\x00\x00")
+
+func _20181004214507_add_comments_tree_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181004214507_add_comments_tree_indexesUpSql,
+		"20181004214507_add_comments_tree_indexes.up.sql",
+	)
+}
+
+func _20181004214507_add_comments_tree_indexesUpSql() (*asset, error) {
+	bytes, err := _20181004214507_add_comments_tree_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181004214507_add_comments_tree_indexes.up.sql", size: 144, mode: os.FileMode(420), modTime: time.Unix(1792220423, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181008170322_add_posts_rankDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x2f\x4a\xcc\xcb\x8e\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x85\xc8\x29\x80\xb5\x39\xfb\xfb\x84\xfa\xfa\x21\xe9\x03\xe9\x48\x4d\x21\x4d\xbd\x35\x60\x00\xca\xc8\x0f\x31\x82\x00\x00\x00")
+
+func _20181008170322_add_posts_rankDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181008170322_add_posts_rankDownSql,
+		"20181008170322_add_posts_rank.down.sql",
+	)
+}
+
+func _20181008170322_add_posts_rankDownSql() (*asset, error) {
+	bytes, err := _20181008170322_add_posts_rankDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181008170322_add_posts_rank.down.sql", size: 130, mode: os.FileMode(420), modTime: time.Unix(1792220584, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181008170322_add_posts_rankUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8c\x31\x4b\xc5\x30\x14\x46\xf7\xfe\x8a\x6f\x7c\x0f\x1c\xdc\x83\x43\x4c\xae\x18\xb8\x4d\x4a\x72\x83\x6e\x8f\x62\x32\x14\xa5\x95\xb6\xa0\x3f\x5f\x62\xe9\xe4\xe0\x76\x39\xf7\x7c\x47\xb3\x50\x84\xe8\x47\x26\x7c\x2e\xdb\xbe\x41\x5b\x0b\x13\x38\xf7\x1e\xeb\x38\xbf\xc3\x86\xdc\x9e\x43\x24\xe3\x92\x0b\x1e\x3e\x08\x7c\x66\x86\xa5\x27\x9d\x59\x70\xaf\xba\x7f\x33\xb5\x40\x5c\x4f\x49\x74\x3f\xfc\x2d\xcc\xcb\xd7\xe5\xaa\xba\x3c\x58\x2d\x67\x20\x91\x9c\xcb\x07\xbc\xad\x75\xdc\x6b\x51\x9d\x89\xd4\x14\xe7\x2d\xbd\x1e\xe2\xad\x49\xb7\xa9\x7c\x23\xf8\x83\x5c\x1a\x81\xa5\x64\xee\x30\x95\xdf\xe3\x8a\x97\x67\x8a\x84\x52\x3f\xea\x5e\x0b\x5c\x82\xcf\xcc\xea\x67\x00\xc8\xff\x19\xbc\xff\x00\x00\x00")
+
+func _20181008170322_add_posts_rankUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181008170322_add_posts_rankUpSql,
+		"20181008170322_add_posts_rank.up.sql",
+	)
+}
+
+func _20181008170322_add_posts_rankUpSql() (*asset, error) {
+	bytes, err := _20181008170322_add_posts_rankUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181008170322_add_posts_rank.up.sql", size: 255, mode: os.FileMode(420), modTime: time.Unix(1792220599, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181010203115_add_vote_countersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x2f\x4e\xce\x2f\x4a\x8d\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\x56\x00\x6b\x75\xf6\xf7\x09\xf5\xf5\x53\x28\x2d\x28\xcb\x2f\x49\x2d\xd6\x41\x11\x4c\xc9\x2f\xcf\xc3\x22\x0c\x36\x17\xd5\x4c\xb0\x85\x14\x1a\x08\x18\x00\x2d\xb0\x80\x03\xca\x00\x00\x00")
+
+func _20181010203115_add_vote_countersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181010203115_add_vote_countersDownSql,
+		"20181010203115_add_vote_counters.down.sql",
+	)
+}
+
+func _20181010203115_add_vote_countersDownSql() (*asset, error) {
+	bytes, err := _20181010203115_add_vote_countersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181010203115_add_vote_counters.down.sql", size: 202, mode: os.FileMode(420), modTime: time.Unix(1792220671, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181010203115_add_vote_countersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x92\xc1\x6e\xa3\x30\x10\x86\xef\x7e\x8a\xff\x08\x2b\x36\xca\x9d\xdd\x95\x08\x4c\xb2\x48\x8e\x89\xc0\x56\xdb\x53\x54\x61\x1f\x90\x92\x60\x15\x48\xfb\xf8\x15\xc6\x34\x51\x0e\x55\x2b\x55\x55\xc5\x05\x8f\xf5\xcf\x7c\xf3\x41\xc2\x25\x95\x90\xc9\x8a\x13\x6c\xdb\xf5\x1d\x03\x80\x24\xcb\x90\x16\x5c\x6d\x05\x06\x7b\x6e\x7b\xd3\x21\x17\x92\x36\x54\x42\x14\x12\x42\x71\x8e\x8c\xd6\x89\xe2\x12\xcb\xe8\x36\xa2\xdb\xe7\xd3\xa7\x43\x5d\xdd\x3e\x99\x77\x02\x31\xbb\x46\xad\xdb\xe3\xd1\x9c\x7e\x32\x2d\x53\xbb\x2c\x91\x5e\x2a\x2c\x2a\x92\x6f\x74\x7f\x71\x5e\x0c\x36\xba\x1a\x3d\x56\xc6\x53\xe4\x3b\x8f\xe7\xc1\xe2\xb7\x2f\xb3\x75\x59\x6c\x11\x38\x8a\x8a\x38\xa5\xd2\xf5\xdd\x37\x7a\x5a\x67\x7c\xd2\x42\x09\x19\xfc\x0a\xb1\xce\x9d\xa8\xe0\xee\x3f\x95\x04\x6d\x0e\xfd\x23\xfe\x61\x19\x22\xa9\x30\xd8\x0f\x06\xfe\xf8\xc0\x08\xe5\x12\x8e\xc0\x0d\x9d\x0c\x6f\xca\x42\xed\xb0\x7a\x98\x41\x58\x88\x33\xa6\x91\x76\xd1\x68\xb7\x81\xbf\x8a\x67\x17\xf3\x57\x43\xfd\xe5\x3a\x7c\xeb\x6f\x37\x32\xcf\xbd\x91\x72\xc1\xb9\xf2\x52\xcf\x5e\x2e\xb7\x31\x63\x69\x49\xa3\x9b\x5c\x64\x74\xef\x64\x76\x7b\xf7\x0f\xec\x1b\xfd\x82\x42\x4c\xa5\xc0\x95\x90\x51\x95\x46\x68\xb4\x7b\x09\x7d\x5b\x6d\x0e\xa6\x37\x1a\x79\x05\xa1\x38\x8f\x5f\x07\x00\x73\x3b\xac\x40\xd3\x03\x00\x00")
+
+func _20181010203115_add_vote_countersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181010203115_add_vote_countersUpSql,
+		"20181010203115_add_vote_counters.up.sql",
+	)
+}
+
+func _20181010203115_add_vote_countersUpSql() (*asset, error) {
+	bytes, err := _20181010203115_add_vote_countersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181010203115_add_vote_counters.up.sql", size: 979, mode: os.FileMode(420), modTime: time.Unix(1792220671, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181013154210_add_search_vectorsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\x8e\x2f\x4e\x4d\x2c\x4a\xce\x88\xcf\x4c\xa9\xb0\xe6\xc2\xaa\xb2\x20\xbf\x18\x4d\x99\xa3\x4f\x88\x6b\x90\x42\x88\xa3\x93\x8f\x2b\xdc\x1c\x05\xb0\x5e\x67\x7f\x9f\x50\x5f\x3f\x24\xcd\x10\xd3\x51\xb5\x80\x0d\xc4\xaf\x1e\x30\x00\x22\xe3\xc4\xba\xb3\x00\x00\x00")
+
+func _20181013154210_add_search_vectorsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181013154210_add_search_vectorsDownSql,
+		"20181013154210_add_search_vectors.down.sql",
+	)
+}
+
+func _20181013154210_add_search_vectorsDownSql() (*asset, error) {
+	bytes, err := _20181013154210_add_search_vectorsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181013154210_add_search_vectors.down.sql", size: 179, mode: os.FileMode(420), modTime: time.Unix(1792220788, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181013154210_add_search_vectorsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xcb\x6a\xf3\x30\x10\x85\xf7\x7e\x8a\xb3\x93\x04\x7e\x83\x7f\x35\x89\x06\x63\xf0\x2f\x83\xad\xd0\x76\x65\x52\x65\x88\x0d\x4e\x54\x22\xd1\x0b\xe4\xe1\xbb\x70\x13\x42\xba\x69\xbb\x9e\x39\x97\xef\x50\xe3\xb9\x83\xa7\x55\xc3\x78\x89\x29\x27\x90\xb5\x58\xb7\xcd\xe6\xbf\x43\x92\xed\x29\x8c\xc8\xe9\x55\x42\x8e\x27\x54\xec\xb8\x23\xcf\x16\xd4\x3c\xd0\x53\x0f\xea\xa1\x0b\x00\x48\x92\xdf\x64\xda\x8f\x59\xe7\x38\x5c\xfe\xb5\x92\xe3\x7e\x9e\xd2\xa8\x4a\x84\xb8\x9d\x25\x05\xd1\x79\xca\xb3\x94\x50\xca\x98\x12\x8a\x94\xc1\xf9\xfc\x4b\x8b\xe7\xb8\xfb\xb8\x3a\xac\x94\x29\x0c\x7a\xdf\x76\x6c\xff\x15\xb7\x38\x21\x1e\x0e\x72\xfc\x2b\xd1\xcf\x4b\xdc\xc4\xaf\x3b\x26\xcf\xa8\x9d\xe5\xc7\x65\xce\x61\x49\x1c\xa6\xdd\x3b\x5a\xf7\x35\xf1\xa6\xaf\x5d\x85\xaa\x76\x7a\xb9\x9a\x3b\xe5\xa5\xf9\x9d\xf8\x0a\xf4\x5d\xff\x39\x00\xf9\xec\x0b\x32\xc7\x01\x00\x00")
+
+func _20181013154210_add_search_vectorsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181013154210_add_search_vectorsUpSql,
+		"20181013154210_add_search_vectors.up.sql",
+	)
+}
+
+func _20181013154210_add_search_vectorsUpSql() (*asset, error) {
+	bytes, err := _20181013154210_add_search_vectorsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181013154210_add_search_vectors.up.sql", size: 455, mode: os.FileMode(420), modTime: time.Unix(1792220788, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181015191842_add_posts_urlDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x4f\xc9\xcf\x4d\xcc\xcc\x8b\xcf\x4c\xa9\xb0\xe6\xc2\xa3\xac\xb4\x28\x07\xa2\xc6\xd1\x27\xc4\x35\x48\x21\xc4\xd1\xc9\xc7\x15\x22\xa5\x00\xd6\xe5\xec\xef\x13\xea\xeb\x87\xa4\x0d\x62\x2e\xf1\xea\x4b\x8b\x72\xac\x01\x03\x00\x0d\xd2\xa7\x6d\xa7\x00\x00\x00")
+
+func _20181015191842_add_posts_urlDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181015191842_add_posts_urlDownSql,
+		"20181015191842_add_posts_url.down.sql",
+	)
+}
+
+func _20181015191842_add_posts_urlDownSql() (*asset, error) {
+	bytes, err := _20181015191842_add_posts_urlDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181015191842_add_posts_url.down.sql", size: 167, mode: os.FileMode(420), modTime: time.Unix(1792220899, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181015191842_add_posts_urlUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\x0a\xc2\x30\x18\x04\xe0\xdd\xa7\xb8\x51\xa1\x6f\xe0\x14\x93\x1f\x2c\xc4\x04\xd2\xbf\xd8\xad\x14\xd3\x21\x10\xad\xa4\x0d\xf8\xf8\x82\x51\xa8\x4b\xd7\xe3\xf8\xee\x84\x66\x72\x60\x71\xd2\x84\xe7\x34\x2f\x33\x84\x52\x90\x56\xb7\x17\x83\x9c\x22\x98\x3a\x86\x69\xb5\x3e\xee\x36\xbb\x7e\xba\x0f\xe1\xb1\xae\x4b\x47\x82\x09\xb5\x51\xd4\x15\xbb\xcf\x29\xf6\xc1\xbf\x60\x4d\x09\xf6\x39\xc5\x0a\xb7\x34\x0e\xcb\xe8\xa1\xa8\x91\x07\x5c\xcf\xe4\xe8\x33\x5d\x37\x30\x76\x43\x2b\x93\xff\x60\xc9\x2a\x04\xff\x93\xbe\xc7\xd6\xd8\x7b\x00\x99\xb6\x0e\x9e\xf5\x00\x00\x00")
+
+func _20181015191842_add_posts_urlUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181015191842_add_posts_urlUpSql,
+		"20181015191842_add_posts_url.up.sql",
+	)
+}
+
+func _20181015191842_add_posts_urlUpSql() (*asset, error) {
+	bytes, err := _20181015191842_add_posts_urlUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181015191842_add_posts_url.up.sql", size: 245, mode: os.FileMode(420), modTime: time.Unix(1792220899, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181018162533_create_communitiesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7f\x00\x80\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x70\x6f\x73\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x75\x6e\x69\x74\x79\x5f\x69\x64\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x75\x6e\x69\x74\x79\x5f\x6d\x65\x6d\x62\x65\x72\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x75\x6e\x69\x74\x69\x65\x73\x3b\x03\x00\xe0\x6b\x6a\x6a\x7f\x00\x00\x00")
+
+func _20181018162533_create_communitiesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181018162533_create_communitiesDownSql,
+		"20181018162533_create_communities.down.sql",
+	)
+}
+
+func _20181018162533_create_communitiesDownSql() (*asset, error) {
+	bytes, err := _20181018162533_create_communitiesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181018162533_create_communities.down.sql", size: 127, mode: os.FileMode(420), modTime: time.Unix(1792220979, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181018162533_create_communitiesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xbd\x6e\xc2\x30\x14\x85\x77\x9e\xe2\x6e\x24\x12\x43\x77\x26\x97\x5c\xda\xa8\x8e\x43\x8d\xa3\xc2\x14\x51\xec\xe1\x56\x24\x46\x76\x10\xed\xdb\x57\x49\xf8\x71\x5a\x50\xbb\xe6\x1c\x1d\xdf\xef\xcb\x4c\x22\x53\x08\x8a\x3d\x72\x84\xad\xad\xaa\x43\x4d\x0d\x19\x1f\x8d\x00\x00\x48\x83\x37\x8e\x36\x3b\x58\xc8\x34\x63\x72\x0d\x2f\xb8\x9e\x74\x51\xbd\xa9\x0c\x28\x5c\x29\x10\xb9\x02\x51\x70\xde\x7f\xd7\xc6\x6f\x1d\xed\x1b\xb2\xf5\x30\x86\x04\xe7\xac\xe0\x0a\xc6\xe3\xbe\xe9\x0e\x3b\xe3\xff\xe8\x6c\x9d\xd9\x34\xd6\x95\xa4\x21\x15\x0a\x9f\x50\x82\xc4\x39\x4a\x14\x33\x5c\xc2\xc1\x1b\xe7\x23\xd2\x71\x3f\x58\x99\xea\xdd\x38\x7f\x69\xfe\x5a\x7d\x08\x46\x8d\x06\x95\x66\xb8\x54\x2c\x5b\x5c\x0a\xb5\x3d\x46\xf1\x28\x9e\x8e\x4e\x5a\x0a\x91\xbe\x16\x08\xa9\x48\x70\x15\xda\x29\x5b\xfa\x92\xf4\x27\xe4\x62\x60\x6d\x67\x8f\xc6\x45\x6d\x1a\x5f\x57\x86\x72\xbf\xca\xd3\x9d\xbd\xe2\xeb\xe7\xdb\x8c\xe7\x9c\x4c\x40\xda\x82\xff\xcf\xc9\x87\xa5\xfa\x3e\x6a\xdf\x09\xfe\x6d\x14\x9e\x33\x39\xbf\xd3\x29\x61\x5c\xa1\x3c\xb1\xec\xad\x6f\x3c\xb0\x24\x81\x59\xce\x8b\x4c\xdc\xa6\xe8\xdc\xdf\x47\xb9\xf8\xe9\xf5\x76\x9b\x65\x38\x74\x16\xdc\x25\x3f\x2e\x23\x1d\xc3\xdb\x33\xca\xd0\x6b\xfb\xf4\x12\x44\xae\x40\x14\x9c\x4f\xbf\x07\x00\x7b\x40\xc5\x51\xdb\x02\x00\x00")
+
+func _20181018162533_create_communitiesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181018162533_create_communitiesUpSql,
+		"20181018162533_create_communities.up.sql",
+	)
+}
+
+func _20181018162533_create_communitiesUpSql() (*asset, error) {
+	bytes, err := _20181018162533_create_communitiesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181018162533_create_communities.up.sql", size: 731, mode: os.FileMode(420), modTime: time.Unix(1792220979, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181021140517_add_moderationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xcd\x4f\x89\xcf\xc9\x4f\xb7\xe6\x02\xcb\xba\x85\xfa\x39\x87\x78\xfa\xfb\x61\x2a\x88\x4f\x2c\x28\x48\xcd\x4b\x89\xcf\xcf\xcb\xa9\xd4\xd0\xb4\xe6\xc2\x65\x58\x6a\x51\x62\x49\x7e\x51\xb1\x35\x97\xa3\x4f\x88\x6b\x10\x54\x45\x72\x7e\x6e\x6e\x6a\x5e\x49\xb1\x02\x58\x97\xb3\xbf\x4f\xa8\x2f\xb2\x15\x45\xa9\xb9\xf9\x65\xa9\x29\xa8\x7a\x0a\xf2\x8b\x71\x6a\xc8\xc9\x4f\xce\x26\x45\x7d\x51\x6a\x6e\x7e\x59\x6a\x8a\x35\x60\x00\x48\x57\xe0\xdf\x02\x01\x00\x00")
+
+func _20181021140517_add_moderationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181021140517_add_moderationDownSql,
+		"20181021140517_add_moderation.down.sql",
+	)
+}
+
+func _20181021140517_add_moderationDownSql() (*asset, error) {
+	bytes, err := _20181021140517_add_moderationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181021140517_add_moderation.down.sql", size: 258, mode: os.FileMode(420), modTime: time.Unix(1792221120, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181021140517_add_moderationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x41\x8f\x9b\x30\x10\x85\xef\xfc\x8a\x39\x44\x0a\x48\x5b\xa9\xf7\x9c\xbc\x66\xc8\xa2\x82\xa1\x83\xad\xcd\x9e\x10\x8a\xad\xc8\x2a\x01\x0a\xa4\xed\xfe\xfb\x0a\xc2\x52\x92\xee\xa6\x39\xf4\xec\xf7\xde\x7c\x33\xcf\x2c\x92\x48\x20\xd9\x63\x84\xd0\xd4\x5d\xdf\x01\xf3\x7d\xe0\x49\xa4\x62\x01\xad\x39\xd6\x3f\x8c\x06\x19\xc6\x98\x49\x16\xa7\x20\x54\x14\x6d\x9c\x9b\xa6\xb2\xde\x7f\xfb\x87\x67\x5f\x1f\x8f\xa6\xba\x6f\x16\x27\x64\x12\x27\xe3\xb1\xd6\xa6\x2d\xfa\xba\xed\x5c\x07\x00\xe0\xd4\x99\x36\xb7\x1a\x42\x21\x71\x8b\x04\x22\x91\xe3\x38\x20\x0c\x90\x50\x70\xcc\x46\x4d\xe7\x5a\xed\x3d\x8c\x96\x61\xf6\xa9\xb2\xfd\xeb\x85\xef\xca\xf3\x26\xb2\x66\xe1\x2c\xb4\xbe\xe0\xf3\x31\x60\x2a\x92\x50\xd5\x3f\x5d\xcf\xf1\x66\x54\x25\xc2\xaf\x0a\x21\x14\x3e\xee\x16\xc4\xf9\x04\x9b\x2f\x09\x72\xab\x7f\x41\x22\x16\x32\x77\x92\x3d\x00\x4f\x58\x84\x19\x47\x77\x69\x78\x80\xcf\x9e\xb7\x71\x42\x91\x21\xc9\x61\xef\xe4\x5d\xef\xd2\xe2\x41\x86\x11\x72\x09\xfb\xd6\x0c\xb2\x51\x60\x35\x04\x94\xc4\xb3\xd0\x9a\x0e\x9e\x9f\x90\x70\xa1\x82\x30\x9b\x2f\xfa\x77\x11\x79\x59\x1f\xce\x2d\x58\x0d\x9d\x69\x6d\x51\x42\x4a\x61\xcc\xe8\x05\xbe\xe0\xcb\xf9\xda\x33\xdb\xad\x6b\xff\xbf\x86\xf6\xbd\xad\x2b\x90\xb8\x93\x33\xf8\x99\xa3\x2f\xda\x83\xe9\x2f\x02\x2f\xde\x5b\x53\x74\xd7\xce\xb9\xe0\xf5\x7a\x02\x1b\x2e\x73\xd7\x17\x98\xbb\x1f\x8e\xf4\x51\xe1\xc3\xdb\x55\xb7\x56\x7f\x94\xb1\x3c\xe4\x75\xc6\xf2\xed\x32\x23\x21\x20\x4c\x23\xc6\x11\x02\x25\xb8\x0c\xff\xb8\xf2\xa2\x69\x4c\xa5\xf3\xba\x2a\x5f\x5d\x0f\x08\xa5\x22\x91\x41\xdf\xda\xc3\xc1\xb4\xc0\x32\x58\xad\x9c\x47\xdc\x86\x62\xdc\x9d\x58\x98\x21\xe0\x8e\x63\x3a\xa6\xac\xa7\x18\xb0\x1d\x9c\x93\x3e\x0d\x49\xeb\x8d\x83\xc2\xdf\x38\xab\x15\x44\x4c\x6c\x15\xdb\x22\x34\x65\x73\xe8\xbe\x97\x33\x94\xa4\x70\x3b\x34\xf0\x06\x52\xd5\xf9\xa9\xd1\x45\x6f\xe0\x11\x83\x84\x10\x54\xea\x4f\xf0\x3e\x46\x28\x71\xb1\xeb\xc8\x12\x24\x04\xc8\xf8\x13\x50\xf2\x0c\xb8\x43\xae\x24\x42\x4a\x09\x47\x5f\x11\xbe\xbf\xe0\xad\xe9\x7d\x7b\xaa\xf6\x8b\xf9\x92\x94\xe0\xec\xc6\xdc\x4c\x32\x89\x31\x0a\x79\xf7\xf4\xdf\x03\x00\x79\x21\x8f\x3a\x67\x05\x00\x00")
+
+func _20181021140517_add_moderationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181021140517_add_moderationUpSql,
+		"20181021140517_add_moderation.up.sql",
+	)
+}
+
+func _20181021140517_add_moderationUpSql() (*asset, error) {
+	bytes, err := _20181021140517_add_moderationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181021140517_add_moderation.up.sql", size: 1383, mode: os.FileMode(420), modTime: time.Unix(1792221120, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181023201104_create_reportsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1d\x00\xe2\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x72\x74\x73\x3b\x03\x00\x99\x58\x51\xa9\x1d\x00\x00\x00")
+
+func _20181023201104_create_reportsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181023201104_create_reportsDownSql,
+		"20181023201104_create_reports.down.sql",
+	)
+}
+
+func _20181023201104_create_reportsDownSql() (*asset, error) {
+	bytes, err := _20181023201104_create_reportsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181023201104_create_reports.down.sql", size: 29, mode: os.FileMode(420), modTime: time.Unix(1792221267, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181023201104_create_reportsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x51\xdd\x6e\x82\x30\x14\xbe\xf7\x29\xce\x9d\x98\xf0\x06\xcb\x2e\x98\x1e\x37\x32\xa8\xae\x96\x4c\xaf\x48\x67\x4f\x96\x66\x48\x4d\x5b\xb2\xf9\xf6\xcb\x2c\x38\x20\x72\xdb\xef\xaf\xe7\xfb\x96\x1c\x13\x81\x20\x92\xa7\x0c\xc1\xd2\xd9\x58\xef\xa2\x19\x00\x80\x56\xe0\xc8\x6a\x59\xc1\x96\xa7\x79\xc2\x0f\xf0\x8a\x87\xf8\x0a\x05\x1e\xd9\x52\x2b\x48\x99\xc0\x67\xe4\xc0\x36\x02\x58\x91\x65\xc0\x71\x8d\x1c\xd9\x12\x77\xd0\x38\xb2\x2e\xd2\x6a\x11\x64\x5f\xba\x56\x20\x70\x2f\x6e\xe4\xf0\xee\xa5\xfd\x24\x7f\xcf\x2c\xe0\x47\x73\x3a\x35\xb5\xf6\x97\x01\x65\x94\xd5\x91\x34\xf5\x12\x2d\x49\x67\xea\x7b\x99\x8a\xbc\xd4\x95\x1b\x42\xb0\xc2\x75\x52\x64\x02\xe6\xf3\xc0\x72\x5e\xfa\x66\x92\x64\xce\x54\xb7\xc4\x63\x65\x1c\xa9\xf2\xe3\x32\xf9\xbf\x51\x17\x41\x00\x22\xcd\x71\x27\x92\x7c\xdb\x3f\xd7\x92\xf4\x03\xac\x4b\xac\xcd\x77\xd4\xea\x0b\x96\xbe\x15\x18\xf5\x96\x88\xaf\xfd\xc6\xff\x6d\x2e\x66\x8b\x87\x59\xbb\x6f\xca\x56\xb8\xef\xf6\x2d\xff\x3e\x5e\x6a\xf5\x03\x1b\x76\xdb\x7c\x2c\x86\xf7\x17\xe4\xd8\x35\xf0\xd8\x5e\x3b\x61\xd8\x5f\x68\x6c\xdc\xc7\xa6\x5c\x7f\x07\x00\x53\x60\x17\x87\x86\x02\x00\x00")
+
+func _20181023201104_create_reportsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181023201104_create_reportsUpSql,
+		"20181023201104_create_reports.up.sql",
+	)
+}
+
+func _20181023201104_create_reportsUpSql() (*asset, error) {
+	bytes, err := _20181023201104_create_reportsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181023201104_create_reports.up.sql", size: 646, mode: os.FileMode(420), modTime: time.Unix(1792221267, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181025183420_add_author_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x89\x2f\xcb\x2f\x49\x2d\x8e\x87\xf1\x32\x53\xe2\x33\x53\x2a\xac\xb9\xb0\x6a\x29\xc8\x2f\x86\xa9\x07\x33\xf1\x2a\x86\x9a\x08\x37\x3a\xb5\x88\xb0\xe1\xc5\xf1\x89\xa5\x25\x19\xf9\x45\xf1\x99\x29\xf1\x99\x29\x15\xd6\x80\x01\x00\xc6\x9d\xe4\x8d\xb9\x00\x00\x00")
+
+func _20181025183420_add_author_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181025183420_add_author_indexesDownSql,
+		"20181025183420_add_author_indexes.down.sql",
+	)
+}
+
+func _20181025183420_add_author_indexesDownSql() (*asset, error) {
+	bytes, err := _20181025183420_add_author_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181025183420_add_author_indexes.down.sql", size: 185, mode: os.FileMode(420), modTime: time.Unix(1792221366, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181025183420_add_author_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x28\xc8\x2f\x2e\x29\x8e\x4f\x2c\x2d\xc9\xc8\x2f\x8a\xcf\x4c\x89\xcf\x4c\xa9\x50\xf0\xf7\x83\x08\x6b\xc0\x85\x75\x14\x32\x53\x34\xad\xb9\x50\x74\x26\xe7\xe7\xe6\xa6\xe6\x95\x14\xc7\x43\x19\xa9\xc8\xfa\x61\x92\x1a\xc8\x92\xd8\x4c\x01\x59\x14\x5f\x96\x5f\x92\x5a\x1c\x0f\x66\xa2\x3a\x01\x22\xa3\x01\x95\xc1\xe1\x02\xa8\x76\x18\x0f\xc3\x11\x50\x43\x10\xf2\x9a\xd6\x80\x01\x00\xef\x9f\x16\x51\x03\x01\x00\x00")
+
+func _20181025183420_add_author_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181025183420_add_author_indexesUpSql,
+		"20181025183420_add_author_indexes.up.sql",
+	)
+}
+
+func _20181025183420_add_author_indexesUpSql() (*asset, error) {
+	bytes, err := _20181025183420_add_author_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181025183420_add_author_indexes.up.sql", size: 259, mode: os.FileMode(420), modTime: time.Unix(1792221366, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181028110932_create_user_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x17\x00\xe8\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x03\x00\x43\x2f\x38\x2e\x17\x00\x00\x00")
+
+func _20181028110932_create_user_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181028110932_create_user_tokensDownSql,
+		"20181028110932_create_user_tokens.down.sql",
+	)
+}
+
+func _20181028110932_create_user_tokensDownSql() (*asset, error) {
+	bytes, err := _20181028110932_create_user_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181028110932_create_user_tokens.down.sql", size: 23, mode: os.FileMode(420), modTime: time.Unix(1792221510, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181028110932_create_user_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x90\xc1\x6a\xc3\x30\x10\x44\xef\xfe\x8a\x39\xda\x90\x3f\xc8\x49\x4d\x36\xad\xa8\xbc\x49\xe5\x15\x4d\x4e\xc6\x54\x82\x88\x14\xa7\x58\x09\xcd\xe7\x17\x64\x13\xec\x5e\xf7\xcd\x2c\xbc\xd9\x58\x52\x42\x10\xf5\x62\x08\xf7\x14\x86\xf6\x76\xbd\x84\x3e\x95\x05\x00\x44\x8f\x14\x86\xd8\x7d\xe3\x60\x75\xad\xec\x09\xef\x74\x5a\x65\x94\xb3\xd1\x43\xb3\xd0\x2b\x59\xf0\x5e\xc0\xce\x18\x58\xda\x91\x25\xde\x50\x93\xff\xa5\x32\xfa\x6a\xac\x5c\x62\xef\x21\x74\x94\x67\x78\xbc\x9f\xbb\x74\x5e\xde\xe1\x58\x7f\x38\x1a\x71\x78\xfc\xc4\x21\x24\x88\xae\xa9\x11\x55\x1f\xfe\xd5\xef\x29\xf8\x39\x7c\x82\xaf\x21\x74\xb7\x05\xdb\xd2\x4e\x39\x23\xe8\xaf\xbf\x65\x55\x54\xeb\x62\xd2\xd7\xbc\xa5\xe3\x5c\xbf\x9d\xf4\xda\xe8\x1f\xd8\xf3\x62\x99\x09\xad\xb2\x4f\x85\xcf\x37\xb2\x79\x3a\x0f\xdd\x80\x9d\x31\xeb\xbf\x01\x00\xe0\xfe\xc8\x19\x55\x01\x00\x00")
+
+func _20181028110932_create_user_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181028110932_create_user_tokensUpSql,
+		"20181028110932_create_user_tokens.up.sql",
+	)
+}
+
+func _20181028110932_create_user_tokensUpSql() (*asset, error) {
+	bytes, err := _20181028110932_create_user_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181028110932_create_user_tokens.up.sql", size: 341, mode: os.FileMode(420), modTime: time.Unix(1792221510, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181030174215_add_users_email_verifiedDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x30\x00\xcf\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x65\x6d\x61\x69\x6c\x5f\x76\x65\x72\x69\x66\x69\x65\x64\x5f\x61\x74\x3b\x03\x00\x1f\x10\x4c\xf7\x30\x00\x00\x00")
+
+func _20181030174215_add_users_email_verifiedDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181030174215_add_users_email_verifiedDownSql,
+		"20181030174215_add_users_email_verified.down.sql",
+	)
+}
+
+func _20181030174215_add_users_email_verifiedDownSql() (*asset, error) {
+	bytes, err := _20181030174215_add_users_email_verifiedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181030174215_add_users_email_verified.down.sql", size: 48, mode: os.FileMode(420), modTime: time.Unix(1792221612, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181030174215_add_users_email_verifiedUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x65\x6d\x61\x69\x6c\x5f\x76\x65\x72\x69\x66\x69\x65\x64\x5f\x61\x74\x20\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x20\x4e\x55\x4c\x4c\x3b\x0a\x55\x50\x44\x41\x54\x45\x20\x75\x73\x65\x72\x73\x20\x53\x45\x54\x20\x65\x6d\x61\x69\x6c\x5f\x76\x65\x72\x69\x66\x69\x65\x64\x5f\x61\x74\x20\x3d\x20\x63\x72\x65\x61\x74\x65\x64\x3b\x03\x00\x7a\x16\x31\x6a\x6c\x00\x00\x00")
+
+func _20181030174215_add_users_email_verifiedUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181030174215_add_users_email_verifiedUpSql,
+		"20181030174215_add_users_email_verified.up.sql",
+	)
+}
+
+func _20181030174215_add_users_email_verifiedUpSql() (*asset, error) {
+	bytes, err := _20181030174215_add_users_email_verifiedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181030174215_add_users_email_verified.up.sql", size: 108, mode: os.FileMode(420), modTime: time.Unix(1792221621, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181102093758_add_users_session_epochDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x2c\x00\xd3\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x73\x65\x73\x73\x69\x6f\x6e\x5f\x65\x70\x6f\x63\x68\x3b\x03\x00\x3c\x8d\x24\xc5\x2c\x00\x00\x00")
+
+func _20181102093758_add_users_session_epochDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181102093758_add_users_session_epochDownSql,
+		"20181102093758_add_users_session_epoch.down.sql",
+	)
+}
+
+func _20181102093758_add_users_session_epochDownSql() (*asset, error) {
+	bytes, err := _20181102093758_add_users_session_epochDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181102093758_add_users_session_epoch.down.sql", size: 44, mode: os.FileMode(420), modTime: time.Unix(1792221699, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181102093758_add_users_session_epochUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x73\x65\x73\x73\x69\x6f\x6e\x5f\x65\x70\x6f\x63\x68\x20\x49\x4e\x54\x45\x47\x45\x52\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x30\x3b\x03\x00\x5a\x17\xe4\x13\x46\x00\x00\x00")
+
+func _20181102093758_add_users_session_epochUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181102093758_add_users_session_epochUpSql,
+		"20181102093758_add_users_session_epoch.up.sql",
+	)
+}
+
+func _20181102093758_add_users_session_epochUpSql() (*asset, error) {
+	bytes, err := _20181102093758_add_users_session_epochUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181102093758_add_users_session_epoch.up.sql", size: 70, mode: os.FileMode(420), modTime: time.Unix(1792221699, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181106152047_add_deleted_userDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x45\x00\xba\xff\x44\x45\x4c\x45\x54\x45\x20\x46\x52\x4f\x4d\x20\x75\x73\x65\x72\x73\x20\x57\x48\x45\x52\x45\x20\x75\x69\x64\x20\x3d\x20\x27\x30\x30\x30\x30\x30\x30\x30\x30\x2d\x30\x30\x30\x30\x2d\x30\x30\x30\x30\x2d\x30\x30\x30\x30\x2d\x30\x30\x30\x30\x30\x30\x30\x30\x30\x30\x30\x30\x27\x3b\x03\x00\x83\x97\x72\x56\x45\x00\x00\x00")
+
+func _20181106152047_add_deleted_userDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181106152047_add_deleted_userDownSql,
+		"20181106152047_add_deleted_user.down.sql",
+	)
+}
+
+func _20181106152047_add_deleted_userDownSql() (*asset, error) {
+	bytes, err := _20181106152047_add_deleted_userDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181106152047_add_deleted_user.down.sql", size: 69, mode: os.FileMode(420), modTime: time.Unix(1792221835, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181106152047_add_deleted_userUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xf2\xf4\x0b\x76\x0d\x0a\x51\xf0\xf4\x0b\xf1\x57\x28\x2d\x4e\x2d\x2a\xd6\x28\xcd\x4c\xd1\x01\x33\xf3\x12\x73\x53\x75\x14\x52\x73\x13\x33\x73\x74\x14\x32\x12\x8b\x33\x74\x14\x52\x52\x73\x52\x4b\x52\x53\x34\xb9\xc2\x1c\x7d\x42\x5d\x83\x35\xd4\x0d\xa0\x40\x17\x0b\x01\x83\xea\x3a\x0a\xea\xd1\x50\x9d\xb1\x18\x1c\x75\x1d\x85\xbc\xfc\x72\x0d\x4d\x4d\x6b\xc0\x00\x86\x30\x06\x62\x8b\x00\x00\x00")
+
+func _20181106152047_add_deleted_userUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181106152047_add_deleted_userUpSql,
+		"20181106152047_add_deleted_user.up.sql",
+	)
+}
+
+func _20181106152047_add_deleted_userUpSql() (*asset, error) {
+	bytes, err := _20181106152047_add_deleted_userUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181106152047_add_deleted_user.up.sql", size: 139, mode: os.FileMode(420), modTime: time.Unix(1792221835, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181109201536_create_api_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x16\x00\xe9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x61\x70\x69\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x03\x00\x2b\x3e\xdd\xc8\x16\x00\x00\x00")
+
+func _20181109201536_create_api_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181109201536_create_api_tokensDownSql,
+		"20181109201536_create_api_tokens.down.sql",
+	)
+}
+
+func _20181109201536_create_api_tokensDownSql() (*asset, error) {
+	bytes, err := _20181109201536_create_api_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181109201536_create_api_tokens.down.sql", size: 22, mode: os.FileMode(420), modTime: time.Unix(1792221929, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181109201536_create_api_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x90\xd1\x6a\xc2\x30\x18\x85\xef\xfb\x14\xe7\xb2\x05\xdf\xc0\xab\x4e\x7f\xb7\xb0\x34\xba\x34\x61\xca\x18\x21\x98\x80\x41\xd7\x4a\xff\xba\xed\xf1\x07\x6d\x71\xba\xdd\xe6\x3b\x27\x87\xff\x5b\x68\x2a\x0d\xc1\x94\x0f\x92\xe0\xcf\xc9\xf5\xed\x31\x36\x9c\x67\x00\x90\x02\x38\x76\xc9\x9f\xb0\xd1\xa2\x2a\xf5\x0e\xcf\xb4\x9b\x0d\xe8\xc2\xb1\x73\x29\x40\x28\x43\x8f\xa4\xa1\xd6\x06\xca\x4a\x09\x4d\x2b\xd2\xa4\x16\x54\x0f\x19\xce\x53\x28\xc6\x4a\xe3\x3f\x22\x0c\x6d\xcd\x35\x3c\xbe\xf3\xbe\x3d\x47\x1e\xc8\xdb\xfb\x1f\x76\xf0\x7c\xb8\xef\xc0\x2a\xf1\x62\x69\xac\xee\xbb\xe8\xfb\x18\x60\x44\x45\xb5\x29\xab\x0d\x96\xb4\x2a\xad\x34\x68\xda\xaf\x7c\xda\x3d\x79\xee\xdd\x85\xef\x62\xbf\x0b\x5d\xfc\x6c\x8f\xff\x58\x56\xcc\xb3\x49\x8d\x50\x4b\xda\xde\xa8\x71\xd3\xe9\x2e\x85\x6f\xac\xd5\xad\xb4\x89\xcc\x90\x42\x81\xd7\x27\xd2\x74\xfd\x5e\xd4\x50\x56\xca\xf9\xcf\x00\x50\x30\x0b\xac\x70\x01\x00\x00")
+
+func _20181109201536_create_api_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181109201536_create_api_tokensUpSql,
+		"20181109201536_create_api_tokens.up.sql",
+	)
+}
+
+func _20181109201536_create_api_tokensUpSql() (*asset, error) {
+	bytes, err := _20181109201536_create_api_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181109201536_create_api_tokens.up.sql", size: 368, mode: os.FileMode(420), modTime: time.Unix(1792221929, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181112184410_create_rate_limitsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x17\x00\xe8\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x72\x61\x74\x65\x5f\x6c\x69\x6d\x69\x74\x73\x3b\x03\x00\xa2\x23\xb8\xfa\x17\x00\x00\x00")
+
+func _20181112184410_create_rate_limitsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181112184410_create_rate_limitsDownSql,
+		"20181112184410_create_rate_limits.down.sql",
+	)
+}
+
+func _20181112184410_create_rate_limitsDownSql() (*asset, error) {
+	bytes, err := _20181112184410_create_rate_limitsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181112184410_create_rate_limits.down.sql", size: 23, mode: os.FileMode(420), modTime: time.Unix(1792222047, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181112184410_create_rate_limitsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8e\xbd\x0a\x83\x30\x18\x45\x77\x9f\xe2\x8e\x15\xfa\x06\x4e\xa9\x7e\x43\xa8\x26\x12\x3f\x41\xa7\x10\x48\x86\x60\x7f\xa4\x46\x68\xdf\xbe\xb4\x95\xd2\xce\xe7\x1e\xce\x2d\x0d\x09\x26\xb0\x38\xd4\x84\x9b\x4b\xc1\x9e\xe2\x39\xa6\x65\x97\x01\xc0\x14\x1e\x60\x1a\x18\xad\x91\x8d\x30\x23\x8e\x34\xee\xdf\x24\x5d\xa7\x70\x59\x50\xe9\xfe\x25\xb6\x86\x4a\xd9\x49\xad\xa0\x34\x43\xf5\x75\xfd\x59\xad\xb3\x77\x29\x78\xb0\x6c\xa8\x63\xd1\xb4\x5f\x9e\xe5\x45\xb6\xb5\xa5\xaa\x68\xf8\x6d\xdb\x4d\xb3\xd1\xdf\xa1\xd5\xdf\xad\x75\xf6\x2e\x05\x9f\x17\xcf\x01\x00\x8e\x9f\xd6\x78\xb9\x00\x00\x00")
+
+func _20181112184410_create_rate_limitsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181112184410_create_rate_limitsUpSql,
+		"20181112184410_create_rate_limits.up.sql",
+	)
+}
+
+func _20181112184410_create_rate_limitsUpSql() (*asset, error) {
+	bytes, err := _20181112184410_create_rate_limitsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181112184410_create_rate_limits.up.sql", size: 185, mode: os.FileMode(420), modTime: time.Unix(1792222047, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181115123059_create_login_failuresDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1a\x00\xe5\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x6c\x6f\x67\x69\x6e\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x3b\x03\x00\x54\x96\x09\xaf\x1a\x00\x00\x00")
+
+func _20181115123059_create_login_failuresDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181115123059_create_login_failuresDownSql,
+		"20181115123059_create_login_failures.down.sql",
+	)
+}
+
+func _20181115123059_create_login_failuresDownSql() (*asset, error) {
+	bytes, err := _20181115123059_create_login_failuresDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181115123059_create_login_failures.down.sql", size: 26, mode: os.FileMode(420), modTime: time.Unix(1792222165, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181115123059_create_login_failuresUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7b\x00\x84\xff\x43\x52\x45\x41\x54\x45\x20\x54\x41\x42\x4c\x45\x20\x6c\x6f\x67\x69\x6e\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x28\x0a\x20\x20\x20\x20\x6b\x65\x79\x20\x54\x45\x58\x54\x20\x50\x52\x49\x4d\x41\x52\x59\x20\x4b\x45\x59\x2c\x0a\x20\x20\x20\x20\x66\x61\x69\x6c\x75\x72\x65\x73\x20\x49\x4e\x54\x45\x47\x45\x52\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x2c\x0a\x20\x20\x20\x20\x6c\x61\x73\x74\x5f\x66\x61\x69\x6c\x65\x64\x20\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x0a\x29\x3b\x03\x00\x09\x3c\x21\x4d\x7b\x00\x00\x00")
+
+func _20181115123059_create_login_failuresUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181115123059_create_login_failuresUpSql,
+		"20181115123059_create_login_failures.up.sql",
+	)
+}
+
+func _20181115123059_create_login_failuresUpSql() (*asset, error) {
+	bytes, err := _20181115123059_create_login_failuresUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181115123059_create_login_failures.up.sql", size: 123, mode: os.FileMode(420), modTime: time.Unix(1792222165, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181119150214_add_two_factorDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4a\x4d\xce\x2f\x4b\x2d\xaa\x8c\x4f\xce\x4f\x49\x2d\xb6\xe6\x72\xf4\x09\x71\x0d\x82\xca\x95\x16\xa7\x16\x15\x2b\x80\x15\x3b\xfb\xfb\x84\xfa\xfa\x29\x94\xe4\x97\x14\xc4\x17\x97\xa4\x16\x10\xa5\x30\x35\x2f\x31\x29\x27\x35\x25\x3e\xb1\x84\x38\x73\x53\x93\x8b\x52\x4b\xac\x01\x03\x00\x34\x40\x87\xb2\x9d\x00\x00\x00")
+
+func _20181119150214_add_two_factorDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181119150214_add_two_factorDownSql,
+		"20181119150214_add_two_factor.down.sql",
+	)
+}
+
+func _20181119150214_add_two_factorDownSql() (*asset, error) {
+	bytes, err := _20181119150214_add_two_factorDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181119150214_add_two_factor.down.sql", size: 157, mode: os.FileMode(420), modTime: time.Unix(1792222905, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181119150214_add_two_factorUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xbd\x6e\xc2\x30\x14\x85\xf7\x3c\xc5\x19\x13\xa9\x43\x77\x26\x43\x2e\xd4\xaa\x63\x90\xe3\xa8\x30\x59\x69\x7c\x25\x22\x21\x82\x6c\xf7\xef\xed\xab\x92\x94\xaa\x59\xda\xf9\xf3\x39\x3e\xdf\x15\xca\x92\x81\x15\x4b\x45\x78\x89\x1c\x22\x44\x59\x62\xb5\x55\x4d\xa5\x91\x86\x74\x71\x91\xbb\xc0\x09\x96\xf6\x16\xba\x51\x6a\x91\xfd\x9d\xe1\x73\xfb\x7c\x62\xef\xda\x04\x2b\x2b\xaa\xad\xa8\x76\xff\x0e\xc7\xc4\x17\x2c\xe5\x46\x6a\x0b\xbd\x1d\x3f\x45\x49\x6b\xd1\x28\x8b\xfb\x45\xb6\x32\x24\x2c\x4d\x15\x81\xbb\xe1\x95\xc3\x87\xeb\x06\xcf\x31\xcf\x00\xa0\xf7\x88\x1c\xfa\xf6\x84\x9d\x91\x95\x30\x07\x3c\xd2\xe1\xee\x8a\xbe\xe6\xba\xde\x43\x6a\x4b\x1b\x32\x3f\xfd\x86\xd6\x64\x48\xaf\xa8\x1e\x57\xe5\xbd\x2f\xc6\xc8\xb1\x8d\xc7\xc9\x7e\x7a\x7c\xab\xf2\x33\xbb\x11\x74\x81\xdb\xf4\x8b\x7d\x8f\x3f\x0f\x6f\x79\x91\x15\x37\x05\xa9\x4b\xda\xcf\x14\xdc\x34\xd1\xf5\xfe\x1d\x5b\x3d\xa3\xf9\x44\x0b\x3c\x3d\x90\xb9\x9e\xdf\x43\xd6\xd0\x8d\x52\x8b\xcf\x01\x00\xb1\xd1\xfa\x33\xcc\x01\x00\x00")
+
+func _20181119150214_add_two_factorUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181119150214_add_two_factorUpSql,
+		"20181119150214_add_two_factor.up.sql",
+	)
+}
+
+func _20181119150214_add_two_factorUpSql() (*asset, error) {
+	bytes, err := _20181119150214_add_two_factorUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181119150214_add_two_factor.up.sql", size: 460, mode: os.FileMode(420), modTime: time.Unix(1792222905, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181122101534_create_notificationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x38\x00\xc7\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x5f\x6d\x75\x74\x65\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x73\x3b\x03\x00\x5e\x61\xfc\xa3\x38\x00\x00\x00")
+
+func _20181122101534_create_notificationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181122101534_create_notificationsDownSql,
+		"20181122101534_create_notifications.down.sql",
+	)
+}
+
+func _20181122101534_create_notificationsDownSql() (*asset, error) {
+	bytes, err := _20181122101534_create_notificationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181122101534_create_notifications.down.sql", size: 56, mode: os.FileMode(420), modTime: time.Unix(1792225579, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181122101534_create_notificationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xc1\x6a\xf3\x30\x10\x84\xef\x7e\x8a\x3d\x5a\xe0\x37\xf0\xc9\xbf\xbd\xf9\x6b\x6a\x2b\x41\x56\x68\x72\x32\xc2\x52\x61\x49\x2d\x15\x5b\xa1\x7d\xfc\x12\x47\x0d\x4a\x20\x0d\xb9\x6a\xe7\x1b\x56\xb3\x53\x0a\x2c\x24\x82\x2c\xfe\x35\x08\xd6\x79\x7a\xa7\x41\x79\x72\x76\x4e\x13\x00\x00\xd2\x30\x9b\x89\xd4\x07\x6c\x44\xdd\x16\x62\x0f\xaf\xb8\xcf\x96\xd1\x71\x36\x53\x4f\x1a\x6a\x2e\xf1\x3f\x0a\xe0\x6b\x09\x7c\xdb\x34\x20\x70\x85\x02\x79\x89\xdd\xa2\x99\x53\xd2\xec\x8c\x1c\xc8\x6a\x90\xb8\x93\x17\xf1\xf9\x5d\x0d\xde\x3d\xeb\xf5\xe9\x66\xff\x08\x39\x69\x22\x64\x70\xe3\x68\xec\x35\x75\x43\x04\x49\x04\x4d\x46\xe9\x5e\x79\x90\x75\x8b\x9d\x2c\xda\x4d\xb4\xf7\x30\x19\xe5\x8d\x8e\x66\x15\xae\x8a\x6d\x23\xc1\xba\xaf\x94\x25\x2c\x4f\x42\xc0\x35\xaf\x70\x77\x1d\x70\x1f\x02\xec\x49\x7f\xc3\x9a\xdf\xa4\x1f\x86\xd9\xe9\x02\x15\x76\xe5\x03\x2b\xbb\xac\xf9\x97\x13\x83\xb7\x17\x14\x78\xf9\x4f\xdd\x2d\x1f\xc9\x93\xbb\x15\xe8\xc7\xa3\x37\xa1\x07\xc1\xe4\x99\x03\xdd\x3b\x76\x54\xa4\xdf\xdd\x32\x38\x90\xd5\x2c\x61\xf9\xcf\x00\x1f\x0f\x3f\x8c\x90\x02\x00\x00")
+
+func _20181122101534_create_notificationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181122101534_create_notificationsUpSql,
+		"20181122101534_create_notifications.up.sql",
+	)
+}
+
+func _20181122101534_create_notificationsUpSql() (*asset, error) {
+	bytes, err := _20181122101534_create_notificationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181122101534_create_notifications.up.sql", size: 656, mode: os.FileMode(420), modTime: time.Unix(1792225579, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181126143312_create_saved_hidden_blocksDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x62\x6c\x6f\x63\x6b\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x68\x69\x64\x64\x65\x6e\x5f\x70\x6f\x73\x74\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x61\x76\x65\x64\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x61\x76\x65\x64\x5f\x70\x6f\x73\x74\x73\x3b\x03\x00\x5d\xfa\xf6\x7e\x5e\x00\x00\x00")
+
+func _20181126143312_create_saved_hidden_blocksDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181126143312_create_saved_hidden_blocksDownSql,
+		"20181126143312_create_saved_hidden_blocks.down.sql",
+	)
+}
+
+func _20181126143312_create_saved_hidden_blocksDownSql() (*asset, error) {
+	bytes, err := _20181126143312_create_saved_hidden_blocksDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181126143312_create_saved_hidden_blocks.down.sql", size: 94, mode: os.FileMode(420), modTime: time.Unix(1792225741, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181126143312_create_saved_hidden_blocksUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x91\x4d\x6a\xc3\x30\x10\x85\xf7\x3e\xc5\x2c\x65\xf0\x0d\xb2\x52\x93\x49\x11\x95\x95\xa0\x28\xd0\xac\x44\xea\x11\x54\xb4\xb1\x4a\x94\xfe\x1c\xbf\xd8\x56\x6b\x41\x0b\xc6\xb4\xab\xec\x04\xf3\x3e\xbd\xf9\x98\xa5\x46\x6e\x10\x0c\xbf\x91\x08\xf1\xf8\xe6\xc8\xbe\x84\x78\x89\xac\x00\x00\x78\x8d\xee\x6c\x3d\x81\x50\x06\x6f\x51\x83\xda\x18\x50\x7b\x29\x41\xe3\x1a\x35\xaa\x25\xee\xfa\x4c\x64\x9e\xca\xaa\x47\x3a\x7a\x0a\xe9\x32\x19\xd2\x9c\xdd\xf1\xe2\x08\x8c\xa8\x71\x67\x78\xbd\x85\x15\xae\xf9\x5e\x1a\x68\xc3\x3b\x4b\xa1\xad\x16\x35\xd7\x07\xb8\xc3\x03\x4b\x6b\x55\x5f\x65\x65\x51\x2e\x8a\x5f\x4c\x9a\x70\x3a\xb9\xf6\x0f\x32\xe9\x83\x29\xea\xbb\xe7\x1f\x94\xc6\xca\x9f\x56\x8f\x9e\xc8\xb5\x57\x71\xa0\x87\xe7\xd0\x3c\x25\x89\xfe\x3d\xdb\x63\xa0\x68\x26\x35\x5b\x65\x5c\xae\xca\x2a\x73\x21\xa1\x56\x78\x3f\xcc\xa2\x1d\x23\xd6\xd3\x07\x6c\x54\x1a\xb0\x8c\x5d\x7c\x0e\x00\x08\xdb\x60\x93\x75\x03\x00\x00")
+
+func _20181126143312_create_saved_hidden_blocksUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181126143312_create_saved_hidden_blocksUpSql,
+		"20181126143312_create_saved_hidden_blocks.up.sql",
+	)
+}
+
+func _20181126143312_create_saved_hidden_blocksUpSql() (*asset, error) {
+	bytes, err := _20181126143312_create_saved_hidden_blocksUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181126143312_create_saved_hidden_blocks.up.sql", size: 885, mode: os.FileMode(420), modTime: time.Unix(1792225741, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181203091845_create_followsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x70\x6f\x73\x74\x73\x5f\x61\x75\x74\x68\x6f\x72\x5f\x69\x64\x5f\x73\x63\x6f\x72\x65\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x70\x6f\x73\x74\x73\x5f\x61\x75\x74\x68\x6f\x72\x5f\x69\x64\x5f\x72\x61\x6e\x6b\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x66\x6f\x6c\x6c\x6f\x77\x73\x3b\x03\x00\x57\x1a\x78\xdc\x5e\x00\x00\x00")
+
+func _20181203091845_create_followsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181203091845_create_followsDownSql,
+		"20181203091845_create_follows.down.sql",
+	)
+}
+
+func _20181203091845_create_followsDownSql() (*asset, error) {
+	bytes, err := _20181203091845_create_followsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181203091845_create_follows.down.sql", size: 94, mode: os.FileMode(420), modTime: time.Unix(1792225866, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181203091845_create_followsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\xd1\x4a\xc3\x30\x14\x86\xef\xf7\x14\xff\x65\x0b\x7d\x03\x45\xa8\xed\x99\x0b\x6b\xb3\x91\x66\xe8\xae\x42\x59\x22\x16\xcb\x22\x49\xc7\x7c\x7c\x69\x48\x25\x0a\xa2\xde\x25\x7c\xff\x9f\x2f\xe7\x54\x82\x4a\x49\x90\xe5\x7d\x43\x78\xb6\xe3\x68\xaf\x3e\x5b\x01\x88\x17\xe3\xd4\xa0\xc1\xb8\xa4\x07\x12\xe0\x3b\x09\x7e\x68\x1a\x08\x5a\x93\x20\x5e\x51\x87\x8b\x37\xce\x67\x83\xce\x8b\xb4\xa6\xff\x59\x3b\x39\xd3\x4f\x46\x43\xb2\x96\x3a\x59\xb6\x7b\xd4\xb4\x2e\x0f\x8d\xc4\xd9\x5e\xb3\x18\xda\x0b\xd6\x96\xe2\x88\x2d\x1d\xb3\xe4\x7b\x45\x2a\x8d\xd1\x6a\x43\xd5\x36\x0d\xe1\xf6\xee\x4b\x6c\x95\xdf\xac\xe2\xf0\x8c\xd7\xf4\x14\xa1\x57\x49\x48\x0d\xfa\x1d\x3b\xbe\xa0\xe5\xb9\xd0\xff\xd6\x7e\xb3\x7e\xf2\xaa\xbf\x4c\x2f\x76\xb6\x29\xd7\x9f\x5f\x97\x7a\x60\xd9\x27\x2b\x30\x43\xd4\xd4\x55\x05\x06\x1d\x0e\x39\x1e\x37\x24\x08\xda\x8c\x66\x5e\x03\xeb\xc2\x9e\x7f\x91\xf8\x93\x75\xe6\x47\x4b\xa0\x7f\xd3\x7c\x0c\x00\x9d\xc0\x71\x46\x06\x02\x00\x00")
+
+func _20181203091845_create_followsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181203091845_create_followsUpSql,
+		"20181203091845_create_follows.up.sql",
+	)
+}
+
+func _20181203091845_create_followsUpSql() (*asset, error) {
+	bytes, err := _20181203091845_create_followsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181203091845_create_follows.up.sql", size: 518, mode: os.FileMode(420), modTime: time.Unix(1792225866, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181210160427_add_body_htmlDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\x8e\xcf\x49\x4d\x4f\x4c\xae\x8c\xcf\x4c\xa9\xb0\xe6\xc2\xaa\xb2\x20\xbf\x18\x4d\x99\xa3\x4f\x88\x6b\x90\x42\x88\xa3\x93\x8f\x2b\xdc\x1c\x05\xb0\x5e\x67\x7f\x9f\x50\x5f\x3f\x05\x88\x99\x44\x28\x4c\xca\x4f\xa9\x8c\xcf\x28\xc9\xcd\x41\x55\x0b\xb6\x92\xa0\x89\x98\xaa\x92\xf2\x53\x2a\xe3\x33\x4a\x72\x73\xac\x01\x03\x00\xd2\x2c\x14\xe3\xf4\x00\x00\x00")
+
+func _20181210160427_add_body_htmlDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181210160427_add_body_htmlDownSql,
+		"20181210160427_add_body_html.down.sql",
+	)
+}
+
+func _20181210160427_add_body_htmlDownSql() (*asset, error) {
+	bytes, err := _20181210160427_add_body_htmlDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181210160427_add_body_html.down.sql", size: 244, mode: os.FileMode(420), modTime: time.Unix(1792229499, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181210160427_add_body_htmlUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xd1\x4e\xc2\x30\x14\x86\xef\x79\x8a\xff\x0e\x4d\x9c\x2f\xc0\xd5\x60\x35\x9a\x94\x2e\xc1\x12\xb9\x23\xa5\x3d\x8c\x65\x5b\x47\xda\x2a\xec\xed\xcd\x36\xd0\x0c\x8c\xca\x65\x4f\xfa\x7d\xf9\xcf\xf9\x63\x2e\xd9\x02\x32\x9e\x72\x86\x7d\xed\x83\x47\x9c\x24\x98\xa5\x7c\x39\x17\xd8\xd4\xa6\x59\xef\x42\x55\x42\xb2\x95\x84\x48\x25\xc4\x92\x73\x24\xec\x29\x5e\x72\x89\xf1\x78\x32\x8a\x22\xd0\x31\xf7\x21\xb7\x59\xfb\x3f\x27\x8f\xbd\x23\xa3\x02\x61\xae\x5c\x61\xea\x83\x7d\xe8\xcc\x99\x23\xff\x28\xe8\x00\x47\xd6\x90\xf3\x08\x3b\xaa\xa0\xac\x81\x2e\x49\xf5\x6f\x6c\x4b\x95\x8d\x7e\xcd\x54\x52\xa6\x74\x83\x69\x9a\x72\x16\x8b\xeb\x4c\xc1\xbd\xd3\xe4\x27\x45\x37\x19\x4a\x5e\x99\xfc\xe2\xb6\xaa\xf4\x17\xa0\xae\xab\x8a\xec\xed\x27\xf9\xcb\x71\xfb\x0a\xdf\x96\xff\x6d\x11\x45\x28\x88\xf6\xfd\x4d\xf5\x8e\x74\x31\xac\xa0\x52\x05\x79\xd4\x16\xf4\x41\xae\x81\x0f\xca\x05\x6c\x5d\x5d\xc1\x6b\x65\x6d\xdb\x65\x4b\x06\xb5\x29\xbb\x7f\x9a\x5a\x53\x33\x76\x74\x6a\x8f\xcc\x68\xb6\x60\xb1\x64\x78\x11\x09\x5b\x75\x76\xbf\xee\x03\xad\x73\x73\x44\x2a\xfa\xd9\x5d\x6e\xee\xf1\xf6\xcc\x16\xec\x14\x77\x32\x04\xcf\x9b\x5d\xb0\xe7\xf1\x35\xfe\x39\x00\x08\x64\xef\x6c\xb1\x02\x00\x00")
+
+func _20181210160427_add_body_htmlUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181210160427_add_body_htmlUpSql,
+		"20181210160427_add_body_html.up.sql",
+	)
+}
+
+func _20181210160427_add_body_htmlUpSql() (*asset, error) {
+	bytes, err := _20181210160427_add_body_htmlUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181210160427_add_body_html.up.sql", size: 689, mode: os.FileMode(420), modTime: time.Unix(1792229499, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181212093514_strip_www_from_urlsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x68\x00\x97\xff\x2d\x2d\x20\x74\x68\x65\x20\x77\x77\x77\x2e\x20\x74\x68\x61\x74\x20\x77\x61\x73\x20\x73\x74\x72\x69\x70\x70\x65\x64\x20\x66\x72\x6f\x6d\x20\x75\x72\x6c\x73\x20\x69\x73\x6e\x27\x74\x20\x6b\x6e\x6f\x77\x6e\x20\x61\x6e\x79\x6d\x6f\x72\x65\x2c\x20\x63\x61\x6e\x6f\x6e\x69\x63\x61\x6c\x20\x75\x72\x6c\x73\x20\x77\x69\x74\x68\x6f\x75\x74\x20\x69\x74\x20\x61\x72\x65\x20\x6a\x75\x73\x74\x20\x61\x73\x20\x76\x61\x6c\x69\x64\x03\x00\x9b\xf8\x63\xd7\x68\x00\x00\x00")
+
+func _20181212093514_strip_www_from_urlsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181212093514_strip_www_from_urlsDownSql,
+		"20181212093514_strip_www_from_urls.down.sql",
+	)
+}
+
+func _20181212093514_strip_www_from_urlsDownSql() (*asset, error) {
+	bytes, err := _20181212093514_strip_www_from_urlsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181212093514_strip_www_from_urls.down.sql", size: 104, mode: os.FileMode(420), modTime: time.Unix(1792227136, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181212093514_strip_www_from_urlsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x64\x00\x9b\xff\x55\x50\x44\x41\x54\x45\x20\x70\x6f\x73\x74\x73\x20\x53\x45\x54\x20\x75\x72\x6c\x20\x3d\x20\x72\x65\x67\x65\x78\x70\x5f\x72\x65\x70\x6c\x61\x63\x65\x28\x75\x72\x6c\x2c\x20\x27\x5e\x28\x68\x74\x74\x70\x73\x3f\x3a\x2f\x2f\x29\x77\x77\x77\x5c\x2e\x27\x2c\x20\x27\x5c\x31\x27\x29\x20\x57\x48\x45\x52\x45\x20\x75\x72\x6c\x20\x7e\x20\x27\x5e\x68\x74\x74\x70\x73\x3f\x3a\x2f\x2f\x77\x77\x77\x5c\x2e\x27\x3b\x03\x00\xf8\x52\x82\x0c\x64\x00\x00\x00")
+
+func _20181212093514_strip_www_from_urlsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181212093514_strip_www_from_urlsUpSql,
+		"20181212093514_strip_www_from_urls.up.sql",
+	)
+}
+
+func _20181212093514_strip_www_from_urlsUpSql() (*asset, error) {
+	bytes, err := _20181212093514_strip_www_from_urlsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181212093514_strip_www_from_urls.up.sql", size: 100, mode: os.FileMode(420), modTime: time.Unix(1792227136, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181214101200_index_posts_by_age_and_scoreDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x61\x00\x9e\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x70\x6f\x73\x74\x73\x5f\x61\x75\x74\x68\x6f\x72\x5f\x69\x64\x5f\x63\x72\x65\x61\x74\x65\x64\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x70\x6f\x73\x74\x73\x5f\x73\x63\x6f\x72\x65\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x70\x6f\x73\x74\x73\x5f\x63\x72\x65\x61\x74\x65\x64\x5f\x69\x64\x78\x3b\x03\x00\xcc\xb7\x26\x7c\x61\x00\x00\x00")
+
+func _20181214101200_index_posts_by_age_and_scoreDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181214101200_index_posts_by_age_and_scoreDownSql,
+		"20181214101200_index_posts_by_age_and_score.down.sql",
+	)
+}
+
+func _20181214101200_index_posts_by_age_and_scoreDownSql() (*asset, error) {
+	bytes, err := _20181214101200_index_posts_by_age_and_scoreDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181214101200_index_posts_by_age_and_score.down.sql", size: 97, mode: os.FileMode(420), modTime: time.Unix(1792228932, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20181214101200_index_posts_by_age_and_scoreUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xce\x41\x0b\x82\x30\x18\xc6\xf1\x7b\x9f\xe2\x39\x16\xe8\x27\xe8\x14\x3a\x48\x10\x03\x2d\xea\x26\xcb\xbd\xb8\xa1\x6c\xb1\xbd\x51\x7d\xfb\x68\x46\x10\x78\xa8\xeb\x6f\xec\xff\x3e\x69\x0a\xed\x18\xa3\x09\x6c\x6c\x1f\x70\x33\xac\xa1\x2d\xbc\xb4\x83\xb1\x3d\x58\x0e\x04\x4f\x1d\x59\xc6\xc5\x05\x0e\x38\x3f\x20\x7b\x82\xb4\x0a\x6e\x54\xe4\xe1\x2c\x45\x0d\x9d\xf3\xb4\xc8\x6a\xb1\xd9\x0b\x14\x55\x2e\x4e\xd3\x8f\xb6\xf3\x24\x99\x54\x6b\xd4\x1d\xbb\x6a\xc2\xe5\x1b\x91\x8b\x26\x5b\xe1\xb8\x15\xb5\x80\xa2\x91\x5e\x56\x34\xa8\x0e\x65\xb9\x9e\x8b\xc5\x2b\xdf\xa9\x48\x31\x94\xc0\xfc\x5f\x94\x57\xd6\xce\xb7\x46\xcd\x0f\xfd\x3c\x27\xf8\x65\xf3\x73\x00\x8e\x9e\x4e\xc7\x51\x01\x00\x00")
+
+func _20181214101200_index_posts_by_age_and_scoreUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20181214101200_index_posts_by_age_and_scoreUpSql,
+		"20181214101200_index_posts_by_age_and_score.up.sql",
+	)
+}
+
+func _20181214101200_index_posts_by_age_and_scoreUpSql() (*asset, error) {
+	bytes, err := _20181214101200_index_posts_by_age_and_scoreUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20181214101200_index_posts_by_age_and_score.up.sql", size: 337, mode: os.FileMode(420), modTime: time.Unix(1792228932, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -448,7 +1435,54 @@ var _bindata = map[string]func() (*asset, error){
 	"20180926010031_add_calculate_depth.up.sql": _20180926010031_add_calculate_depthUpSql,
 	"20180926010233_create_comment_votes_table.down.sql": _20180926010233_create_comment_votes_tableDownSql,
 	"20180926010233_create_comment_votes_table.up.sql": _20180926010233_create_comment_votes_tableUpSql,
-	"bindata.go": bindataGo,
+	"20181002193011_add_comment_votes_unique.down.sql": _20181002193011_add_comment_votes_uniqueDownSql,
+	"20181002193011_add_comment_votes_unique.up.sql": _20181002193011_add_comment_votes_uniqueUpSql,
+	"20181004214507_add_comments_tree_indexes.down.sql": _20181004214507_add_comments_tree_indexesDownSql,
+	"20181004214507_add_comments_tree_indexes.up.sql": _20181004214507_add_comments_tree_indexesUpSql,
+	"20181008170322_add_posts_rank.down.sql": _20181008170322_add_posts_rankDownSql,
+	"20181008170322_add_posts_rank.up.sql": _20181008170322_add_posts_rankUpSql,
+	"20181010203115_add_vote_counters.down.sql": _20181010203115_add_vote_countersDownSql,
+	"20181010203115_add_vote_counters.up.sql": _20181010203115_add_vote_countersUpSql,
+	"20181013154210_add_search_vectors.down.sql": _20181013154210_add_search_vectorsDownSql,
+	"20181013154210_add_search_vectors.up.sql": _20181013154210_add_search_vectorsUpSql,
+	"20181015191842_add_posts_url.down.sql": _20181015191842_add_posts_urlDownSql,
+	"20181015191842_add_posts_url.up.sql": _20181015191842_add_posts_urlUpSql,
+	"20181018162533_create_communities.down.sql": _20181018162533_create_communitiesDownSql,
+	"20181018162533_create_communities.up.sql": _20181018162533_create_communitiesUpSql,
+	"20181021140517_add_moderation.down.sql": _20181021140517_add_moderationDownSql,
+	"20181021140517_add_moderation.up.sql": _20181021140517_add_moderationUpSql,
+	"20181023201104_create_reports.down.sql": _20181023201104_create_reportsDownSql,
+	"20181023201104_create_reports.up.sql": _20181023201104_create_reportsUpSql,
+	"20181025183420_add_author_indexes.down.sql": _20181025183420_add_author_indexesDownSql,
+	"20181025183420_add_author_indexes.up.sql": _20181025183420_add_author_indexesUpSql,
+	"20181028110932_create_user_tokens.down.sql": _20181028110932_create_user_tokensDownSql,
+	"20181028110932_create_user_tokens.up.sql": _20181028110932_create_user_tokensUpSql,
+	"20181030174215_add_users_email_verified.down.sql": _20181030174215_add_users_email_verifiedDownSql,
+	"20181030174215_add_users_email_verified.up.sql": _20181030174215_add_users_email_verifiedUpSql,
+	"20181102093758_add_users_session_epoch.down.sql": _20181102093758_add_users_session_epochDownSql,
+	"20181102093758_add_users_session_epoch.up.sql": _20181102093758_add_users_session_epochUpSql,
+	"20181106152047_add_deleted_user.down.sql": _20181106152047_add_deleted_userDownSql,
+	"20181106152047_add_deleted_user.up.sql": _20181106152047_add_deleted_userUpSql,
+	"20181109201536_create_api_tokens.down.sql": _20181109201536_create_api_tokensDownSql,
+	"20181109201536_create_api_tokens.up.sql": _20181109201536_create_api_tokensUpSql,
+	"20181112184410_create_rate_limits.down.sql": _20181112184410_create_rate_limitsDownSql,
+	"20181112184410_create_rate_limits.up.sql": _20181112184410_create_rate_limitsUpSql,
+	"20181115123059_create_login_failures.down.sql": _20181115123059_create_login_failuresDownSql,
+	"20181115123059_create_login_failures.up.sql": _20181115123059_create_login_failuresUpSql,
+	"20181119150214_add_two_factor.down.sql": _20181119150214_add_two_factorDownSql,
+	"20181119150214_add_two_factor.up.sql": _20181119150214_add_two_factorUpSql,
+	"20181122101534_create_notifications.down.sql": _20181122101534_create_notificationsDownSql,
+	"20181122101534_create_notifications.up.sql": _20181122101534_create_notificationsUpSql,
+	"20181126143312_create_saved_hidden_blocks.down.sql": _20181126143312_create_saved_hidden_blocksDownSql,
+	"20181126143312_create_saved_hidden_blocks.up.sql": _20181126143312_create_saved_hidden_blocksUpSql,
+	"20181203091845_create_follows.down.sql": _20181203091845_create_followsDownSql,
+	"20181203091845_create_follows.up.sql": _20181203091845_create_followsUpSql,
+	"20181210160427_add_body_html.down.sql": _20181210160427_add_body_htmlDownSql,
+	"20181210160427_add_body_html.up.sql": _20181210160427_add_body_htmlUpSql,
+	"20181212093514_strip_www_from_urls.down.sql": _20181212093514_strip_www_from_urlsDownSql,
+	"20181212093514_strip_www_from_urls.up.sql": _20181212093514_strip_www_from_urlsUpSql,
+	"20181214101200_index_posts_by_age_and_score.down.sql": _20181214101200_index_posts_by_age_and_scoreDownSql,
+	"20181214101200_index_posts_by_age_and_score.up.sql": _20181214101200_index_posts_by_age_and_scoreUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -505,7 +1539,54 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"20180926010031_add_calculate_depth.up.sql": &bintree{_20180926010031_add_calculate_depthUpSql, map[string]*bintree{}},
 	"20180926010233_create_comment_votes_table.down.sql": &bintree{_20180926010233_create_comment_votes_tableDownSql, map[string]*bintree{}},
 	"20180926010233_create_comment_votes_table.up.sql": &bintree{_20180926010233_create_comment_votes_tableUpSql, map[string]*bintree{}},
-	"bindata.go": &bintree{bindataGo, map[string]*bintree{}},
+	"20181002193011_add_comment_votes_unique.down.sql": &bintree{_20181002193011_add_comment_votes_uniqueDownSql, map[string]*bintree{}},
+	"20181002193011_add_comment_votes_unique.up.sql": &bintree{_20181002193011_add_comment_votes_uniqueUpSql, map[string]*bintree{}},
+	"20181004214507_add_comments_tree_indexes.down.sql": &bintree{_20181004214507_add_comments_tree_indexesDownSql, map[string]*bintree{}},
+	"20181004214507_add_comments_tree_indexes.up.sql": &bintree{_20181004214507_add_comments_tree_indexesUpSql, map[string]*bintree{}},
+	"20181008170322_add_posts_rank.down.sql": &bintree{_20181008170322_add_posts_rankDownSql, map[string]*bintree{}},
+	"20181008170322_add_posts_rank.up.sql": &bintree{_20181008170322_add_posts_rankUpSql, map[string]*bintree{}},
+	"20181010203115_add_vote_counters.down.sql": &bintree{_20181010203115_add_vote_countersDownSql, map[string]*bintree{}},
+	"20181010203115_add_vote_counters.up.sql": &bintree{_20181010203115_add_vote_countersUpSql, map[string]*bintree{}},
+	"20181013154210_add_search_vectors.down.sql": &bintree{_20181013154210_add_search_vectorsDownSql, map[string]*bintree{}},
+	"20181013154210_add_search_vectors.up.sql": &bintree{_20181013154210_add_search_vectorsUpSql, map[string]*bintree{}},
+	"20181015191842_add_posts_url.down.sql": &bintree{_20181015191842_add_posts_urlDownSql, map[string]*bintree{}},
+	"20181015191842_add_posts_url.up.sql": &bintree{_20181015191842_add_posts_urlUpSql, map[string]*bintree{}},
+	"20181018162533_create_communities.down.sql": &bintree{_20181018162533_create_communitiesDownSql, map[string]*bintree{}},
+	"20181018162533_create_communities.up.sql": &bintree{_20181018162533_create_communitiesUpSql, map[string]*bintree{}},
+	"20181021140517_add_moderation.down.sql": &bintree{_20181021140517_add_moderationDownSql, map[string]*bintree{}},
+	"20181021140517_add_moderation.up.sql": &bintree{_20181021140517_add_moderationUpSql, map[string]*bintree{}},
+	"20181023201104_create_reports.down.sql": &bintree{_20181023201104_create_reportsDownSql, map[string]*bintree{}},
+	"20181023201104_create_reports.up.sql": &bintree{_20181023201104_create_reportsUpSql, map[string]*bintree{}},
+	"20181025183420_add_author_indexes.down.sql": &bintree{_20181025183420_add_author_indexesDownSql, map[string]*bintree{}},
+	"20181025183420_add_author_indexes.up.sql": &bintree{_20181025183420_add_author_indexesUpSql, map[string]*bintree{}},
+	"20181028110932_create_user_tokens.down.sql": &bintree{_20181028110932_create_user_tokensDownSql, map[string]*bintree{}},
+	"20181028110932_create_user_tokens.up.sql": &bintree{_20181028110932_create_user_tokensUpSql, map[string]*bintree{}},
+	"20181030174215_add_users_email_verified.down.sql": &bintree{_20181030174215_add_users_email_verifiedDownSql, map[string]*bintree{}},
+	"20181030174215_add_users_email_verified.up.sql": &bintree{_20181030174215_add_users_email_verifiedUpSql, map[string]*bintree{}},
+	"20181102093758_add_users_session_epoch.down.sql": &bintree{_20181102093758_add_users_session_epochDownSql, map[string]*bintree{}},
+	"20181102093758_add_users_session_epoch.up.sql": &bintree{_20181102093758_add_users_session_epochUpSql, map[string]*bintree{}},
+	"20181106152047_add_deleted_user.down.sql": &bintree{_20181106152047_add_deleted_userDownSql, map[string]*bintree{}},
+	"20181106152047_add_deleted_user.up.sql": &bintree{_20181106152047_add_deleted_userUpSql, map[string]*bintree{}},
+	"20181109201536_create_api_tokens.down.sql": &bintree{_20181109201536_create_api_tokensDownSql, map[string]*bintree{}},
+	"20181109201536_create_api_tokens.up.sql": &bintree{_20181109201536_create_api_tokensUpSql, map[string]*bintree{}},
+	"20181112184410_create_rate_limits.down.sql": &bintree{_20181112184410_create_rate_limitsDownSql, map[string]*bintree{}},
+	"20181112184410_create_rate_limits.up.sql": &bintree{_20181112184410_create_rate_limitsUpSql, map[string]*bintree{}},
+	"20181115123059_create_login_failures.down.sql": &bintree{_20181115123059_create_login_failuresDownSql, map[string]*bintree{}},
+	"20181115123059_create_login_failures.up.sql": &bintree{_20181115123059_create_login_failuresUpSql, map[string]*bintree{}},
+	"20181119150214_add_two_factor.down.sql": &bintree{_20181119150214_add_two_factorDownSql, map[string]*bintree{}},
+	"20181119150214_add_two_factor.up.sql": &bintree{_20181119150214_add_two_factorUpSql, map[string]*bintree{}},
+	"20181122101534_create_notifications.down.sql": &bintree{_20181122101534_create_notificationsDownSql, map[string]*bintree{}},
+	"20181122101534_create_notifications.up.sql": &bintree{_20181122101534_create_notificationsUpSql, map[string]*bintree{}},
+	"20181126143312_create_saved_hidden_blocks.down.sql": &bintree{_20181126143312_create_saved_hidden_blocksDownSql, map[string]*bintree{}},
+	"20181126143312_create_saved_hidden_blocks.up.sql": &bintree{_20181126143312_create_saved_hidden_blocksUpSql, map[string]*bintree{}},
+	"20181203091845_create_follows.down.sql": &bintree{_20181203091845_create_followsDownSql, map[string]*bintree{}},
+	"20181203091845_create_follows.up.sql": &bintree{_20181203091845_create_followsUpSql, map[string]*bintree{}},
+	"20181210160427_add_body_html.down.sql": &bintree{_20181210160427_add_body_htmlDownSql, map[string]*bintree{}},
+	"20181210160427_add_body_html.up.sql": &bintree{_20181210160427_add_body_htmlUpSql, map[string]*bintree{}},
+	"20181212093514_strip_www_from_urls.down.sql": &bintree{_20181212093514_strip_www_from_urlsDownSql, map[string]*bintree{}},
+	"20181212093514_strip_www_from_urls.up.sql": &bintree{_20181212093514_strip_www_from_urlsUpSql, map[string]*bintree{}},
+	"20181214101200_index_posts_by_age_and_score.down.sql": &bintree{_20181214101200_index_posts_by_age_and_scoreDownSql, map[string]*bintree{}},
+	"20181214101200_index_posts_by_age_and_score.up.sql": &bintree{_20181214101200_index_posts_by_age_and_scoreUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/moderation"
+	"github.com/jmoiron/sqlx"
+)
+
+// ModerationRepository implements `moderation.Repository` interface
+type ModerationRepository struct {
+	db *sqlx.DB
+}
+
+// NewModerationRepository is a constructor
+func NewModerationRepository(db *sql.DB) moderation.Repository {
+	return &ModerationRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *ModerationRepository) IsModerator(ctx context.Context, userID int, communityID *int) (bool, error) {
+	op := errors.Op("moderation.Repository.IsModerator")
+	query := `
+	SELECT EXISTS(
+		SELECT 1 FROM moderators
+		WHERE user_id = $1 AND (community_id IS NULL OR community_id = $2)
+	)`
+
+	var ok bool
+	err := repo.db.QueryRowContext(ctx, query, userID, communityID).
+		Scan(&ok)
+	if err != nil {
+		return false, errors.E(errors.Internal, op, err)
+	}
+	return ok, nil
+}
+
+func (repo *ModerationRepository) Target(ctx context.Context, action moderation.Action, targetID int) (communityID *int, postID int, err error) {
+	op := errors.Op("moderation.Repository.Target")
+	query := `SELECT community_id, id FROM posts WHERE id = $1 AND deleted IS NULL`
+	notFound := moderation.ErrPostNotFound
+	if action == moderation.RemoveComment || action == moderation.RestoreComment {
+		query = `
+		SELECT p.community_id, p.id FROM comments c
+		JOIN posts p ON p.id = c.post_id
+		WHERE c.id = $1 AND c.deleted IS NULL`
+		notFound = moderation.ErrCommentNotFound
+	}
+
+	err = repo.db.QueryRowContext(ctx, query, targetID).
+		Scan(&communityID, &postID)
+	if err == sql.ErrNoRows {
+		return nil, 0, notFound
+	}
+	if err != nil {
+		return nil, 0, errors.E(errors.Internal, op, err)
+	}
+	return communityID, postID, nil
+}
+
+// moderations are the statements applying each action,
+// they only affect rows which aren't already in the resulting state
+var moderations = map[moderation.Action]string{
+	moderation.RemovePost:      `UPDATE posts SET removed = now() WHERE id = $1 AND deleted IS NULL AND removed IS NULL`,
+	moderation.RestorePost:     `UPDATE posts SET removed = NULL WHERE id = $1 AND removed IS NOT NULL`,
+	moderation.LockPost:        `UPDATE posts SET locked = now() WHERE id = $1 AND deleted IS NULL AND locked IS NULL`,
+	moderation.UnlockPost:      `UPDATE posts SET locked = NULL WHERE id = $1 AND locked IS NOT NULL`,
+	moderation.RemoveComment:   `UPDATE comments SET removed = now() WHERE id = $1 AND deleted IS NULL AND removed IS NULL`,
+	moderation.RestoreComment:  `UPDATE comments SET removed = NULL WHERE id = $1 AND removed IS NOT NULL`,
+	moderation.AddModerator:    `INSERT INTO moderators(user_id, community_id) VALUES($1, $2) ON CONFLICT DO NOTHING`,
+	moderation.RemoveModerator: `DELETE FROM moderators WHERE user_id = $1 AND community_id IS NOT DISTINCT FROM $2`,
+}
+
+// moderationReferences map the foreign keys an action can violate to what wasn't found
+var moderationReferences = map[string]error{
+	"moderators_user_id_fkey":      moderation.ErrUserNotFound,
+	"moderators_community_id_fkey": moderation.ErrCommunityNotFound,
+	"mod_log_moderator_id_fkey":    moderation.ErrUserNotFound,
+	"mod_log_community_id_fkey":    moderation.ErrCommunityNotFound,
+}
+
+func (repo *ModerationRepository) Act(ctx context.Context, entry *moderation.Entry) error {
+	op := errors.Op("moderation.Repository.Act")
+	stmt, ok := moderations[entry.Action]
+	if !ok {
+		return moderation.ErrUnknownAction
+	}
+	args := []interface{}{entry.TargetID}
+	if entry.Action == moderation.AddModerator || entry.Action == moderation.RemoveModerator {
+		args = append(args, entry.CommunityID)
+	}
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected < 1 {
+			return moderation.ErrNoChange
+		}
+
+		log := `
+		INSERT INTO mod_log(moderator_id, community_id, action, target_id, reason)
+		VALUES(NULLIF($1, 0), $2, $3, $4, $5) RETURNING id, created`
+		return tx.QueryRowContext(ctx, log,
+			entry.ModeratorID, entry.CommunityID, entry.Action, entry.TargetID, entry.Reason).
+			Scan(&entry.ID, &entry.Created)
+	})
+	if notFound, ok := moderationReferences[violatedForeignKey(err)]; ok {
+		return notFound
+	}
+	return internal(op, err)
+}
+
+func (repo *ModerationRepository) Log(ctx context.Context, q moderation.LogQuery) ([]*moderation.Entry, error) {
+	op := errors.Op("moderation.Repository.Log")
+	query := `
+	SELECT id, COALESCE(moderator_id, 0), community_id, action, target_id, reason, created
+	FROM mod_log
+	WHERE ($1 = 0 OR community_id = $1)
+		AND ($2 = 0 OR moderator_id = $2)
+		AND ($3 = 0 OR id < $3)
+	ORDER BY id DESC LIMIT $4`
+
+	rows, err := repo.db.QueryContext(ctx, query, q.CommunityID, q.ModeratorID, q.Before, q.Limit)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	entries := []*moderation.Entry{}
+	for rows.Next() {
+		e := &moderation.Entry{}
+		err := rows.Scan(&e.ID, &e.ModeratorID, &e.CommunityID, &e.Action, &e.TargetID, &e.Reason, &e.Created)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/moderation"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestModerationRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	postID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Spam", Body: "Buy now"})
+	c.Assert(err, qt.IsNil)
+	commentrepo := NewCommentRepository(db)
+
+	repo := NewModerationRepository(db)
+
+	// Moderators
+	ok, err := repo.IsModerator(ctx, user.ID, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, false)
+	err = repo.Act(ctx, &moderation.Entry{Action: moderation.AddModerator, TargetID: user.ID})
+	c.Assert(err, qt.IsNil)
+	err = repo.Act(ctx, &moderation.Entry{Action: moderation.AddModerator, TargetID: user.ID})
+	c.Assert(err, qt.Equals, moderation.ErrNoChange)
+	err = repo.Act(ctx, &moderation.Entry{Action: moderation.AddModerator, TargetID: 4242})
+	c.Assert(err, qt.Equals, moderation.ErrUserNotFound)
+	missing := 4242
+	err = repo.Act(ctx, &moderation.Entry{Action: moderation.AddModerator, TargetID: user.ID, CommunityID: &missing})
+	c.Assert(err, qt.Equals, moderation.ErrCommunityNotFound)
+	ok, err = repo.IsModerator(ctx, user.ID, &postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, true)
+
+	// Target
+	community, target, err := repo.Target(ctx, moderation.RemovePost, postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(community, qt.IsNil)
+	c.Assert(target, qt.Equals, postID)
+	_, _, err = repo.Target(ctx, moderation.RemoveComment, 4242)
+	c.Assert(err, qt.Equals, moderation.ErrCommentNotFound)
+
+	// Lock
+	err = repo.Act(ctx, &moderation.Entry{ModeratorID: user.ID, Action: moderation.LockPost, TargetID: postID})
+	c.Assert(err, qt.IsNil)
+	_, err = commentrepo.Create(ctx, &comments.Comment{PostID: postID, CommenterID: user.ID, Body: "first"})
+	c.Assert(err, qt.Equals, comments.ErrPostLocked)
+
+	// Remove/Restore
+	err = repo.Act(ctx, &moderation.Entry{ModeratorID: user.ID, Action: moderation.RemovePost, TargetID: postID, Reason: "spam"})
+	c.Assert(err, qt.IsNil)
+	_, err = postrepo.Get(ctx, postID)
+	c.Assert(err, qt.Equals, posts.ErrPostNotFound)
+	err = repo.Act(ctx, &moderation.Entry{ModeratorID: user.ID, Action: moderation.RestorePost, TargetID: postID})
+	c.Assert(err, qt.IsNil)
+	post, err := postrepo.Get(ctx, postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(post.Locked, qt.Equals, true)
+
+	// Log
+	entries, err := repo.Log(ctx, moderation.LogQuery{ModeratorID: user.ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 3)
+	c.Assert(entries[0].Action, qt.Equals, moderation.RestorePost)
+	c.Assert(entries[1].Reason, qt.Equals, "spam")
+	entries, err = repo.Log(ctx, moderation.LogQuery{Before: entries[1].ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 2)
+	c.Assert(entries[1].ModeratorID, qt.Equals, 0)
+
+	// Log is append-only
+	_, err = db.Exec(`DELETE FROM mod_log`)
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = db.Exec(`UPDATE mod_log SET reason = ''`)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/notifications"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// NotificationRepository implements `notifications.Repository` interface
+type NotificationRepository struct {
+	db *sqlx.DB
+}
+
+// NewNotificationRepository is a constructor
+func NewNotificationRepository(db *sql.DB) notifications.Repository {
+	return &NotificationRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *NotificationRepository) Create(ctx context.Context, ns []*notifications.Notification) error {
+	op := errors.Op("notifications.Repository.Create")
+	// erased users, users who muted the kind and users who blocked the actor are skipped
+	stmt := `
+	INSERT INTO notifications(user_id, kind, actor_id, post_id, comment_id)
+	SELECT u.id, $2::text, $3::int, $4::int, $5::int FROM users u
+	WHERE u.id = $1 AND u.deleted IS NULL AND NOT EXISTS (
+		SELECT 1 FROM notification_mutes m WHERE m.user_id = u.id AND m.kind = $2
+	) AND NOT EXISTS (
+		SELECT 1 FROM blocks b WHERE b.blocker_id = u.id AND b.blocked_id = $3
+	)`
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		for _, n := range ns {
+			var commentID *int
+			if n.CommentID != 0 {
+				commentID = &n.CommentID
+			}
+			_, err := tx.ExecContext(ctx, stmt, n.UserID, n.Kind, n.ActorID, n.PostID, commentID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+func (repo *NotificationRepository) PostAuthor(ctx context.Context, postID int) (int, error) {
+	op := errors.Op("notifications.Repository.PostAuthor")
+
+	var userID int
+	err := repo.db.QueryRowContext(ctx, `SELECT author_id FROM posts WHERE id = $1`, postID).Scan(&userID)
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return userID, nil
+}
+
+func (repo *NotificationRepository) CommentAuthor(ctx context.Context, commentID int) (int, error) {
+	op := errors.Op("notifications.Repository.CommentAuthor")
+
+	var userID int
+	err := repo.db.QueryRowContext(ctx, `SELECT commenter_id FROM comments WHERE id = $1`, commentID).Scan(&userID)
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return userID, nil
+}
+
+func (repo *NotificationRepository) Users(ctx context.Context, usernames []string) ([]int, error) {
+	op := errors.Op("notifications.Repository.Users")
+	query := `SELECT id FROM users WHERE username = ANY($1) AND deleted IS NULL`
+
+	userIDs := []int{}
+	err := repo.db.SelectContext(ctx, &userIDs, query, pq.Array(usernames))
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return userIDs, nil
+}
+
+func (repo *NotificationRepository) Inbox(ctx context.Context, q notifications.InboxQuery) ([]*notifications.Notification, error) {
+	op := errors.Op("notifications.Repository.Inbox")
+	query := `
+	SELECT n.id, n.user_id, n.kind, n.actor_id, a.username, n.post_id, COALESCE(n.comment_id, 0),
+		n.read_at IS NOT NULL, n.created
+	FROM notifications n JOIN users a ON a.id = n.actor_id
+	WHERE n.user_id = $1 AND ($2 = 0 OR n.id < $2) AND (NOT $3 OR n.read_at IS NULL)
+	ORDER BY n.id DESC LIMIT $4`
+
+	rows, err := repo.db.QueryContext(ctx, query, q.UserID, q.Before, q.Unread, q.Limit)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	ns := []*notifications.Notification{}
+	for rows.Next() {
+		n := &notifications.Notification{}
+		err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.ActorID, &n.Actor, &n.PostID, &n.CommentID, &n.Read, &n.Created)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		ns = append(ns, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return ns, nil
+}
+
+func (repo *NotificationRepository) Unread(ctx context.Context, userID int) (int, error) {
+	op := errors.Op("notifications.Repository.Unread")
+	query := `SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return count, nil
+}
+
+func (repo *NotificationRepository) MarkRead(ctx context.Context, userID int, notificationID int) error {
+	op := errors.Op("notifications.Repository.MarkRead")
+	stmt := `UPDATE notifications SET read_at = COALESCE(read_at, now()) WHERE id = $1 AND user_id = $2`
+
+	result, err := repo.db.ExecContext(ctx, stmt, notificationID, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return notifications.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (repo *NotificationRepository) MarkAllRead(ctx context.Context, userID int) error {
+	op := errors.Op("notifications.Repository.MarkAllRead")
+	stmt := `UPDATE notifications SET read_at = now() WHERE user_id = $1 AND read_at IS NULL`
+
+	_, err := repo.db.ExecContext(ctx, stmt, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+func (repo *NotificationRepository) Mutes(ctx context.Context, userID int) ([]notifications.Kind, error) {
+	op := errors.Op("notifications.Repository.Mutes")
+	query := `SELECT kind FROM notification_mutes WHERE user_id = $1 ORDER BY kind`
+
+	kinds := []notifications.Kind{}
+	err := repo.db.SelectContext(ctx, &kinds, query, userID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return kinds, nil
+}
+
+func (repo *NotificationRepository) SetMuted(ctx context.Context, userID int, kind notifications.Kind, muted bool) error {
+	op := errors.Op("notifications.Repository.SetMuted")
+	stmt := `DELETE FROM notification_mutes WHERE user_id = $1 AND kind = $2`
+	if muted {
+		stmt = `INSERT INTO notification_mutes(user_id, kind) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	}
+	_, err := repo.db.ExecContext(ctx, stmt, userID, kind)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
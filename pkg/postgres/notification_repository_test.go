@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/notifications"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestNotificationRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	postID, err := NewPostRepository(db).Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Title", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	commentID, err := NewCommentRepository(db).Create(ctx, &comments.Comment{PostID: postID, CommenterID: user2.ID, Body: "@pacninja"})
+	c.Assert(err, qt.IsNil)
+
+	repo := NewNotificationRepository(db)
+
+	// Authors and users
+	author, err := repo.PostAuthor(ctx, postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(author, qt.Equals, user.ID)
+	author, err = repo.CommentAuthor(ctx, commentID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(author, qt.Equals, user2.ID)
+	userIDs, err := repo.Users(ctx, []string{"pacninja", "nobody"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(userIDs, qt.DeepEquals, []int{user.ID})
+
+	// Create leaves out muted kinds
+	err = repo.SetMuted(ctx, user.ID, notifications.Mention, true)
+	c.Assert(err, qt.IsNil)
+	mutes, err := repo.Mutes(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(mutes, qt.DeepEquals, []notifications.Kind{notifications.Mention})
+	err = repo.Create(ctx, []*notifications.Notification{
+		{UserID: user.ID, Kind: notifications.PostReply, ActorID: user2.ID, PostID: postID, CommentID: commentID},
+		{UserID: user.ID, Kind: notifications.Mention, ActorID: user2.ID, PostID: postID, CommentID: commentID},
+		{UserID: user2.ID, Kind: notifications.Mention, ActorID: user.ID, PostID: postID},
+	})
+	c.Assert(err, qt.IsNil)
+	err = repo.SetMuted(ctx, user.ID, notifications.Mention, false)
+	c.Assert(err, qt.IsNil)
+	mutes, err = repo.Mutes(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(mutes, qt.HasLen, 0)
+
+	// Inbox
+	inbox, err := repo.Inbox(ctx, notifications.InboxQuery{UserID: user.ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox, qt.HasLen, 1)
+	c.Assert(inbox[0].Kind, qt.Equals, notifications.PostReply)
+	c.Assert(inbox[0].Actor, qt.Equals, "lala")
+	c.Assert(inbox[0].CommentID, qt.Equals, commentID)
+	inbox, err = repo.Inbox(ctx, notifications.InboxQuery{UserID: user2.ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox, qt.HasLen, 1)
+	c.Assert(inbox[0].CommentID, qt.Equals, 0)
+	inbox, err = repo.Inbox(ctx, notifications.InboxQuery{UserID: user2.ID, Before: inbox[0].ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox, qt.HasLen, 0)
+
+	// Reading
+	unread, err := repo.Unread(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(unread, qt.Equals, 1)
+	inbox, err = repo.Inbox(ctx, notifications.InboxQuery{UserID: user.ID, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	err = repo.MarkRead(ctx, user2.ID, inbox[0].ID)
+	c.Assert(err, qt.Equals, notifications.ErrNotificationNotFound)
+	err = repo.MarkRead(ctx, user.ID, inbox[0].ID)
+	c.Assert(err, qt.IsNil)
+	unread, err = repo.Unread(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(unread, qt.Equals, 0)
+	inbox, err = repo.Inbox(ctx, notifications.InboxQuery{UserID: user.ID, Unread: true, Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(inbox, qt.HasLen, 0)
+
+	err = repo.MarkAllRead(ctx, user2.ID)
+	c.Assert(err, qt.IsNil)
+	unread, err = repo.Unread(ctx, user2.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(unread, qt.Equals, 0)
+}
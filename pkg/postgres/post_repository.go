@@ -3,49 +3,221 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/ranking"
 	"github.com/jmoiron/sqlx"
 )
 
 // PostRepository implements `posts.Repository` interface
 type PostRepository struct {
-	db *sqlx.DB
+	db       *sqlx.DB
+	rankings map[posts.Sort][]ranked
 }
 
-// NewPostRepository is a constructor
+// ranked is a part of a listing, a listing merges the top of each of its parts.
+// Rank is the SQL expression the part is ranked by and order one that sorts posts the same way,
+// which an index can serve. Where narrows the part down and cursor prefilters it so the
+// index scan starts at the cursor, both may refer to the listing's args.
+type ranked struct {
+	rank   string
+	order  string
+	where  string
+	cursor string
+}
+
+// NewPostRepository is a constructor, hot listings are ordered by the rank the ranking service stores
 func NewPostRepository(db *sql.DB) posts.Repository {
+	return &PostRepository{
+		db:       sqlx.NewDb(db, "postgres"),
+		rankings: rankings(ranked{rank: `p.rank`, order: `p.rank`}),
+	}
+}
+
+// NewRankedPostRepository is NewPostRepository with hot listings ranked as o ranks posts.
+// Ranks that decay with time can't be kept in a cursor when they're stored, as the ranking service
+// rewrites them between pages and posts would be listed twice or skipped. So hn ranks are taken as of
+// the listing's AsOf: posts within the window are ranked as they're listed, and as older ones have
+// stopped decaying their rank is their score over a constant, which they're listed by.
+func NewRankedPostRepository(db *sql.DB, o ranking.Options) posts.Repository {
+	if o.Stored() {
+		return NewPostRepository(db)
+	}
+	window := fmt.Sprintf(`$1::timestamp - make_interval(secs => %g)`, o.Window.Seconds())
+	settled := fmt.Sprintf(`power(%g, %g)`, o.Window.Hours()+2, o.Gravity)
+	decaying := fmt.Sprintf(`p.score / power(GREATEST(EXTRACT(EPOCH FROM $1::timestamp - p.created), 0) / 3600 + 2, %g)`, o.Gravity)
 	return &PostRepository{
 		db: sqlx.NewDb(db, "postgres"),
+		rankings: rankings(
+			ranked{
+				// mirrors ranking.HN
+				rank:  decaying,
+				order: decaying,
+				where: `p.created > ` + window,
+			},
+			ranked{
+				rank:   `p.score / ` + settled,
+				order:  `p.score`,
+				where:  `p.created <= ` + window,
+				cursor: `p.score <= ceil($6 * ` + settled + `)`,
+			},
+		),
 	}
 }
 
 func (repo *PostRepository) Create(ctx context.Context, post *posts.Post) (id int, err error) {
-	stmt := `INSERT INTO posts(author_id, title, body) VALUES($1, $2, $3) RETURNING id`
+	stmt := `
+	INSERT INTO posts(author_id, community_id, title, body, body_html, url, domain)
+	VALUES($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, '')) RETURNING id`
 
-	err = repo.db.QueryRowContext(ctx, stmt,
-		post.AuthorID, post.Title, post.Body).
-		Scan(&id)
+	err = transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		if post.URL != "" {
+			// submissions of the same link take turns, so only one of them gets past the duplicate check
+			if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, post.URL); err != nil {
+				return err
+			}
+			query := `
+			SELECT id FROM posts
+			WHERE url = $1 AND created >= $2 AND deleted IS NULL AND removed IS NULL
+			ORDER BY created DESC LIMIT 1`
+			err := tx.QueryRowContext(ctx, query, post.URL, time.Now().Add(-posts.RepostWindow)).Scan(&id)
+			if err == nil {
+				return posts.ErrDuplicateURL
+			}
+			if err != sql.ErrNoRows {
+				return err
+			}
+		}
+		return tx.QueryRowContext(ctx, stmt,
+			post.AuthorID, post.CommunityID, post.Title, post.Body, post.BodyHTML, post.URL, post.Domain).
+			Scan(&id)
+	})
 	return
 }
 
 func (repo *PostRepository) Get(ctx context.Context, postID int) (*posts.Post, error) {
-	query := `SELECT id, author_id, body, title FROM posts WHERE id = $1 AND deleted IS NULL;`
+	query := `
+	SELECT id, author_id, community_id, title, body, body_html, COALESCE(url, ''), COALESCE(domain, ''),
+		locked IS NOT NULL, created, upvotes, downvotes, score
+	FROM posts WHERE id = $1 AND deleted IS NULL AND removed IS NULL;`
 
 	post := &posts.Post{}
 	err := repo.db.QueryRowContext(ctx, query, postID).
-		Scan(&post.ID, &post.AuthorID, &post.Title, &post.Body)
+		Scan(&post.ID, &post.AuthorID, &post.CommunityID, &post.Title, &post.Body, &post.BodyHTML, &post.URL, &post.Domain,
+			&post.Locked, &post.Created, &post.Upvotes, &post.Downvotes, &post.Score)
 	if err == sql.ErrNoRows {
 		return nil, posts.ErrPostNotFound
 	}
 	return post, err
 }
 
+func (repo *PostRepository) FindByURL(ctx context.Context, url string, since time.Time) (postID int, err error) {
+	query := `
+	SELECT id FROM posts
+	WHERE url = $1 AND created >= $2 AND deleted IS NULL AND removed IS NULL
+	ORDER BY created DESC LIMIT 1`
+
+	err = repo.db.QueryRowContext(ctx, query, url, since).
+		Scan(&postID)
+	if err == sql.ErrNoRows {
+		err = posts.ErrPostNotFound
+	}
+	return
+}
+
+// rankings are the parts of each listing, $1 is the listing's AsOf
+func rankings(hot ...ranked) map[posts.Sort][]ranked {
+	return map[posts.Sort][]ranked{
+		posts.SortNew: {{rank: `p.id`, order: `p.id`}},
+		posts.SortTop: {{rank: `p.score`, order: `p.score`}},
+		posts.SortHot: hot,
+	}
+}
+
+// listingColumns are what a listing selects from posts p, %s is the ranking
+const listingColumns = `p.id, p.author_id, p.community_id, p.title, p.body, p.body_html,
+	COALESCE(p.url, '') AS url, COALESCE(p.domain, '') AS domain, p.locked IS NOT NULL AS locked,
+	p.created, p.upvotes, p.downvotes, p.score, (%s)::float8 AS rank`
+
+// listingFilters are the conditions posts p of a listing meet, the args are ordered as in List
+const listingFilters = `p.deleted IS NULL AND p.removed IS NULL AND p.created <= $1
+	AND ($3 = '' OR p.domain = $3)
+	AND ($4 = 0 OR p.community_id = $4)
+	AND ($5 = 0 OR (
+		NOT EXISTS (SELECT 1 FROM hidden_posts h WHERE h.user_id = $5 AND h.post_id = p.id)
+		AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $5 AND b.blocked_id = p.author_id)
+	))`
+
+func (repo *PostRepository) List(ctx context.Context, q posts.ListQuery) ([]*posts.Post, error) {
+	parts, ok := repo.rankings[q.Sort]
+	if !ok {
+		return nil, posts.ErrInvalidSort
+	}
+
+	args := []interface{}{q.AsOf, q.Limit, q.Domain, q.CommunityID, q.ViewerID}
+	if q.After != nil {
+		args = append(args, q.After.Rank, q.After.ID)
+	}
+	// Following takes the top of each followed author's posts and merges them, every author's
+	// part is a short scan of an (author_id, ...) index however many authors are followed.
+	author := ""
+	if q.Following {
+		author = `p.author_id = f.followed_id AND`
+	}
+	selects := make([]string, len(parts))
+	for i, part := range parts {
+		where := ""
+		if part.where != "" {
+			where += ` AND ` + part.where
+		}
+		if q.After != nil {
+			if part.cursor != "" {
+				where += ` AND ` + part.cursor
+			}
+			where += fmt.Sprintf(` AND ((%s)::float8, p.id) < ($6, $7)`, part.rank)
+		}
+		selects[i] = fmt.Sprintf(`(
+			SELECT %s FROM posts p
+			WHERE %s %s %s
+			ORDER BY %s DESC, p.id DESC LIMIT $2
+		)`, fmt.Sprintf(listingColumns, part.rank), author, listingFilters, where, part.order)
+	}
+	from := fmt.Sprintf(`(%s) listing`, strings.Join(selects, ` UNION ALL `))
+	if q.Following {
+		from = fmt.Sprintf(`follows f, LATERAL %s WHERE f.follower_id = $5`, from)
+	}
+	query := fmt.Sprintf(`
+	SELECT id, author_id, community_id, title, body, body_html, url, domain, locked, created, upvotes, downvotes, score, rank
+	FROM %s
+	ORDER BY rank DESC, id DESC LIMIT $2`, from)
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*posts.Post{}
+	for rows.Next() {
+		post := &posts.Post{}
+		err := rows.Scan(&post.ID, &post.AuthorID, &post.CommunityID, &post.Title, &post.Body, &post.BodyHTML, &post.URL, &post.Domain,
+			&post.Locked, &post.Created, &post.Upvotes, &post.Downvotes, &post.Score, &post.Rank)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, post)
+	}
+	return list, rows.Err()
+}
+
 func (repo *PostRepository) Edit(ctx context.Context, post *posts.Post) error {
-	stmt := `UPDATE posts SET title = $1, body = $2 WHERE id = $3 AND author_id = $4 AND deleted IS NULL;`
+	stmt := `UPDATE posts SET title = $1, body = $2, body_html = $3 WHERE id = $4 AND author_id = $5 AND deleted IS NULL;`
 
 	result, err := repo.db.Exec(stmt,
-		post.Title, post.Body, post.ID, post.AuthorID)
+		post.Title, post.Body, post.BodyHTML, post.ID, post.AuthorID)
 	affected, _ := result.RowsAffected()
 	if IsForeignKeyViolation(err) {
 		return posts.ErrPostNotFound
@@ -71,33 +243,27 @@ func (repo *PostRepository) Delete(ctx context.Context, authorID, postID int) er
 }
 
 func (repo *PostRepository) Vote(ctx context.Context, postID int, voterID int, delta int) error {
-	stmt := `
-	INSERT INTO post_votes(post_id, voter_id, delta) 
-	VALUES 
-	($1, $2, $3)
-	ON CONFLICT (voter_id, post_id) DO
-	UPDATE SET delta = $3 WHERE post_votes.post_id = $1 AND post_votes.voter_id = $2;
-	`
-
-	_, err := repo.db.ExecContext(ctx, stmt, postID, voterID, delta)
-	if IsForeignKeyViolation(err) {
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		return postCounter.vote(ctx, tx, postID, voterID, delta)
+	})
+	if err == sql.ErrNoRows {
 		return posts.ErrPostNotFound
 	}
 	return err
 }
 
 func (repo *PostRepository) Unvote(ctx context.Context, postID int, voterID int) error {
-	stmt := `DELETE FROM post_votes WHERE post_id = $1 AND voter_id = $2`
-
-	_, err := repo.db.ExecContext(ctx, stmt, postID, voterID)
-	if IsForeignKeyViolation(err) {
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		return postCounter.unvote(ctx, tx, postID, voterID)
+	})
+	if err == sql.ErrNoRows {
 		return posts.ErrPostNotFound
 	}
 	return err
 }
 
 func (repo *PostRepository) Score(ctx context.Context, postID int) (score int, err error) {
-	query := `SELECT COALESCE(SUM(delta), 0) FROM post_votes WHERE post_id = $1`
+	query := `SELECT score FROM posts WHERE id = $1 AND deleted IS NULL`
 
 	err = repo.db.QueryRowContext(ctx, query, postID).
 		Scan(&score)
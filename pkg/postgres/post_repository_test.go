@@ -3,9 +3,11 @@ package postgres
 import (
 	"context"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/ranking"
 	"github.com/godwhoa/upboat/pkg/users"
 )
 
@@ -56,11 +58,53 @@ func TestPostRepository(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(post.AuthorID, qt.Equals, userID)
 
+	// List
+	list, err := postrepo.List(ctx, posts.ListQuery{
+		Sort:  posts.SortNew,
+		AsOf:  time.Now().UTC(),
+		Limit: 10,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, postID)
+
+	// Link posts
+	linkID, err := postrepo.Create(ctx, &posts.Post{
+		AuthorID: user2ID,
+		Title:    "A link",
+		URL:      "https://example.com/a",
+		Domain:   "example.com",
+	})
+	c.Assert(err, qt.IsNil)
+	dupID, err := postrepo.Create(ctx, &posts.Post{
+		AuthorID: userID,
+		Title:    "Same link",
+		URL:      "https://example.com/a",
+		Domain:   "example.com",
+	})
+	c.Assert(err, qt.Equals, posts.ErrDuplicateURL)
+	c.Assert(dupID, qt.Equals, linkID)
+	found, err := postrepo.FindByURL(ctx, "https://example.com/a", time.Now().Add(-time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(found, qt.Equals, linkID)
+	_, err = postrepo.FindByURL(ctx, "https://example.com/b", time.Now().Add(-time.Hour))
+	c.Assert(err, qt.Equals, posts.ErrPostNotFound)
+	list, err = postrepo.List(ctx, posts.ListQuery{
+		Sort:   posts.SortNew,
+		AsOf:   time.Now().UTC(),
+		Limit:  10,
+		Domain: "example.com",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].URL, qt.Equals, "https://example.com/a")
+
 	// Update
 	err = postrepo.Edit(ctx, &posts.Post{
 		ID:       post.ID,
 		AuthorID: post.AuthorID,
-		Body:     "Updated",
+		Body:     "*Updated*",
+		BodyHTML: "<p><em>Updated</em></p>\n",
 		Title:    "Updated",
 	})
 	c.Assert(err, qt.IsNil)
@@ -69,7 +113,8 @@ func TestPostRepository(t *testing.T) {
 	upost, err := postrepo.Get(ctx, post.ID)
 	c.Assert(err, qt.IsNil)
 	c.Assert(upost.Title, qt.Equals, "Updated")
-	c.Assert(upost.Body, qt.Equals, "Updated")
+	c.Assert(upost.Body, qt.Equals, "*Updated*")
+	c.Assert(upost.BodyHTML, qt.Equals, "<p><em>Updated</em></p>\n")
 
 	// Vote
 	err = postrepo.Vote(ctx, post.ID, userID, +1)
@@ -80,7 +125,7 @@ func TestPostRepository(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 
 	// Get vote
-	votes, err := postrepo.Votes(ctx, post.ID)
+	votes, err := postrepo.Score(ctx, post.ID)
 	c.Assert(err, qt.IsNil)
 	c.Assert(votes, qt.Equals, 2)
 
@@ -90,7 +135,7 @@ func TestPostRepository(t *testing.T) {
 	err = postrepo.Unvote(ctx, post.ID, user2ID)
 	c.Assert(err, qt.IsNil)
 	// Verify
-	votes, err = postrepo.Votes(ctx, post.ID)
+	votes, err = postrepo.Score(ctx, post.ID)
 	c.Assert(err, qt.IsNil)
 	c.Assert(votes, qt.Equals, 0)
 
@@ -100,3 +145,86 @@ func TestPostRepository(t *testing.T) {
 	_, err = postrepo.Get(ctx, postID)
 	c.Assert(err, qt.Equals, posts.ErrPostNotFound)
 }
+
+func TestPostRepository_HotCursor(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewRankedPostRepository(db, ranking.Options{Algorithm: "hn", Gravity: 1.8, Window: 72 * time.Hour})
+	first, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "First", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	second, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Second", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(postrepo.Vote(ctx, first, user.ID, +1), qt.IsNil)
+
+	asOf := time.Now().UTC()
+	q := posts.ListQuery{Sort: posts.SortHot, AsOf: asOf, Limit: 1}
+	list, err := postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, first)
+
+	// stored ranks being rewritten between pages doesn't move posts across the cursor
+	err = NewRankingRepository(db).SetRanks(ctx, map[int]float64{first: 0, second: 100})
+	c.Assert(err, qt.IsNil)
+	q.After = &posts.Cursor{Sort: posts.SortHot, Rank: list[0].Rank, ID: list[0].ID, AsOf: asOf}
+	list, err = postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, second)
+
+	// posts past the window have stopped decaying, they're merged in by score
+	old, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Old", Body: "Body"})
+	c.Assert(err, qt.IsNil)
+	_, err = db.ExecContext(ctx, `UPDATE posts SET created = $2, score = 10 WHERE id = $1`, old, asOf.Add(-100*24*time.Hour))
+	c.Assert(err, qt.IsNil)
+	q = posts.ListQuery{Sort: posts.SortHot, AsOf: asOf, Limit: 3}
+	list, err = postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 3)
+	c.Assert([]int{list[0].ID, list[1].ID, list[2].ID}, qt.DeepEquals, []int{first, old, second})
+	q.Limit = 1
+	q.After = &posts.Cursor{Sort: posts.SortHot, Rank: list[1].Rank, ID: old, AsOf: asOf}
+	list, err = postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, second)
+}
+
+// Bodies from before Markdown were stored escaped, they get unescaped and rendered once
+func TestRenderLegacy(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	postID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Old", Body: "placeholder"})
+	c.Assert(err, qt.IsNil)
+	_, err = db.ExecContext(ctx, `UPDATE posts SET body = 'fish &amp; chips &lt;3', body_html = '', legacy = true WHERE id = $1`, postID)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(renderLegacy(ctx, db), qt.IsNil)
+	post, err := postrepo.Get(ctx, postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(post.Body, qt.Equals, "fish & chips <3")
+	c.Assert(post.BodyHTML, qt.Equals, "<p>fish &amp; chips &lt;3</p>\n")
+}
@@ -1,17 +1,33 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"html"
 
+	"github.com/basvanbeek/ocsql"
+	"github.com/godwhoa/upboat/pkg/apitokens"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/curation"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/markdown"
+	"github.com/godwhoa/upboat/pkg/moderation"
+	"github.com/godwhoa/upboat/pkg/notifications"
 	"github.com/godwhoa/upboat/pkg/postgres/migrations"
 	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/privacy"
+	"github.com/godwhoa/upboat/pkg/profiles"
+	"github.com/godwhoa/upboat/pkg/ranking"
+	"github.com/godwhoa/upboat/pkg/reports"
+	"github.com/godwhoa/upboat/pkg/search"
 	"github.com/godwhoa/upboat/pkg/users"
 	"github.com/golang-migrate/migrate"
 	"github.com/golang-migrate/migrate/database/postgres"
 	"github.com/golang-migrate/migrate/source/go_bindata"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	"github.com/basvanbeek/ocsql"
 )
 
 // Options holds information for connecting to a postgres instance
@@ -30,8 +46,8 @@ func (o Options) ConnectionInfo() string {
 	return fmt.Sprintf("host='%s' port='%d' user='%s' password='%s' dbname='%s' sslmode='%s' statement_timeout=%d", o.Host, o.Port, o.User, o.Pass, o.DBName, o.SSLMode, o.StatementTimeout)
 }
 
-// NewFromOptions will connect to a postgresql server with given options
-func NewFromOptions(options Options) (*Repositories, error) {
+// NewFromOptions will connect to a postgresql server with given options, hot listings rank posts as rankingOpts does
+func NewFromOptions(options Options, rankingOpts ranking.Options) (*Repositories, error) {
 	driverName, err := ocsql.Register("postgres", ocsql.WithAllTraceOptions())
 	if err != nil {
 		return nil, err
@@ -41,28 +57,68 @@ func NewFromOptions(options Options) (*Repositories, error) {
 	if err != nil {
 		return nil, err
 	}
-	return New(db)
+	return New(db, rankingOpts)
 }
 
 // Repositories is a container for multiple setup repositories (eg. User, Posts etc.)
 type Repositories struct {
-	UserRepo users.Repository
-	PostRepo posts.Repository
+	// DB is the connection the repositories share, for maintenance tasks
+	DB *sql.DB
+
+	UserRepo         users.Repository
+	PostRepo         posts.Repository
+	CommentRepo      comments.Repository
+	RankingRepo      ranking.Repository
+	SearchRepo       search.Repository
+	CommunityRepo    communities.Repository
+	ModerationRepo   moderation.Repository
+	ReportRepo       reports.Repository
+	ProfileRepo      profiles.Repository
+	PrivacyRepo      privacy.Repository
+	APITokenRepo     apitokens.Repository
+	NotificationRepo notifications.Repository
+	CurationRepo     curation.Repository
 }
 
 // New runs migrations and returns wired-up Repositories
-func New(db *sql.DB) (*Repositories, error) {
+func New(db *sql.DB, rankingOpts ranking.Options) (*Repositories, error) {
 	if err := Migrate(db); err != nil {
 		return nil, err
 	}
+	if err := renderLegacy(context.Background(), db); err != nil {
+		return nil, err
+	}
 	return &Repositories{
-		UserRepo: NewUserRepository(db),
-		PostRepo: NewPostRepository(db),
+		DB:               db,
+		UserRepo:         NewUserRepository(db),
+		PostRepo:         NewRankedPostRepository(db, rankingOpts),
+		CommentRepo:      NewCommentRepository(db),
+		RankingRepo:      NewRankingRepository(db),
+		SearchRepo:       NewSearchRepository(db),
+		CommunityRepo:    NewCommunityRepository(db),
+		ModerationRepo:   NewModerationRepository(db),
+		ReportRepo:       NewReportRepository(db),
+		ProfileRepo:      NewProfileRepository(db),
+		PrivacyRepo:      NewPrivacyRepository(db),
+		APITokenRepo:     NewAPITokenRepository(db),
+		NotificationRepo: NewNotificationRepository(db),
+		CurationRepo:     NewCurationRepository(db),
 	}, nil
 }
 
+// minServerVersion is the oldest Postgres the migrations run on, search vectors are generated columns which came in 12
+const minServerVersion = 120000
+
 // Migrate runs migrations on the database
 func Migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`SHOW server_version_num`).Scan(&version); err != nil {
+		return err
+	}
+	if version < minServerVersion {
+		return fmt.Errorf("postgres %d is too old, upboat needs 12 or newer", version)
+	}
+
 	driver, err := postgres.WithInstance(db, &postgres.Config{MigrationsTable: "migrations", DatabaseName: "upboat"})
 	if err != nil {
 		return err
@@ -89,6 +145,65 @@ func Migrate(db *sql.DB) error {
 	return nil
 }
 
+// renderLegacy renders the bodies of posts and comments written before Markdown.
+// Those were stored escaped by a strict sanitizer, so they're unescaped back to what the author typed first.
+func renderLegacy(ctx context.Context, db *sql.DB) error {
+	dbx := sqlx.NewDb(db, "postgres")
+	for _, table := range []string{"posts", "comments"} {
+		query := fmt.Sprintf(`SELECT id, body FROM %s WHERE legacy ORDER BY id LIMIT 500 FOR UPDATE`, table)
+		stmt := fmt.Sprintf(`UPDATE %s SET body = $2, body_html = $3, legacy = false WHERE id = $1`, table)
+		for {
+			rendered := 0
+			err := transact(ctx, dbx, func(tx *sqlx.Tx) error {
+				rows := []struct {
+					ID   int    `db:"id"`
+					Body string `db:"body"`
+				}{}
+				if err := tx.SelectContext(ctx, &rows, query); err != nil {
+					return err
+				}
+				for _, row := range rows {
+					body := html.UnescapeString(row.Body)
+					if _, err := tx.ExecContext(ctx, stmt, row.ID, body, markdown.Render(body)); err != nil {
+						return err
+					}
+				}
+				rendered = len(rows)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if rendered == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// transact runs fn in a transaction, committing if it returns nil and rolling back otherwise
+func transact(ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// internal wraps err as an errors.Internal, unless it's nil or already an application error
+// such as the ErrNotFound a transaction returned
+func internal(op errors.Op, err error) error {
+	if _, ok := err.(*errors.Error); err == nil || ok {
+		return err
+	}
+	return errors.E(errors.Internal, op, err)
+}
+
 // IsUniqueKeyViolation checks if an error was caused by an unique key violation
 func IsUniqueKeyViolation(err error) bool {
 	pqerr, ok := err.(*pq.Error)
@@ -106,3 +221,11 @@ func IsForeignKeyViolation(err error) bool {
 	}
 	return pqerr.Code.Name() == "foreign_key_violation"
 }
+
+// violatedForeignKey is the name of the foreign key constraint an error was caused by, empty for other errors
+func violatedForeignKey(err error) string {
+	if !IsForeignKeyViolation(err) {
+		return ""
+	}
+	return err.(*pq.Error).Constraint
+}
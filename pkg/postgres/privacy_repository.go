@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/privacy"
+	"github.com/jmoiron/sqlx"
+)
+
+// deletedUID is the uid of the [deleted] user, erased users' posts and comments are handed over to it
+const deletedUID = "00000000-0000-0000-0000-000000000000"
+
+// PrivacyRepository implements `privacy.Repository` interface
+type PrivacyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPrivacyRepository is a constructor
+func NewPrivacyRepository(db *sql.DB) privacy.Repository {
+	return &PrivacyRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *PrivacyRepository) Export(ctx context.Context, userID int) (*privacy.Archive, error) {
+	op := errors.Op("privacy.Repository.Export")
+	archive := &privacy.Archive{
+		Exported:     time.Now().UTC(),
+		Posts:        []*privacy.Post{},
+		Comments:     []*privacy.Comment{},
+		PostVotes:    []*privacy.PostVote{},
+		CommentVotes: []*privacy.CommentVote{},
+	}
+
+	// a snapshot so the parts of the archive agree with each other
+	tx, err := repo.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer tx.Rollback()
+
+	account := `
+	SELECT id, username, email, email_verified_at IS NOT NULL, created
+	FROM users WHERE id = $1 AND uid <> $2`
+	a := &archive.Account
+	err = tx.QueryRowContext(ctx, account, userID, deletedUID).
+		Scan(&a.ID, &a.Username, &a.Email, &a.Verified, &a.Joined)
+	if err == sql.ErrNoRows {
+		return nil, privacy.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+
+	err = each(ctx, tx, `
+	SELECT id, community_id, title, body, COALESCE(url, ''), created, deleted IS NOT NULL, removed IS NOT NULL
+	FROM posts WHERE author_id = $1 ORDER BY id`, userID, func(rows *sql.Rows) error {
+		p := &privacy.Post{}
+		archive.Posts = append(archive.Posts, p)
+		return rows.Scan(&p.ID, &p.CommunityID, &p.Title, &p.Body, &p.URL, &p.Created, &p.Deleted, &p.Removed)
+	})
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+
+	err = each(ctx, tx, `
+	SELECT id, post_id, parent_id, body, created, deleted IS NOT NULL, removed IS NOT NULL
+	FROM comments WHERE commenter_id = $1 ORDER BY id`, userID, func(rows *sql.Rows) error {
+		c := &privacy.Comment{}
+		archive.Comments = append(archive.Comments, c)
+		return rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.Body, &c.Created, &c.Deleted, &c.Removed)
+	})
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+
+	err = each(ctx, tx, `SELECT post_id, delta FROM post_votes WHERE voter_id = $1 ORDER BY id`, userID,
+		func(rows *sql.Rows) error {
+			v := &privacy.PostVote{}
+			archive.PostVotes = append(archive.PostVotes, v)
+			return rows.Scan(&v.PostID, &v.Delta)
+		})
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+
+	err = each(ctx, tx, `SELECT comment_id, delta FROM comment_votes WHERE voter_id = $1 ORDER BY id`, userID,
+		func(rows *sql.Rows) error {
+			v := &privacy.CommentVote{}
+			archive.CommentVotes = append(archive.CommentVotes, v)
+			return rows.Scan(&v.CommentID, &v.Delta)
+		})
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return archive, nil
+}
+
+// each runs a query and calls fn for every row
+func each(ctx context.Context, tx *sqlx.Tx, query string, arg interface{}, fn func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// handovers give an erased user's content to the [deleted] user, $1 is the user and $2 the [deleted] user
+var handovers = []string{
+	`UPDATE posts SET author_id = $2 WHERE author_id = $1`,
+	`UPDATE comments SET commenter_id = $2 WHERE commenter_id = $1`,
+	`UPDATE notifications SET actor_id = $2 WHERE actor_id = $1`,
+}
+
+// erasures remove everything else tied to the user $1 and scrub their account last.
+// Reports and the moderation log keep pointing at the scrubbed account.
+var erasures = []string{
+	`WITH gone AS (
+		DELETE FROM community_members WHERE user_id = $1 RETURNING community_id
+	)
+	UPDATE communities c SET members = members - 1 FROM gone WHERE c.id = gone.community_id`,
+	`DELETE FROM moderators WHERE user_id = $1`,
+	`DELETE FROM user_tokens WHERE user_id = $1`,
+	`DELETE FROM api_tokens WHERE user_id = $1`,
+	`DELETE FROM login_failures WHERE key = 'user:' || $1`,
+	`DELETE FROM recovery_codes WHERE user_id = $1`,
+	`DELETE FROM notifications WHERE user_id = $1`,
+	`DELETE FROM notification_mutes WHERE user_id = $1`,
+	`DELETE FROM saved_posts WHERE user_id = $1`,
+	`DELETE FROM saved_comments WHERE user_id = $1`,
+	`DELETE FROM hidden_posts WHERE user_id = $1`,
+	`DELETE FROM blocks WHERE blocker_id = $1 OR blocked_id = $1`,
+	`DELETE FROM follows WHERE follower_id = $1 OR followed_id = $1`,
+	`UPDATE users SET
+		username = '[deleted-' || id || ']',
+		email = 'deleted-' || id || '@invalid',
+		hash = '',
+		email_verified_at = NULL,
+		totp_secret = NULL,
+		totp_enabled_at = NULL,
+		deleted = COALESCE(deleted, now()),
+		session_epoch = session_epoch + 1
+	WHERE id = $1`,
+}
+
+func (repo *PrivacyRepository) Erase(ctx context.Context, userID int) error {
+	op := errors.Op("privacy.Repository.Erase")
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		lock := `SELECT id FROM users WHERE id = $1 AND uid <> $2 FOR UPDATE`
+		if err := tx.QueryRowContext(ctx, lock, userID, deletedUID).Scan(&userID); err != nil {
+			return err
+		}
+		var deletedID int
+		err := tx.QueryRowContext(ctx, `SELECT id FROM users WHERE uid = $1`, deletedUID).Scan(&deletedID)
+		if err != nil {
+			return err
+		}
+
+		if err := postCounter.retract(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err := commentCounter.retract(ctx, tx, userID); err != nil {
+			return err
+		}
+		for _, stmt := range handovers {
+			if _, err := tx.ExecContext(ctx, stmt, userID, deletedID); err != nil {
+				return err
+			}
+		}
+		for _, stmt := range erasures {
+			if _, err := tx.ExecContext(ctx, stmt, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == sql.ErrNoRows {
+		return privacy.ErrUserNotFound
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
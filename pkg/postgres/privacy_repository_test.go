@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/communities"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/privacy"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestPrivacyRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	communityrepo := NewCommunityRepository(db)
+	communityID, err := communityrepo.Create(ctx, &communities.Community{Name: "golang", CreatorID: user2.ID})
+	c.Assert(err, qt.IsNil)
+	err = communityrepo.Join(ctx, communityID, user.ID)
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	postID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Mine", Body: "All mine"})
+	c.Assert(err, qt.IsNil)
+	otherID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user2.ID, Title: "Theirs", Body: "Not mine"})
+	c.Assert(err, qt.IsNil)
+	err = postrepo.Vote(ctx, otherID, user.ID, +1)
+	c.Assert(err, qt.IsNil)
+
+	commentrepo := NewCommentRepository(db)
+	commentID, err := commentrepo.Create(ctx, &comments.Comment{PostID: otherID, CommenterID: user.ID, Body: "Nice"})
+	c.Assert(err, qt.IsNil)
+
+	repo := NewPrivacyRepository(db)
+
+	// Export
+	archive, err := repo.Export(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(archive.Account.Email, qt.Equals, "pac@pac.com")
+	c.Assert(archive.Posts, qt.HasLen, 1)
+	c.Assert(archive.Posts[0].ID, qt.Equals, postID)
+	c.Assert(archive.Comments, qt.HasLen, 1)
+	c.Assert(archive.Comments[0].ID, qt.Equals, commentID)
+	c.Assert(archive.PostVotes, qt.DeepEquals, []*privacy.PostVote{{PostID: otherID, Delta: 1}})
+	_, err = repo.Export(ctx, 4242)
+	c.Assert(err, qt.Equals, privacy.ErrUserNotFound)
+
+	// Erase
+	err = repo.Erase(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	score, err := postrepo.Score(ctx, otherID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(score, qt.Equals, 0)
+	post, err := postrepo.Get(ctx, postID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(post.AuthorID, qt.Not(qt.Equals), user.ID)
+	community, err := communityrepo.Get(ctx, communityID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(community.Members, qt.Equals, 1)
+	_, err = userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.Equals, users.ErrUserNotFound)
+	erased, err := repo.Export(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(erased.Posts, qt.HasLen, 0)
+	c.Assert(erased.Comments, qt.HasLen, 0)
+	c.Assert(erased.PostVotes, qt.HasLen, 0)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/profiles"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProfileRepository implements `profiles.Repository` interface
+type ProfileRepository struct {
+	db *sqlx.DB
+}
+
+// NewProfileRepository is a constructor
+func NewProfileRepository(db *sql.DB) profiles.Repository {
+	return &ProfileRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *ProfileRepository) Profile(ctx context.Context, username string) (*profiles.Profile, error) {
+	op := errors.Op("profiles.Repository.Profile")
+	// karma leaves out votes users gave themselves
+	query := `
+	SELECT u.id, u.username, u.created,
+		(SELECT COALESCE(SUM(v.delta), 0) FROM posts p
+		JOIN post_votes v ON v.post_id = p.id
+		WHERE p.author_id = u.id AND v.voter_id <> u.id),
+		(SELECT COALESCE(SUM(v.delta), 0) FROM comments c
+		JOIN comment_votes v ON v.comment_id = c.id
+		WHERE c.commenter_id = u.id AND v.voter_id <> u.id),
+		(SELECT COUNT(*) FROM follows WHERE followed_id = u.id),
+		(SELECT COUNT(*) FROM follows WHERE follower_id = u.id)
+	FROM users u WHERE u.username = $1 AND u.deleted IS NULL`
+
+	p := &profiles.Profile{}
+	err := repo.db.QueryRowContext(ctx, query, username).
+		Scan(&p.ID, &p.Username, &p.Joined, &p.PostKarma, &p.CommentKarma, &p.Followers, &p.Following)
+	if err == sql.ErrNoRows {
+		return nil, profiles.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return p, nil
+}
+
+func (repo *ProfileRepository) Posts(ctx context.Context, userID int, page profiles.Page) ([]*posts.Post, error) {
+	op := errors.Op("profiles.Repository.Posts")
+	query := `
+	SELECT id, author_id, community_id, title, body, body_html, COALESCE(url, ''), COALESCE(domain, ''),
+		locked IS NOT NULL, created, upvotes, downvotes, score
+	FROM posts
+	WHERE author_id = $1 AND deleted IS NULL AND removed IS NULL AND ($2 = 0 OR id < $2)
+		AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $4 AND b.blocked_id = author_id)
+	ORDER BY id DESC LIMIT $3`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID, page.Before, page.Limit, page.ViewerID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	list := []*posts.Post{}
+	for rows.Next() {
+		post := &posts.Post{}
+		err := rows.Scan(&post.ID, &post.AuthorID, &post.CommunityID, &post.Title, &post.Body, &post.BodyHTML, &post.URL, &post.Domain,
+			&post.Locked, &post.Created, &post.Upvotes, &post.Downvotes, &post.Score)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		list = append(list, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return list, nil
+}
+
+func (repo *ProfileRepository) Comments(ctx context.Context, userID int, page profiles.Page) ([]*comments.Comment, error) {
+	op := errors.Op("profiles.Repository.Comments")
+	query := `
+	SELECT id, post_id, parent_id, commenter_id, body, body_html, depth, upvotes, downvotes, score
+	FROM comments
+	WHERE commenter_id = $1 AND deleted IS NULL AND removed IS NULL AND ($2 = 0 OR id < $2)
+		AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $4 AND b.blocked_id = commenter_id)
+	ORDER BY id DESC LIMIT $3`
+
+	c := []*comments.Comment{}
+	err := repo.db.SelectContext(ctx, &c, query, userID, page.Before, page.Limit, page.ViewerID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return c, nil
+}
+
+func (repo *ProfileRepository) Follow(ctx context.Context, userID, followedID int) error {
+	op := errors.Op("profiles.Repository.Follow")
+	stmt := `INSERT INTO follows(follower_id, followed_id) VALUES($1, $2) ON CONFLICT DO NOTHING`
+
+	_, err := repo.db.ExecContext(ctx, stmt, userID, followedID)
+	if IsForeignKeyViolation(err) {
+		return profiles.ErrUserNotFound
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+func (repo *ProfileRepository) Unfollow(ctx context.Context, userID, followedID int) error {
+	op := errors.Op("profiles.Repository.Unfollow")
+	stmt := `DELETE FROM follows WHERE follower_id = $1 AND followed_id = $2`
+
+	_, err := repo.db.ExecContext(ctx, stmt, userID, followedID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/profiles"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestProfileRepository_Follow(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	ids := map[string]int{}
+	for _, name := range []string{"pacninja", "lala", "someone"} {
+		err = userrepo.Create(ctx, &users.User{Username: name, Email: name + "@mail.com", Hash: "bcrypt_hash"})
+		c.Assert(err, qt.IsNil)
+		user, err := userrepo.FindByEmail(ctx, name+"@mail.com")
+		c.Assert(err, qt.IsNil)
+		ids[name] = user.ID
+	}
+
+	postrepo := NewPostRepository(db)
+	for i := 0; i < 3; i++ {
+		for _, name := range []string{"lala", "someone", "pacninja"} {
+			_, err := postrepo.Create(ctx, &posts.Post{AuthorID: ids[name], Title: "By " + name, Body: "Body"})
+			c.Assert(err, qt.IsNil)
+		}
+	}
+
+	repo := NewProfileRepository(db)
+
+	// Follow
+	c.Assert(repo.Follow(ctx, ids["pacninja"], ids["lala"]), qt.IsNil)
+	c.Assert(repo.Follow(ctx, ids["pacninja"], ids["lala"]), qt.IsNil)
+	c.Assert(repo.Follow(ctx, ids["someone"], ids["lala"]), qt.IsNil)
+	c.Assert(repo.Follow(ctx, ids["pacninja"], 4242), qt.Equals, profiles.ErrUserNotFound)
+
+	profile, err := repo.Profile(ctx, "lala")
+	c.Assert(err, qt.IsNil)
+	c.Assert(profile.Followers, qt.Equals, 2)
+	c.Assert(profile.Following, qt.Equals, 0)
+	profile, err = repo.Profile(ctx, "pacninja")
+	c.Assert(err, qt.IsNil)
+	c.Assert(profile.Followers, qt.Equals, 0)
+	c.Assert(profile.Following, qt.Equals, 1)
+
+	// Following feed pages through posts by followed users only
+	asOf := time.Now().UTC()
+	q := posts.ListQuery{Sort: posts.SortNew, AsOf: asOf, Limit: 2, ViewerID: ids["pacninja"], Following: true}
+	list, err := postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 2)
+	last := list[1]
+	q.After = &posts.Cursor{Sort: posts.SortNew, Rank: last.Rank, ID: last.ID, AsOf: asOf}
+	rest, err := postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rest, qt.HasLen, 1)
+	for _, post := range append(list, rest...) {
+		c.Assert(post.AuthorID, qt.Equals, ids["lala"])
+	}
+	c.Assert(list[0].ID > list[1].ID && list[1].ID > rest[0].ID, qt.Equals, true)
+
+	// Unfollow
+	c.Assert(repo.Unfollow(ctx, ids["pacninja"], ids["lala"]), qt.IsNil)
+	q.After = nil
+	list, err = postrepo.List(ctx, q)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 0)
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/ranking"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// RankingRepository implements `ranking.Repository` interface
+type RankingRepository struct {
+	db *sqlx.DB
+}
+
+// NewRankingRepository is a constructor
+func NewRankingRepository(db *sql.DB) ranking.Repository {
+	return &RankingRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *RankingRepository) Entry(ctx context.Context, postID int) (*ranking.Entry, error) {
+	op := errors.Op("ranking.Repository.Entry")
+	query := `SELECT id, created, score FROM posts WHERE id = $1 AND deleted IS NULL`
+
+	e := &ranking.Entry{}
+	err := repo.db.QueryRowContext(ctx, query, postID).
+		Scan(&e.PostID, &e.Created, &e.Score)
+	if err == sql.ErrNoRows {
+		return nil, ranking.ErrPostNotFound
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return e, nil
+}
+
+func (repo *RankingRepository) Stale(ctx context.Context, now time.Time, window time.Duration) ([]*ranking.Entry, error) {
+	op := errors.Op("ranking.Repository.Stale")
+	// posts are re-ranked until they're ranked once after leaving the window,
+	// so they don't keep a stale rank from when they were younger
+	query := `
+	SELECT p.id, p.created, p.score
+	FROM posts p
+	WHERE p.deleted IS NULL AND p.removed IS NULL AND (
+		p.created >= $1::timestamp - make_interval(secs => $2) OR
+		p.ranked < p.created + make_interval(secs => $2)
+	)`
+
+	rows, err := repo.db.QueryContext(ctx, query, now, window.Seconds())
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	entries := []*ranking.Entry{}
+	for rows.Next() {
+		e := &ranking.Entry{}
+		if err := rows.Scan(&e.PostID, &e.Created, &e.Score); err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return entries, nil
+}
+
+func (repo *RankingRepository) SetRanks(ctx context.Context, ranks map[int]float64) error {
+	op := errors.Op("ranking.Repository.SetRanks")
+	stmt := `
+	UPDATE posts SET rank = r.rank, ranked = now()
+	FROM unnest($1::integer[], $2::float8[]) AS r(id, rank)
+	WHERE posts.id = r.id`
+
+	ids := make([]int64, 0, len(ranks))
+	values := make([]float64, 0, len(ranks))
+	for id, rank := range ranks {
+		ids = append(ids, int64(id))
+		values = append(values, rank)
+	}
+	_, err := repo.db.ExecContext(ctx, stmt, pq.Array(ids), pq.Array(values))
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
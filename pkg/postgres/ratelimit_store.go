@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/ratelimit"
+	"github.com/jmoiron/sqlx"
+)
+
+// RateLimitStore implements `ratelimit.Store` interface, it's shared by every instance using the database
+type RateLimitStore struct {
+	db *sqlx.DB
+}
+
+// NewRateLimitStore is a constructor
+func NewRateLimitStore(db *sql.DB) ratelimit.Store {
+	return &RateLimitStore{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+// Take locks key's bucket so instances take tokens from it one at a time
+func (s *RateLimitStore) Take(ctx context.Context, key string, policy ratelimit.Policy, now time.Time) (wait time.Duration, ok bool, err error) {
+	now = now.UTC()
+	err = transact(ctx, s.db, func(tx *sqlx.Tx) error {
+		insert := `INSERT INTO rate_limits(key, tokens, updated) VALUES($1, $2, $3) ON CONFLICT (key) DO NOTHING`
+		if _, err := tx.ExecContext(ctx, insert, key, policy.Burst, now); err != nil {
+			return err
+		}
+
+		var tokens float64
+		var updated time.Time
+		lock := `SELECT tokens, updated FROM rate_limits WHERE key = $1 FOR UPDATE`
+		if err := tx.QueryRowContext(ctx, lock, key).Scan(&tokens, &updated); err != nil {
+			return err
+		}
+
+		tokens, wait, ok = policy.Take(tokens, updated, now)
+		_, err := tx.ExecContext(ctx, `UPDATE rate_limits SET tokens = $2, updated = $3 WHERE key = $1`, key, tokens, now)
+		return err
+	})
+	return
+}
+
+func (s *RateLimitStore) Sweep(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM rate_limits WHERE updated < $1`, before.UTC())
+	return err
+}
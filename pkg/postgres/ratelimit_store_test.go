@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/ratelimit"
+)
+
+func TestRateLimitStore(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	store := NewRateLimitStore(db)
+	policy := ratelimit.Policy{Burst: 2, Every: time.Minute}
+	now := time.Now().Truncate(time.Microsecond)
+
+	_, ok, err := store.Take(ctx, "vote:user:1", policy, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, true)
+	_, ok, err = store.Take(ctx, "vote:user:1", policy, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, true)
+	wait, ok, err := store.Take(ctx, "vote:user:1", policy, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, false)
+	c.Assert(wait, qt.Equals, time.Minute)
+	_, ok, err = store.Take(ctx, "vote:user:1", policy, now.Add(time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, true)
+
+	// Sweep
+	err = store.Sweep(ctx, now.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	_, ok, err = store.Take(ctx, "vote:user:1", policy, now.Add(time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.Equals, true)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/reports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ReportRepository implements `reports.Repository` interface
+type ReportRepository struct {
+	db *sqlx.DB
+}
+
+// NewReportRepository is a constructor
+func NewReportRepository(db *sql.DB) reports.Repository {
+	return &ReportRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *ReportRepository) Create(ctx context.Context, report *reports.Report) (id int, err error) {
+	op := errors.Op("reports.Repository.Create")
+	// the target's community is copied over so the queue can be filtered by it
+	stmt := `
+	INSERT INTO reports(reporter_id, kind, target_id, community_id, reason, details)
+	SELECT $1, 'post', p.id, p.community_id, $3, $4 FROM posts p
+	WHERE p.id = $2 AND p.deleted IS NULL AND p.removed IS NULL
+	RETURNING id, created`
+	notFound := reports.ErrPostNotFound
+	if report.Kind == reports.KindComment {
+		stmt = `
+		INSERT INTO reports(reporter_id, kind, target_id, community_id, reason, details)
+		SELECT $1, 'comment', c.id, p.community_id, $3, $4 FROM comments c
+		JOIN posts p ON p.id = c.post_id
+		WHERE c.id = $2 AND c.deleted IS NULL AND c.removed IS NULL
+		RETURNING id, created`
+		notFound = reports.ErrCommentNotFound
+	}
+
+	err = repo.db.QueryRowContext(ctx, stmt,
+		report.ReporterID, report.TargetID, report.Reason, report.Details).
+		Scan(&id, &report.Created)
+	if err == sql.ErrNoRows {
+		return 0, notFound
+	}
+	if IsUniqueKeyViolation(err) {
+		return 0, reports.ErrAlreadyReported
+	}
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return id, nil
+}
+
+func (repo *ReportRepository) Queue(ctx context.Context, q reports.QueueQuery) ([]*reports.Item, error) {
+	op := errors.Op("reports.Repository.Queue")
+	query := `
+	SELECT kind, target_id, community_id, COUNT(*) AS reports,
+		array_agg(DISTINCT reason) AS reasons, MAX(created) AS latest
+	FROM reports
+	WHERE status = 'open' AND ($1 = 0 OR community_id = $1)
+	GROUP BY kind, target_id, community_id
+	ORDER BY reports DESC, latest DESC, kind, target_id
+	OFFSET $2 LIMIT $3`
+
+	rows, err := repo.db.QueryContext(ctx, query, q.CommunityID, q.Offset, q.Limit)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	items := []*reports.Item{}
+	for rows.Next() {
+		item := &reports.Item{}
+		var reasons []string
+		err := rows.Scan(&item.Kind, &item.TargetID, &item.CommunityID, &item.Reports,
+			pq.Array(&reasons), &item.Latest)
+		if err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		for _, reason := range reasons {
+			item.Reasons = append(item.Reasons, reports.Reason(reason))
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return items, nil
+}
+
+func (repo *ReportRepository) Community(ctx context.Context, kind reports.Kind, targetID int) (communityID *int, err error) {
+	op := errors.Op("reports.Repository.Community")
+	query := `SELECT community_id FROM reports WHERE kind = $1 AND target_id = $2 AND status = 'open' LIMIT 1`
+
+	err = repo.db.QueryRowContext(ctx, query, kind, targetID).
+		Scan(&communityID)
+	if err == sql.ErrNoRows {
+		return nil, reports.ErrNoOpenReports
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return communityID, nil
+}
+
+func (repo *ReportRepository) Close(ctx context.Context, kind reports.Kind, targetID int, status reports.Status, moderatorID int) error {
+	op := errors.Op("reports.Repository.Close")
+	stmt := `
+	UPDATE reports SET status = $3, closed_by = $4, closed = now()
+	WHERE kind = $1 AND target_id = $2 AND status = 'open'`
+
+	result, err := repo.db.ExecContext(ctx, stmt, kind, targetID, status, moderatorID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return reports.ErrNoOpenReports
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/posts"
+	"github.com/godwhoa/upboat/pkg/reports"
+	"github.com/godwhoa/upboat/pkg/users"
+)
+
+func TestReportRepository(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db, purge, err := setupDB()
+	c.Assert(err, qt.IsNil)
+	defer purge()
+
+	userrepo := NewUserRepository(db)
+	err = userrepo.Create(ctx, &users.User{Username: "pacninja", Email: "pac@pac.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user, err := userrepo.FindByEmail(ctx, "pac@pac.com")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Create(ctx, &users.User{Username: "lala", Email: "lala@lala.com", Hash: "bcrypt_hash"})
+	c.Assert(err, qt.IsNil)
+	user2, err := userrepo.FindByEmail(ctx, "lala@lala.com")
+	c.Assert(err, qt.IsNil)
+
+	postrepo := NewPostRepository(db)
+	spamID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Spam", Body: "Buy now"})
+	c.Assert(err, qt.IsNil)
+	rudeID, err := postrepo.Create(ctx, &posts.Post{AuthorID: user.ID, Title: "Rude", Body: "You all suck"})
+	c.Assert(err, qt.IsNil)
+
+	repo := NewReportRepository(db)
+
+	// Create
+	_, err = repo.Create(ctx, &reports.Report{ReporterID: user2.ID, Kind: reports.KindPost, TargetID: spamID, Reason: reports.Spam})
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Create(ctx, &reports.Report{ReporterID: user2.ID, Kind: reports.KindPost, TargetID: spamID, Reason: reports.Other})
+	c.Assert(err, qt.Equals, reports.ErrAlreadyReported)
+	_, err = repo.Create(ctx, &reports.Report{ReporterID: user.ID, Kind: reports.KindPost, TargetID: spamID, Reason: reports.Other})
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Create(ctx, &reports.Report{ReporterID: user2.ID, Kind: reports.KindPost, TargetID: rudeID, Reason: reports.Harassment})
+	c.Assert(err, qt.IsNil)
+	_, err = repo.Create(ctx, &reports.Report{ReporterID: user2.ID, Kind: reports.KindComment, TargetID: 4242, Reason: reports.Spam})
+	c.Assert(err, qt.Equals, reports.ErrCommentNotFound)
+
+	// Queue is ordered by report count
+	items, err := repo.Queue(ctx, reports.QueueQuery{Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(items, qt.HasLen, 2)
+	c.Assert(items[0].TargetID, qt.Equals, spamID)
+	c.Assert(items[0].Reports, qt.Equals, 2)
+	c.Assert(items[0].Reasons, qt.DeepEquals, []reports.Reason{reports.Other, reports.Spam})
+
+	// Close
+	err = repo.Close(ctx, reports.KindPost, spamID, reports.Resolved, user.ID)
+	c.Assert(err, qt.IsNil)
+	err = repo.Close(ctx, reports.KindPost, spamID, reports.Dismissed, user.ID)
+	c.Assert(err, qt.Equals, reports.ErrNoOpenReports)
+	_, err = repo.Community(ctx, reports.KindPost, spamID)
+	c.Assert(err, qt.Equals, reports.ErrNoOpenReports)
+	items, err = repo.Queue(ctx, reports.QueueQuery{Limit: 10})
+	c.Assert(err, qt.IsNil)
+	c.Assert(items, qt.HasLen, 1)
+}
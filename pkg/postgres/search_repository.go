@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/search"
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchRepository implements `search.Repository` interface
+type SearchRepository struct {
+	db *sqlx.DB
+}
+
+// NewSearchRepository is a constructor
+func NewSearchRepository(db *sql.DB) search.Repository {
+	return &SearchRepository{
+		db: sqlx.NewDb(db, "postgres"),
+	}
+}
+
+func (repo *SearchRepository) Search(ctx context.Context, q search.Query) ([]*search.Result, error) {
+	op := errors.Op("search.Repository.Search")
+	// matches are paged before highlighting since ts_headline is costly,
+	// comments on deleted or removed posts are left out along with deleted or removed comments,
+	// and so is anything by users the viewer blocked
+	query := `
+	WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query),
+	matches AS (
+		SELECT 'post' AS type, p.id, p.id AS post_id, p.title, p.body, p.created,
+			ts_rank(p.search, q.query) AS rank
+		FROM posts p, q
+		WHERE ($2 = '' OR $2 = 'post') AND p.deleted IS NULL AND p.removed IS NULL AND p.search @@ q.query
+			AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $5 AND b.blocked_id = p.author_id)
+		UNION ALL
+		SELECT 'comment' AS type, c.id, c.post_id, p.title, c.body, c.created,
+			ts_rank(c.search, q.query) AS rank
+		FROM comments c
+		JOIN posts p ON p.id = c.post_id, q
+		WHERE ($2 = '' OR $2 = 'comment') AND c.deleted IS NULL AND c.removed IS NULL
+			AND p.deleted IS NULL AND p.removed IS NULL AND c.search @@ q.query
+			AND NOT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = $5 AND b.blocked_id = c.commenter_id)
+		ORDER BY rank DESC, created DESC, type, id DESC
+		OFFSET $3 LIMIT $4
+	)
+	SELECT m.type, m.id, m.post_id, m.title, m.rank, m.created,
+		ts_headline('english', m.body, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2')
+	FROM matches m, q
+	ORDER BY m.rank DESC, m.created DESC, m.type, m.id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, q.Text, q.Type, q.Offset, q.Limit, q.ViewerID)
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	defer rows.Close()
+
+	results := []*search.Result{}
+	for rows.Next() {
+		r := &search.Result{}
+		if err := rows.Scan(&r.Type, &r.ID, &r.PostID, &r.Title, &r.Rank, &r.Created, &r.Snippet); err != nil {
+			return nil, errors.E(errors.Internal, op, err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return results, nil
+}
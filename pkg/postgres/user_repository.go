@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/godwhoa/upboat/pkg/errors"
 	"github.com/godwhoa/upboat/pkg/users"
@@ -42,11 +43,15 @@ func (repo *UserRepository) Create(ctx context.Context, user *users.User) error
 // Find finds an user by id
 func (repo *UserRepository) Find(ctx context.Context, id int) (*users.User, error) {
 	op := errors.Op("users.Repository.Find")
-	query := `SELECT id, username, email, hash FROM users WHERE id = $1;`
+	query := `
+	SELECT id, username, email, hash, email_verified_at IS NOT NULL, deleted IS NOT NULL, session_epoch,
+		totp_enabled_at IS NOT NULL
+	FROM users WHERE id = $1;`
 
 	user := &users.User{}
 	err := repo.db.QueryRowContext(ctx, query, id).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Hash)
+		Scan(&user.ID, &user.Username, &user.Email, &user.Hash, &user.Verified, &user.Deactivated, &user.Epoch,
+			&user.TwoFactor)
 	if err == sql.ErrNoRows {
 		return nil, users.ErrUserNotFound
 	}
@@ -59,11 +64,15 @@ func (repo *UserRepository) Find(ctx context.Context, id int) (*users.User, erro
 // FindByEmail finds by email
 func (repo *UserRepository) FindByEmail(ctx context.Context, email string) (*users.User, error) {
 	op := errors.Op("users.Repository.FindByEmail")
-	query := `SELECT id, username, email, hash FROM users WHERE email = $1;`
+	query := `
+	SELECT id, username, email, hash, email_verified_at IS NOT NULL, deleted IS NOT NULL, session_epoch,
+		totp_enabled_at IS NOT NULL
+	FROM users WHERE email = $1;`
 
 	user := &users.User{}
 	err := repo.db.QueryRowContext(ctx, query, email).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Hash)
+		Scan(&user.ID, &user.Username, &user.Email, &user.Hash, &user.Verified, &user.Deactivated, &user.Epoch,
+			&user.TwoFactor)
 	if err == sql.ErrNoRows {
 		return nil, users.ErrUserNotFound
 	}
@@ -72,3 +81,303 @@ func (repo *UserRepository) FindByEmail(ctx context.Context, email string) (*use
 	}
 	return user, nil
 }
+
+// MarkVerified marks the email verified, verifying again keeps the original time
+func (repo *UserRepository) MarkVerified(ctx context.Context, userID int) error {
+	op := errors.Op("users.Repository.MarkVerified")
+	stmt := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, stmt, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return users.ErrUserNotFound
+	}
+	return nil
+}
+
+// SetHash replaces the password hash, bumps the session epoch and revokes the API tokens
+func (repo *UserRepository) SetHash(ctx context.Context, userID int, hash string) (epoch int, err error) {
+	op := errors.Op("users.Repository.SetHash")
+	stmt := `
+	WITH revoked AS (
+		UPDATE api_tokens SET revoked = now() WHERE user_id = $2 AND revoked IS NULL
+	)
+	UPDATE users SET hash = $1, session_epoch = session_epoch + 1 WHERE id = $2 RETURNING session_epoch`
+
+	err = repo.db.QueryRowContext(ctx, stmt, hash, userID).Scan(&epoch)
+	if err == sql.ErrNoRows {
+		return 0, users.ErrUserNotFound
+	}
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return epoch, nil
+}
+
+// Rehash swaps in an upgraded hash, a password changed in the meantime is left alone
+func (repo *UserRepository) Rehash(ctx context.Context, userID int, old string, hash string) error {
+	op := errors.Op("users.Repository.Rehash")
+	stmt := `UPDATE users SET hash = $1 WHERE id = $2 AND hash = $3`
+
+	if _, err := repo.db.ExecContext(ctx, stmt, hash, userID, old); err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// SetEmail replaces the email, a verification sent to the old one can't verify the new one
+func (repo *UserRepository) SetEmail(ctx context.Context, userID int, email string) error {
+	op := errors.Op("users.Repository.SetEmail")
+	stmt := `UPDATE users SET email = $1, email_verified_at = NULL WHERE id = $2`
+	revoke := `UPDATE user_tokens SET used = now() WHERE user_id = $1 AND kind = $2 AND used IS NULL`
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, stmt, email, userID)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected < 1 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.ExecContext(ctx, revoke, userID, users.TokenVerifyEmail)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return users.ErrUserNotFound
+	}
+	if IsUniqueKeyViolation(err) {
+		return users.ErrEmailTaken
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// Deactivate sets deleted and bumps the session epoch, the username and email stay taken
+func (repo *UserRepository) Deactivate(ctx context.Context, userID int) error {
+	op := errors.Op("users.Repository.Deactivate")
+	stmt := `UPDATE users SET deleted = now(), session_epoch = session_epoch + 1 WHERE id = $1 AND deleted IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, stmt, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return users.ErrUserNotFound
+	}
+	return nil
+}
+
+// CreateToken stores a token
+func (repo *UserRepository) CreateToken(ctx context.Context, token *users.Token) error {
+	op := errors.Op("users.Repository.CreateToken")
+	stmt := `INSERT INTO user_tokens(user_id, kind, hash, expires) VALUES($1, $2, $3, $4)`
+
+	_, err := repo.db.ExecContext(ctx, stmt,
+		token.UserID, token.Kind, token.Hash, token.Expires.UTC())
+	if IsForeignKeyViolation(err) {
+		return users.ErrUserNotFound
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// HasToken checks for an usable token of kind
+func (repo *UserRepository) HasToken(ctx context.Context, userID int, kind users.TokenKind) (has bool, err error) {
+	op := errors.Op("users.Repository.HasToken")
+	query := `SELECT EXISTS(SELECT 1 FROM user_tokens WHERE user_id = $1 AND kind = $2 AND used IS NULL AND expires > now())`
+
+	if err := repo.db.QueryRowContext(ctx, query, userID, kind).Scan(&has); err != nil {
+		return false, errors.E(errors.Internal, op, err)
+	}
+	return has, nil
+}
+
+// ConsumeToken marks the token used, then the rest of the user's tokens of that kind
+// so an older email can't be used after a newer one.
+func (repo *UserRepository) ConsumeToken(ctx context.Context, kind users.TokenKind, hash string) (userID int, err error) {
+	op := errors.Op("users.Repository.ConsumeToken")
+	consume := `
+	UPDATE user_tokens SET used = now()
+	WHERE hash = $1 AND kind = $2 AND used IS NULL AND expires > now()
+	RETURNING user_id`
+	rest := `UPDATE user_tokens SET used = now() WHERE user_id = $1 AND kind = $2 AND used IS NULL`
+
+	err = transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		if err := tx.QueryRowContext(ctx, consume, hash, kind).Scan(&userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, rest, userID, kind)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return 0, users.ErrInvalidToken
+	}
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return userID, nil
+}
+
+// LoginFailures returns the failed logins of key, none is 0 and the zero time
+func (repo *UserRepository) LoginFailures(ctx context.Context, key string) (failures int, last time.Time, err error) {
+	op := errors.Op("users.Repository.LoginFailures")
+	query := `SELECT failures, last_failed FROM login_failures WHERE key = $1`
+
+	err = repo.db.QueryRowContext(ctx, query, key).Scan(&failures, &last)
+	if err == sql.ErrNoRows {
+		return 0, time.Time{}, nil
+	}
+	if err != nil {
+		return 0, time.Time{}, errors.E(errors.Internal, op, err)
+	}
+	return failures, last, nil
+}
+
+// RecordLoginFailure counts a failed login, failures from before since are forgotten
+func (repo *UserRepository) RecordLoginFailure(ctx context.Context, key string, since time.Time) (failures int, err error) {
+	op := errors.Op("users.Repository.RecordLoginFailure")
+	stmt := `
+	INSERT INTO login_failures(key, failures, last_failed) VALUES($1, 1, now())
+	ON CONFLICT (key) DO UPDATE SET
+		failures = CASE WHEN login_failures.last_failed < $2 THEN 1 ELSE login_failures.failures + 1 END,
+		last_failed = now()
+	RETURNING failures`
+
+	err = repo.db.QueryRowContext(ctx, stmt, key, since.UTC()).Scan(&failures)
+	if err != nil {
+		return 0, errors.E(errors.Internal, op, err)
+	}
+	return failures, nil
+}
+
+// ClearLoginFailures forgets the failed logins of key
+func (repo *UserRepository) ClearLoginFailures(ctx context.Context, key string) error {
+	op := errors.Op("users.Repository.ClearLoginFailures")
+	_, err := repo.db.ExecContext(ctx, `DELETE FROM login_failures WHERE key = $1`, key)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// TwoFactor returns the TOTP setup of an user
+func (repo *UserRepository) TwoFactor(ctx context.Context, userID int) (*users.TwoFactor, error) {
+	op := errors.Op("users.Repository.TwoFactor")
+	query := `SELECT COALESCE(totp_secret, ''), totp_enabled_at IS NOT NULL, totp_step FROM users WHERE id = $1`
+
+	tf := &users.TwoFactor{}
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(&tf.Secret, &tf.Enabled, &tf.LastStep)
+	if err == sql.ErrNoRows {
+		return nil, users.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errors.E(errors.Internal, op, err)
+	}
+	return tf, nil
+}
+
+// SetTwoFactorSecret replaces a pending secret, an enabled one is left alone
+func (repo *UserRepository) SetTwoFactorSecret(ctx context.Context, userID int, secret string) error {
+	op := errors.Op("users.Repository.SetTwoFactorSecret")
+	stmt := `UPDATE users SET totp_secret = $1 WHERE id = $2 AND totp_enabled_at IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, stmt, secret, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return users.ErrTwoFactorEnabled
+	}
+	return nil
+}
+
+// EnableTwoFactor turns 2FA on and replaces any earlier recovery codes
+func (repo *UserRepository) EnableTwoFactor(ctx context.Context, userID int, step int64, hashes []string) error {
+	op := errors.Op("users.Repository.EnableTwoFactor")
+	enable := `
+	UPDATE users SET totp_enabled_at = now(), totp_step = $1
+	WHERE id = $2 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL`
+	clear := `DELETE FROM recovery_codes WHERE user_id = $1`
+	insert := `INSERT INTO recovery_codes(user_id, hash) VALUES($1, $2)`
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, enable, step, userID)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected < 1 {
+			return users.ErrTwoFactorEnabled
+		}
+		if _, err := tx.ExecContext(ctx, clear, userID); err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			if _, err := tx.ExecContext(ctx, insert, userID, hash); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == users.ErrTwoFactorEnabled {
+		return err
+	}
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
+
+// UseTwoFactorStep only moves forward, so a code can't be replayed within its window
+func (repo *UserRepository) UseTwoFactorStep(ctx context.Context, userID int, step int64) error {
+	op := errors.Op("users.Repository.UseTwoFactorStep")
+	stmt := `UPDATE users SET totp_step = $1 WHERE id = $2 AND totp_step < $1`
+
+	result, err := repo.db.ExecContext(ctx, stmt, step, userID)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return users.ErrInvalidCode
+	}
+	return nil
+}
+
+// UseRecoveryCode marks a recovery code used
+func (repo *UserRepository) UseRecoveryCode(ctx context.Context, userID int, hash string) error {
+	op := errors.Op("users.Repository.UseRecoveryCode")
+	stmt := `UPDATE recovery_codes SET used = now() WHERE user_id = $1 AND hash = $2 AND used IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, stmt, userID, hash)
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return users.ErrInvalidCode
+	}
+	return nil
+}
+
+// DisableTwoFactor turns 2FA off, enrolling again starts from a new secret
+func (repo *UserRepository) DisableTwoFactor(ctx context.Context, userID int) error {
+	op := errors.Op("users.Repository.DisableTwoFactor")
+	disable := `UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_step = 0 WHERE id = $1`
+	clear := `DELETE FROM recovery_codes WHERE user_id = $1`
+
+	err := transact(ctx, repo.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, disable, userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, clear, userID)
+		return err
+	})
+	if err != nil {
+		return errors.E(errors.Internal, op, err)
+	}
+	return nil
+}
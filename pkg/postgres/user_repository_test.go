@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/godwhoa/upboat/pkg/users"
@@ -20,7 +21,7 @@ func setupDB() (*sql.DB, func(), error) {
 	}
 
 	database := "upboat"
-	resource, err := pool.Run("postgres", "9.6", []string{"POSTGRES_PASSWORD=secret", "POSTGRES_DB=" + database})
+	resource, err := pool.Run("postgres", "12", []string{"POSTGRES_PASSWORD=secret", "POSTGRES_DB=" + database})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -90,4 +91,134 @@ func TestUserRepository(t *testing.T) {
 	// Find User Not Found
 	_, err = userrepo.Find(ctx, 666)
 	c.Assert(err, qt.Equals, users.ErrUserNotFound)
+	// Tokens are single-use and consuming one voids the others
+	for _, hash := range []string{"old", "new"} {
+		err = userrepo.CreateToken(ctx, &users.Token{
+			UserID:  id,
+			Kind:    users.TokenPasswordReset,
+			Hash:    hash,
+			Expires: time.Now().Add(time.Hour),
+		})
+		c.Assert(err, qt.IsNil)
+	}
+	userID, err := userrepo.ConsumeToken(ctx, users.TokenPasswordReset, "new")
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, id)
+	_, err = userrepo.ConsumeToken(ctx, users.TokenPasswordReset, "new")
+	c.Assert(err, qt.Equals, users.ErrInvalidToken)
+	_, err = userrepo.ConsumeToken(ctx, users.TokenPasswordReset, "old")
+	c.Assert(err, qt.Equals, users.ErrInvalidToken)
+	// Expired tokens can't be used
+	err = userrepo.CreateToken(ctx, &users.Token{
+		UserID:  id,
+		Kind:    users.TokenPasswordReset,
+		Hash:    "expired",
+		Expires: time.Now().Add(-time.Minute),
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = userrepo.ConsumeToken(ctx, users.TokenPasswordReset, "expired")
+	c.Assert(err, qt.Equals, users.ErrInvalidToken)
+	// Rehash
+	err = userrepo.Rehash(ctx, id, "not_the_hash", "ignored")
+	c.Assert(err, qt.IsNil)
+	err = userrepo.Rehash(ctx, id, "bcrypt_hash", "argon2id_hash")
+	c.Assert(err, qt.IsNil)
+	user, err = userrepo.Find(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Hash, qt.Equals, "argon2id_hash")
+	c.Assert(user.Epoch, qt.Equals, 0)
+	// SetHash
+	epoch, err := userrepo.SetHash(ctx, id, "new_hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(epoch, qt.Equals, 1)
+	user, err = userrepo.Find(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Hash, qt.Equals, "new_hash")
+	c.Assert(user.Epoch, qt.Equals, 1)
+	// Verification
+	c.Assert(user.Verified, qt.Equals, false)
+	err = userrepo.MarkVerified(ctx, id)
+	c.Assert(err, qt.IsNil)
+	user, err = userrepo.Find(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Verified, qt.Equals, true)
+	err = userrepo.MarkVerified(ctx, 666)
+	c.Assert(err, qt.Equals, users.ErrUserNotFound)
+	// SetEmail
+	err = userrepo.Create(ctx, &users.User{
+		Username: "other",
+		Email:    "other@other.com",
+		Hash:     "bcrypt_hash",
+	})
+	c.Assert(err, qt.IsNil)
+	err = userrepo.SetEmail(ctx, id, "other@other.com")
+	c.Assert(err, qt.Equals, users.ErrEmailTaken)
+	err = userrepo.CreateToken(ctx, &users.Token{
+		UserID:  id,
+		Kind:    users.TokenVerifyEmail,
+		Hash:    "verify",
+		Expires: time.Now().Add(time.Hour),
+	})
+	c.Assert(err, qt.IsNil)
+	err = userrepo.SetEmail(ctx, id, "new@pac.com")
+	c.Assert(err, qt.IsNil)
+	user, err = userrepo.FindByEmail(ctx, "new@pac.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Verified, qt.Equals, false)
+	_, err = userrepo.ConsumeToken(ctx, users.TokenVerifyEmail, "verify")
+	c.Assert(err, qt.Equals, users.ErrInvalidToken)
+	// Deactivate
+	err = userrepo.Deactivate(ctx, id)
+	c.Assert(err, qt.IsNil)
+	user, err = userrepo.Find(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Deactivated, qt.Equals, true)
+	c.Assert(user.Epoch, qt.Equals, 2)
+	err = userrepo.Deactivate(ctx, id)
+	c.Assert(err, qt.Equals, users.ErrUserNotFound)
+
+	// Login failures
+	failures, _, err := userrepo.LoginFailures(ctx, "ip:127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(failures, qt.Equals, 0)
+	for i := 1; i <= 2; i++ {
+		failures, err = userrepo.RecordLoginFailure(ctx, "ip:127.0.0.1", time.Now().Add(-time.Hour))
+		c.Assert(err, qt.IsNil)
+		c.Assert(failures, qt.Equals, i)
+	}
+	// failures older than since start over
+	failures, err = userrepo.RecordLoginFailure(ctx, "ip:127.0.0.1", time.Now().Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(failures, qt.Equals, 1)
+	err = userrepo.ClearLoginFailures(ctx, "ip:127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	failures, _, err = userrepo.LoginFailures(ctx, "ip:127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(failures, qt.Equals, 0)
+
+	// Two-factor
+	err = userrepo.SetTwoFactorSecret(ctx, id, "JBSWY3DPEHPK3PXP")
+	c.Assert(err, qt.IsNil)
+	tf, err := userrepo.TwoFactor(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*tf, qt.Equals, users.TwoFactor{Secret: "JBSWY3DPEHPK3PXP"})
+	err = userrepo.EnableTwoFactor(ctx, id, 10, []string{"code1", "code2"})
+	c.Assert(err, qt.IsNil)
+	err = userrepo.SetTwoFactorSecret(ctx, id, "other")
+	c.Assert(err, qt.Equals, users.ErrTwoFactorEnabled)
+	user, err = userrepo.Find(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.TwoFactor, qt.Equals, true)
+	// steps only move forward
+	c.Assert(userrepo.UseTwoFactorStep(ctx, id, 10), qt.Equals, users.ErrInvalidCode)
+	c.Assert(userrepo.UseTwoFactorStep(ctx, id, 11), qt.IsNil)
+	// recovery codes are single-use
+	c.Assert(userrepo.UseRecoveryCode(ctx, id, "code1"), qt.IsNil)
+	c.Assert(userrepo.UseRecoveryCode(ctx, id, "code1"), qt.Equals, users.ErrInvalidCode)
+	err = userrepo.DisableTwoFactor(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(userrepo.UseRecoveryCode(ctx, id, "code2"), qt.Equals, users.ErrInvalidCode)
+	tf, err = userrepo.TwoFactor(ctx, id)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tf.Enabled, qt.Equals, false)
 }
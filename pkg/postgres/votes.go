@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// counter describes a votes table and the table its upvotes/downvotes/score counters live in
+type counter struct {
+	// votes table eg. post_votes
+	votes string
+	// column in votes referencing the voted on row eg. post_id
+	ref string
+	// table holding the counters eg. posts
+	counted string
+}
+
+var (
+	postCounter    = counter{votes: "post_votes", ref: "post_id", counted: "posts"}
+	commentCounter = counter{votes: "comment_votes", ref: "comment_id", counted: "comments"}
+)
+
+// vote upserts a vote and updates the counters to match, returns sql.ErrNoRows if the voted on row doesn't exist
+func (c counter) vote(ctx context.Context, tx *sqlx.Tx, id, voterID, delta int) error {
+	prev, err := c.lock(ctx, tx, id, voterID)
+	if err != nil {
+		return err
+	}
+	if prev == delta {
+		return nil
+	}
+
+	stmt := fmt.Sprintf(`
+	INSERT INTO %[1]s(%[2]s, voter_id, delta) VALUES ($1, $2, $3)
+	ON CONFLICT (voter_id, %[2]s) DO UPDATE SET delta = $3`, c.votes, c.ref)
+	if _, err := tx.ExecContext(ctx, stmt, id, voterID, delta); err != nil {
+		return err
+	}
+	return c.apply(ctx, tx, id, prev, delta)
+}
+
+// unvote deletes a vote and updates the counters to match, returns sql.ErrNoRows if the voted on row doesn't exist
+func (c counter) unvote(ctx context.Context, tx *sqlx.Tx, id, voterID int) error {
+	prev, err := c.lock(ctx, tx, id, voterID)
+	if err != nil {
+		return err
+	}
+	if prev == 0 {
+		return nil
+	}
+
+	stmt := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND voter_id = $2`, c.votes, c.ref)
+	if _, err := tx.ExecContext(ctx, stmt, id, voterID); err != nil {
+		return err
+	}
+	return c.apply(ctx, tx, id, prev, 0)
+}
+
+// retract deletes every vote of a voter and takes them back off the counters
+func (c counter) retract(ctx context.Context, tx *sqlx.Tx, voterID int) error {
+	// the voted on rows are locked before the votes, in the same order as vote does
+	lock := fmt.Sprintf(`
+	SELECT id FROM %[3]s WHERE id IN (SELECT %[2]s FROM %[1]s WHERE voter_id = $1)
+	ORDER BY id FOR UPDATE`, c.votes, c.ref, c.counted)
+	if _, err := tx.ExecContext(ctx, lock, voterID); err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf(`
+	WITH gone AS (
+		DELETE FROM %[1]s WHERE voter_id = $1 RETURNING %[2]s AS id, delta
+	)
+	UPDATE %[3]s t SET upvotes = upvotes - g.up, downvotes = downvotes - g.down, score = score - g.up + g.down
+	FROM (
+		SELECT id,
+			COUNT(*) FILTER (WHERE delta > 0) AS up,
+			COUNT(*) FILTER (WHERE delta < 0) AS down
+		FROM gone GROUP BY id
+	) g
+	WHERE t.id = g.id`, c.votes, c.ref, c.counted)
+	_, err := tx.ExecContext(ctx, stmt, voterID)
+	return err
+}
+
+// lock locks the voted on row, serializing votes on it, and returns the voter's current delta (0 if none)
+func (c counter) lock(ctx context.Context, tx *sqlx.Tx, id, voterID int) (prev int, err error) {
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 AND deleted IS NULL AND removed IS NULL FOR UPDATE`, c.counted)
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&id); err != nil {
+		return
+	}
+
+	query = fmt.Sprintf(`SELECT delta FROM %s WHERE %s = $1 AND voter_id = $2`, c.votes, c.ref)
+	err = tx.QueryRowContext(ctx, query, id, voterID).Scan(&prev)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return
+}
+
+// apply moves the counters from a prev to a next vote, 0 meaning no vote
+func (c counter) apply(ctx context.Context, tx *sqlx.Tx, id, prev, next int) error {
+	up, down := tally(next)
+	pup, pdown := tally(prev)
+	stmt := fmt.Sprintf(`
+	UPDATE %s SET upvotes = upvotes + $2, downvotes = downvotes + $3, score = score + $4
+	WHERE id = $1`, c.counted)
+	_, err := tx.ExecContext(ctx, stmt, id, up-pup, down-pdown, next-prev)
+	return err
+}
+
+// recount recomputes every row's counters from the votes table, returns how many rows were off.
+// Votes are blocked while it runs so the counters it writes are consistent. Locks are taken
+// on the counted table before the votes table, the same order vote locks them in.
+func (c counter) recount(ctx context.Context, tx *sqlx.Tx) (int64, error) {
+	lock := fmt.Sprintf(`LOCK TABLE %s IN EXCLUSIVE MODE; LOCK TABLE %s IN SHARE MODE`, c.counted, c.votes)
+	if _, err := tx.ExecContext(ctx, lock); err != nil {
+		return 0, err
+	}
+
+	stmt := fmt.Sprintf(`
+	UPDATE %[3]s t SET upvotes = c.up, downvotes = c.down, score = c.up - c.down
+	FROM (
+		SELECT t.id,
+			COUNT(v.id) FILTER (WHERE v.delta > 0) AS up,
+			COUNT(v.id) FILTER (WHERE v.delta < 0) AS down
+		FROM %[3]s t LEFT JOIN %[1]s v ON v.%[2]s = t.id
+		GROUP BY t.id
+	) c
+	WHERE t.id = c.id AND (t.upvotes, t.downvotes, t.score) IS DISTINCT FROM (c.up, c.down, c.up - c.down)`,
+		c.votes, c.ref, c.counted)
+	result, err := tx.ExecContext(ctx, stmt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// tally splits a vote into upvote and downvote counts
+func tally(delta int) (up, down int) {
+	switch {
+	case delta > 0:
+		return 1, 0
+	case delta < 0:
+		return 0, 1
+	}
+	return 0, 0
+}
+
+// RecountVotes repairs the vote counters of posts and comments from post_votes and comment_votes.
+// Posts and comments are recounted in transactions of their own, so only one of them is locked at a time.
+func RecountVotes(ctx context.Context, db *sql.DB) (posts, comments int64, err error) {
+	dbx := sqlx.NewDb(db, "postgres")
+	err = transact(ctx, dbx, func(tx *sqlx.Tx) (err error) {
+		posts, err = postCounter.recount(ctx, tx)
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = transact(ctx, dbx, func(tx *sqlx.Tx) (err error) {
+		comments, err = commentCounter.recount(ctx, tx)
+		return
+	})
+	return
+}
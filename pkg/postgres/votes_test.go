@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestTally(t *testing.T) {
+	c := qt.New(t)
+
+	// flipping a downvote to an upvote
+	up, down := tally(+1)
+	pup, pdown := tally(-1)
+	c.Assert(up-pup, qt.Equals, 1)
+	c.Assert(down-pdown, qt.Equals, -1)
+
+	// removing an upvote
+	up, down = tally(0)
+	pup, pdown = tally(+1)
+	c.Assert(up-pup, qt.Equals, -1)
+	c.Assert(down-pdown, qt.Equals, 0)
+}
@@ -0,0 +1,39 @@
+package posts
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// Cursor marks where a listing page ended.
+// Posts are ordered by (Rank, ID) descending so the next page starts right after it.
+type Cursor struct {
+	Sort Sort      `json:"s"`
+	Rank float64   `json:"r"`
+	ID   int       `json:"i"`
+	AsOf time.Time `json:"t"`
+}
+
+// Encode returns an opaque string form of the cursor
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor made by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &Cursor{}
+	if err := json.Unmarshal(b, c); err != nil || c.AsOf.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func validSort(sort Sort) bool {
+	return sort == SortNew || sort == SortTop || sort == SortHot
+}
@@ -0,0 +1,33 @@
+package posts
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	asOf := time.Date(2018, 10, 6, 12, 30, 0, 123456000, time.UTC)
+	cur := &Cursor{Sort: SortHot, Rank: 0.1 + 0.2, ID: 42, AsOf: asOf}
+
+	decoded, err := DecodeCursor(cur.Encode())
+	c.Assert(err, qt.IsNil)
+	c.Assert(decoded.Sort, qt.Equals, SortHot)
+	// rank has to survive exactly, it's compared against in SQL
+	c.Assert(decoded.Rank, qt.Equals, 0.1+0.2)
+	c.Assert(decoded.ID, qt.Equals, 42)
+	c.Assert(decoded.AsOf.Equal(asOf), qt.Equals, true)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := DecodeCursor("not a cursor")
+	c.Assert(err, qt.Equals, ErrInvalidCursor)
+
+	_, err = DecodeCursor("e30") // {}
+	c.Assert(err, qt.Equals, ErrInvalidCursor)
+}
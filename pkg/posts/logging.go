@@ -2,6 +2,7 @@ package posts
 
 import (
 	"context"
+	"time"
 
 	"github.com/godwhoa/upboat/pkg/errors"
 	"go.uber.org/zap"
@@ -54,6 +55,30 @@ func (m *loggingMiddleware) Get(ctx context.Context, postID int) (post *Post, er
 	return
 }
 
+func (m *loggingMiddleware) FindByURL(ctx context.Context, url string, since time.Time) (postID int, err error) {
+	postID, err = m.service.FindByURL(ctx, url, since)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from posts.Service.FindByURL()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) List(ctx context.Context, q ListQuery) (list []*Post, err error) {
+	list, err = m.service.List(ctx, q)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from posts.Service.List()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Listing(ctx context.Context, opts ListingOptions) (listing *Listing, err error) {
+	listing, err = m.service.Listing(ctx, opts)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from posts.Service.Listing()", zap.Error(err))
+	}
+	return
+}
+
 func (m *loggingMiddleware) Edit(ctx context.Context, post *Post) (err error) {
 	err = m.service.Edit(ctx, post)
 	if errors.Is(errors.Internal, err) {
@@ -62,8 +87,8 @@ func (m *loggingMiddleware) Edit(ctx context.Context, post *Post) (err error) {
 	return
 }
 
-func (m *loggingMiddleware) Delete(ctx context.Context, postID, authorID int) (err error) {
-	err = m.service.Delete(ctx, postID, authorID)
+func (m *loggingMiddleware) Delete(ctx context.Context, authorID, postID int) (err error) {
+	err = m.service.Delete(ctx, authorID, postID)
 	if errors.Is(errors.Internal, err) {
 		m.log.Error("Error from posts.Service.Delete()", zap.Error(err))
 	}
@@ -2,26 +2,45 @@ package posts
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/godwhoa/upboat/pkg/events"
+	"github.com/godwhoa/upboat/pkg/markdown"
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// policy is for titles, bodies are Markdown
 var policy = bluemonday.StrictPolicy().AllowElements("br")
 
 type service struct {
-	repo Repository
+	repo   Repository
+	events events.Publisher
 }
 
-// NewService is a constructor for user.Service
-func NewService(repo Repository) Service {
+// NewService is a constructor for user.Service, score changes from votes are published to publisher
+func NewService(repo Repository, publisher events.Publisher) Service {
 	return &service{
-		repo: repo,
+		repo:   repo,
+		events: publisher,
 	}
 }
 
 func (s *service) Create(ctx context.Context, post *Post) (int, error) {
 	post.Title = policy.Sanitize(post.Title)
-	post.Body = policy.Sanitize(post.Body)
+	post.BodyHTML = markdown.Render(post.Body)
+	if post.URL == "" {
+		if strings.TrimSpace(post.Body) == "" {
+			return 0, ErrEmptyBody
+		}
+		return s.repo.Create(ctx, post)
+	}
+
+	canonical, domain, err := Canonicalize(post.URL)
+	if err != nil {
+		return 0, err
+	}
+	post.URL, post.Domain = canonical, domain
 	return s.repo.Create(ctx, post)
 }
 
@@ -29,22 +48,109 @@ func (s *service) Get(ctx context.Context, postID int) (*Post, error) {
 	return s.repo.Get(ctx, postID)
 }
 
+func (s *service) FindByURL(ctx context.Context, url string, since time.Time) (int, error) {
+	return s.repo.FindByURL(ctx, url, since)
+}
+
+func (s *service) List(ctx context.Context, q ListQuery) ([]*Post, error) {
+	return s.repo.List(ctx, q)
+}
+
+func (s *service) Listing(ctx context.Context, opts ListingOptions) (*Listing, error) {
+	if opts.Sort == "" {
+		opts.Sort = SortHot
+	}
+	if !validSort(opts.Sort) {
+		return nil, ErrInvalidSort
+	}
+	if opts.Limit < 1 || opts.Limit > MaxLimit {
+		opts.Limit = DefaultLimit
+	}
+
+	q := ListQuery{
+		Sort:        opts.Sort,
+		AsOf:        time.Now().UTC().Truncate(time.Microsecond),
+		Limit:       opts.Limit + 1, // one extra to know if there's a next page
+		Domain:      strings.TrimPrefix(strings.ToLower(opts.Domain), "www."),
+		CommunityID: opts.CommunityID,
+		ViewerID:    opts.ViewerID,
+		Following:   opts.Following,
+	}
+	if opts.Cursor != "" {
+		after, err := DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.Sort != opts.Sort {
+			return nil, ErrInvalidCursor
+		}
+		q.AsOf = after.AsOf
+		q.After = after
+	}
+
+	list, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &Listing{Posts: list}
+	if len(list) > opts.Limit {
+		listing.Posts = list[:opts.Limit]
+		last := listing.Posts[opts.Limit-1]
+		next := &Cursor{Sort: q.Sort, Rank: last.Rank, ID: last.ID, AsOf: q.AsOf}
+		listing.Next = next.Encode()
+	}
+	return listing, nil
+}
+
 func (s *service) Edit(ctx context.Context, post *Post) error {
 	post.Title = policy.Sanitize(post.Title)
-	post.Body = policy.Sanitize(post.Body)
+	post.BodyHTML = markdown.Render(post.Body)
+	if strings.TrimSpace(post.Body) == "" {
+		// only link posts can go without a body
+		existing, err := s.repo.Get(ctx, post.ID)
+		if err != nil {
+			return err
+		}
+		if existing.URL == "" {
+			return ErrEmptyBody
+		}
+	}
 	return s.repo.Edit(ctx, post)
 }
 
-func (s *service) Delete(ctx context.Context, postID, authorID int) error {
-	return s.repo.Delete(ctx, postID, authorID)
+func (s *service) Delete(ctx context.Context, authorID, postID int) error {
+	if err := s.repo.Delete(ctx, authorID, postID); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{Type: events.PostDeleted, PostID: postID})
+	return nil
 }
 
 func (s *service) Vote(ctx context.Context, postID, voterID, delta int) error {
-	return s.repo.Vote(ctx, postID, voterID, delta)
+	if err := s.repo.Vote(ctx, postID, voterID, delta); err != nil {
+		return err
+	}
+	s.scoreChanged(ctx, postID)
+	return nil
 }
 
 func (s *service) Unvote(ctx context.Context, postID, voterID int) error {
-	return s.repo.Unvote(ctx, postID, voterID)
+	if err := s.repo.Unvote(ctx, postID, voterID); err != nil {
+		return err
+	}
+	s.scoreChanged(ctx, postID)
+	return nil
+}
+
+// scoreChanged publishes the post's new score.
+// Events are best effort, the vote went through either way and the publisher logs its own failures.
+func (s *service) scoreChanged(ctx context.Context, postID int) {
+	score, err := s.repo.Score(ctx, postID)
+	if err != nil {
+		return
+	}
+	s.events.Publish(ctx, events.Event{Type: events.ScoreChanged, PostID: postID, Score: &score})
 }
 
 func (s *service) Score(ctx context.Context, postID int) (int, error) {
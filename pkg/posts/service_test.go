@@ -0,0 +1,42 @@
+package posts
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/events"
+)
+
+// mockRepo has post 100 by user 1
+type mockRepo struct {
+	Repository
+}
+
+func (r *mockRepo) Delete(ctx context.Context, authorID, postID int) error {
+	if authorID != 1 || postID != 100 {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+type mockPublisher struct {
+	published []events.Event
+}
+
+func (p *mockPublisher) Publish(ctx context.Context, e events.Event) error {
+	p.published = append(p.published, e)
+	return nil
+}
+
+func TestService_Delete(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	publisher := &mockPublisher{}
+	service := NewService(&mockRepo{}, publisher)
+
+	c.Assert(service.Delete(ctx, 2, 100), qt.Equals, ErrPostNotFound)
+	c.Assert(publisher.published, qt.HasLen, 0)
+	c.Assert(service.Delete(ctx, 1, 100), qt.IsNil)
+	c.Assert(publisher.published, qt.DeepEquals, []events.Event{{Type: events.PostDeleted, PostID: 100}})
+}
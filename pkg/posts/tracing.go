@@ -2,6 +2,7 @@ package posts
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/trace"
 )
@@ -27,16 +28,34 @@ func (m *tracingMiddleware) Get(ctx context.Context, postID int) (*Post, error)
 	return m.service.Get(ctx, postID)
 }
 
+func (m *tracingMiddleware) FindByURL(ctx context.Context, url string, since time.Time) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "posts.Service.FindByURL")
+	defer span.End()
+	return m.service.FindByURL(ctx, url, since)
+}
+
+func (m *tracingMiddleware) List(ctx context.Context, q ListQuery) ([]*Post, error) {
+	ctx, span := trace.StartSpan(ctx, "posts.Service.List")
+	defer span.End()
+	return m.service.List(ctx, q)
+}
+
+func (m *tracingMiddleware) Listing(ctx context.Context, opts ListingOptions) (*Listing, error) {
+	ctx, span := trace.StartSpan(ctx, "posts.Service.Listing")
+	defer span.End()
+	return m.service.Listing(ctx, opts)
+}
+
 func (m *tracingMiddleware) Edit(ctx context.Context, post *Post) (err error) {
 	ctx, span := trace.StartSpan(ctx, "posts.Service.Edit")
 	defer span.End()
 	return m.service.Edit(ctx, post)
 }
 
-func (m *tracingMiddleware) Delete(ctx context.Context, postID, authorID int) (err error) {
+func (m *tracingMiddleware) Delete(ctx context.Context, authorID, postID int) (err error) {
 	ctx, span := trace.StartSpan(ctx, "posts.Service.Delete")
 	defer span.End()
-	return m.service.Delete(ctx, postID, authorID)
+	return m.service.Delete(ctx, authorID, postID)
 }
 
 func (m *tracingMiddleware) Vote(ctx context.Context, postID, voterID, delta int) (err error) {
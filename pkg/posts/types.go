@@ -2,29 +2,117 @@ package posts
 
 import (
 	"context"
+	"time"
 
 	"github.com/godwhoa/upboat/pkg/errors"
 )
 
-// Post models a post
+// Post models a post.
+// URL is the canonical form of the link a post was submitted with, if any.
+// CommunityID is nil for posts outside of any community.
+// Locked posts don't take new comments.
+// Body is the Markdown source and BodyHTML is what it renders to.
 type Post struct {
-	ID       int    `json:"id"`
-	AuthorID int    `json:"author_id"`
-	Title    string `json:"title"`
-	Body     string `json:"body"`
+	ID          int       `json:"id"`
+	AuthorID    int       `json:"author_id"`
+	CommunityID *int      `json:"community_id,omitempty"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	BodyHTML    string    `json:"body_html"`
+	URL         string    `json:"url,omitempty"`
+	Domain      string    `json:"domain,omitempty"`
+	Locked      bool      `json:"locked"`
+	Upvotes     int       `json:"upvotes"`
+	Downvotes   int       `json:"downvotes"`
+	Score       int       `json:"score"`
+	Created     time.Time `json:"created"`
+	// Rank is the value a listing was ordered by
+	Rank float64 `json:"-"`
 }
 
+// Sort is the order of a listing
+type Sort string
+
+// Supported listing orders
+const (
+	SortNew Sort = "new"
+	SortTop Sort = "top"
+	SortHot Sort = "hot"
+)
+
+// RepostWindow is how long a link counts as a duplicate after it was submitted
+const RepostWindow = 30 * 24 * time.Hour
+
+// Limits for a listing page
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
 var (
 	// ErrPostNotFound for when post is not found.
 	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
 	// ErrUnauthorized for when a user tries to delete or edit of another user
 	ErrUnauthorized = errors.E(errors.Unauthorized, "Unauthorized to delete/edit the post")
+	// ErrInvalidSort for when a listing is requested in an unknown order
+	ErrInvalidSort = errors.E(errors.Invalid, "Invalid sort, must be one of new, top or hot")
+	// ErrInvalidCursor for when a listing cursor is malformed or for another sort
+	ErrInvalidCursor = errors.E(errors.Invalid, "Invalid cursor")
+	// ErrInvalidURL for when a link isn't an absolute http(s) URL
+	ErrInvalidURL = errors.E(errors.Invalid, "Invalid URL, must be an absolute http or https URL")
+	// ErrEmptyBody for when a post has neither a body nor a URL
+	ErrEmptyBody = errors.E(errors.Invalid, "Body can't be empty for posts without a URL")
+	// ErrDuplicateURL for when a link was already submitted within RepostWindow
+	ErrDuplicateURL = errors.E(errors.Conflict, "This link was already submitted")
 )
 
+// ListQuery selects a page of posts ordered by Sort.
+// Only posts created at or before AsOf are listed,
+// so pages of the same listing don't shift when new posts arrive.
+// Hot ranks are taken as of AsOf too, so they don't shift as they decay.
+type ListQuery struct {
+	Sort  Sort
+	AsOf  time.Time
+	Limit int
+	// Domain restricts the listing to links to a domain, empty for all posts
+	Domain string
+	// CommunityID restricts the listing to a community, 0 for all posts
+	CommunityID int
+	// ViewerID leaves out posts the viewer hid or whose author they blocked, 0 for anonymous listings
+	ViewerID int
+	// Following restricts the listing to posts by users ViewerID follows
+	Following bool
+	// After is where the previous page ended, nil for the first page
+	After *Cursor
+}
+
+// ListingOptions are the options for a listing page as requested by a client
+type ListingOptions struct {
+	Sort        Sort
+	Cursor      string
+	Limit       int
+	Domain      string
+	CommunityID int
+	ViewerID    int
+	Following   bool
+}
+
+// Listing is a page of posts, Next is the cursor for the following page
+type Listing struct {
+	Posts []*Post `json:"posts"`
+	Next  string  `json:"next,omitempty"`
+}
+
 // Repository handles storing posts and their votes
 type Repository interface {
+	// Create stores a post. A link submitted within RepostWindow of an earlier post of the same URL
+	// isn't stored, the earlier post's ID is returned along with ErrDuplicateURL instead.
 	Create(ctx context.Context, post *Post) (id int, err error)
 	Get(ctx context.Context, postID int) (post *Post, err error)
+	// FindByURL fetches the latest post submitted with a canonical URL since a point in time
+	FindByURL(ctx context.Context, url string, since time.Time) (postID int, err error)
+	// List fetches a page of posts with their Rank set
+	List(ctx context.Context, q ListQuery) ([]*Post, error)
 	Edit(ctx context.Context, post *Post) error
 	Delete(ctx context.Context, authorID, postID int) error
 	// Vote upserts a vote for a specific post/user
@@ -34,7 +122,11 @@ type Repository interface {
 	Score(ctx context.Context, postID int) (score int, err error)
 }
 
-// Service is a thin layer around Repository which sanitizes Title and Body
+// Service is a thin layer around Repository which sanitizes Title, renders Body
+// and canonicalizes URLs. Create returns the existing post's ID along with ErrDuplicateURL
+// when a link is resubmitted within RepostWindow.
 type Service interface {
 	Repository
+	// Listing fetches a page of posts, continuing from opts.Cursor if it is set
+	Listing(ctx context.Context, opts ListingOptions) (*Listing, error)
 }
@@ -0,0 +1,72 @@
+package posts
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query params which only track where a link was shared from,
+// params starting with utm_ are dropped as well
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"igshid":  true,
+	"yclid":   true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Canonicalize normalizes a link so the same page submitted twice has the same URL.
+// Scheme and host are lowercased, a leading "www.", default ports, credentials, fragments and tracking params
+// are dropped and the remaining query params are sorted. The domain is the host without its port.
+func Canonicalize(raw string) (canonical, domain string, err error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", ErrInvalidURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if _, ok := defaultPorts[u.Scheme]; !ok || u.Opaque != "" {
+		return "", "", ErrInvalidURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return "", "", ErrInvalidURL
+	}
+	host = strings.TrimPrefix(host, "www.")
+	domain = host
+	if port := u.Port(); port != "" && port != defaultPorts[u.Scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		// IPv6 literal
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	query := u.Query()
+	for param := range query {
+		if trackingParams[strings.ToLower(param)] || strings.HasPrefix(strings.ToLower(param), "utm_") {
+			query.Del(param)
+		}
+	}
+	// Encode sorts by key
+	u.RawQuery = query.Encode()
+	u.ForceQuery = false
+
+	return u.String(), domain, nil
+}
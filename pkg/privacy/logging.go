@@ -0,0 +1,55 @@
+package privacy
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Export(ctx context.Context, userID int) (archive *Archive, err error) {
+	archive, err = m.service.Export(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from privacy.Service.Export()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Erase(ctx context.Context, userID int, password string) (err error) {
+	err = m.service.Erase(ctx, userID, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from privacy.Service.Erase()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,27 @@
+package privacy
+
+import "context"
+
+type service struct {
+	repo      Repository
+	passwords Passwords
+}
+
+// NewService is a constructor for privacy.Service
+func NewService(repo Repository, passwords Passwords) Service {
+	return &service{
+		repo:      repo,
+		passwords: passwords,
+	}
+}
+
+func (s *service) Export(ctx context.Context, userID int) (*Archive, error) {
+	return s.repo.Export(ctx, userID)
+}
+
+func (s *service) Erase(ctx context.Context, userID int, password string) error {
+	if err := s.passwords.VerifyPassword(ctx, userID, password); err != nil {
+		return err
+	}
+	return s.repo.Erase(ctx, userID)
+}
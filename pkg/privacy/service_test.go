@@ -0,0 +1,64 @@
+package privacy
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+type mockRepo struct {
+	erased bool
+}
+
+func (r *mockRepo) Export(ctx context.Context, userID int) (*Archive, error) {
+	if userID != 1 {
+		return nil, ErrUserNotFound
+	}
+	return &Archive{Account: Account{ID: 1, Username: "blah"}}, nil
+}
+
+func (r *mockRepo) Erase(ctx context.Context, userID int) error {
+	r.erased = true
+	return nil
+}
+
+// mockPasswords takes "password" as everyone's password
+type mockPasswords struct{}
+
+var errIncorrectPassword = errors.E(errors.Invalid, "Incorrect password")
+
+func (mockPasswords) VerifyPassword(ctx context.Context, userID int, password string) error {
+	if password != "password" {
+		return errIncorrectPassword
+	}
+	return nil
+}
+
+func TestService_Export(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{}, mockPasswords{})
+
+	archive, err := service.Export(context.Background(), 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(archive.Account.Username, qt.Equals, "blah")
+
+	_, err = service.Export(context.Background(), 2)
+	c.Assert(err, qt.Equals, ErrUserNotFound)
+}
+
+// Erase only goes through with the right password
+func TestService_Erase(t *testing.T) {
+	c := qt.New(t)
+	repo := &mockRepo{}
+	service := NewService(repo, mockPasswords{})
+
+	err := service.Erase(context.Background(), 1, "wrong")
+	c.Assert(err, qt.Equals, errIncorrectPassword)
+	c.Assert(repo.erased, qt.Equals, false)
+
+	err = service.Erase(context.Background(), 1, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.erased, qt.Equals, true)
+}
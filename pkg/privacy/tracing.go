@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Export(ctx context.Context, userID int) (*Archive, error) {
+	ctx, span := trace.StartSpan(ctx, "privacy.Service.Export")
+	defer span.End()
+	return m.service.Export(ctx, userID)
+}
+
+func (m *tracingMiddleware) Erase(ctx context.Context, userID int, password string) error {
+	ctx, span := trace.StartSpan(ctx, "privacy.Service.Erase")
+	defer span.End()
+	return m.service.Erase(ctx, userID, password)
+}
@@ -0,0 +1,90 @@
+package privacy
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+var (
+	// ErrUserNotFound for when there's no user to export or erase
+	ErrUserNotFound = errors.E(errors.NotFound, "User not found")
+)
+
+// Archive is everything stored about a user that they can take with them.
+// It includes their deleted and removed content, since that is still stored.
+type Archive struct {
+	Exported     time.Time      `json:"exported"`
+	Account      Account        `json:"account"`
+	Posts        []*Post        `json:"posts"`
+	Comments     []*Comment     `json:"comments"`
+	PostVotes    []*PostVote    `json:"post_votes"`
+	CommentVotes []*CommentVote `json:"comment_votes"`
+}
+
+// Account is the user's own account details
+type Account struct {
+	ID       int       `json:"id"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+	Verified bool      `json:"verified"`
+	Joined   time.Time `json:"joined"`
+}
+
+// Post is a post the user authored
+type Post struct {
+	ID          int       `json:"id"`
+	CommunityID *int      `json:"community_id,omitempty"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	URL         string    `json:"url,omitempty"`
+	Created     time.Time `json:"created"`
+	Deleted     bool      `json:"deleted"`
+	Removed     bool      `json:"removed"`
+}
+
+// Comment is a comment the user authored
+type Comment struct {
+	ID       int       `json:"id"`
+	PostID   int       `json:"post_id"`
+	ParentID *int      `json:"parent_id"`
+	Body     string    `json:"body"`
+	Created  time.Time `json:"created"`
+	Deleted  bool      `json:"deleted"`
+	Removed  bool      `json:"removed"`
+}
+
+// PostVote is a vote the user cast on a post
+type PostVote struct {
+	PostID int `json:"post_id"`
+	Delta  int `json:"delta"`
+}
+
+// CommentVote is a vote the user cast on a comment
+type CommentVote struct {
+	CommentID int `json:"comment_id"`
+	Delta     int `json:"delta"`
+}
+
+// Repository reads and erases everything stored about a user
+type Repository interface {
+	// Export collects a user's data, returns ErrUserNotFound if there's no such user
+	Export(ctx context.Context, userID int) (*Archive, error)
+	// Erase removes a user's votes and memberships, hands their posts and comments over to the [deleted] user
+	// and scrubs their account of anything identifying. It all happens in a single transaction.
+	Erase(ctx context.Context, userID int) error
+}
+
+// Passwords confirms a user's password before their data is erased, users.Service satisfies it
+type Passwords interface {
+	VerifyPassword(ctx context.Context, userID int, password string) error
+}
+
+// Service lets users take their data or have it erased
+type Service interface {
+	// Export collects a user's data
+	Export(ctx context.Context, userID int) (*Archive, error)
+	// Erase erases a user after checking their password, see Repository.Erase
+	Erase(ctx context.Context, userID int, password string) error
+}
@@ -0,0 +1,79 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Profile(ctx context.Context, username string) (profile *Profile, err error) {
+	profile, err = m.service.Profile(ctx, username)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from profiles.Service.Profile()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Posts(ctx context.Context, username string, page Page) (result *PostsPage, err error) {
+	result, err = m.service.Posts(ctx, username, page)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from profiles.Service.Posts()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Comments(ctx context.Context, username string, page Page) (result *CommentsPage, err error) {
+	result, err = m.service.Comments(ctx, username, page)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from profiles.Service.Comments()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Follow(ctx context.Context, userID int, username string) (err error) {
+	err = m.service.Follow(ctx, userID, username)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from profiles.Service.Follow()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unfollow(ctx context.Context, userID int, username string) (err error) {
+	err = m.service.Unfollow(ctx, userID, username)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from profiles.Service.Unfollow()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package profiles
+
+import (
+	"context"
+)
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for profiles.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Profile(ctx context.Context, username string) (*Profile, error) {
+	return s.repo.Profile(ctx, username)
+}
+
+// clamp defaults the limit and asks for one extra item to know if there's a next page
+func clamp(page Page) (Page, int) {
+	if page.Limit < 1 || page.Limit > MaxLimit {
+		page.Limit = DefaultLimit
+	}
+	if page.Before < 0 {
+		page.Before = 0
+	}
+	limit := page.Limit
+	page.Limit++
+	return page, limit
+}
+
+func (s *service) Posts(ctx context.Context, username string, page Page) (*PostsPage, error) {
+	profile, err := s.repo.Profile(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	page, limit := clamp(page)
+	list, err := s.repo.Posts(ctx, profile.ID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PostsPage{Posts: list}
+	if len(list) > limit {
+		result.Posts = list[:limit]
+		result.Next = result.Posts[limit-1].ID
+	}
+	return result, nil
+}
+
+func (s *service) Comments(ctx context.Context, username string, page Page) (*CommentsPage, error) {
+	profile, err := s.repo.Profile(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	page, limit := clamp(page)
+	list, err := s.repo.Comments(ctx, profile.ID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CommentsPage{Comments: list}
+	if len(list) > limit {
+		result.Comments = list[:limit]
+		result.Next = result.Comments[limit-1].ID
+	}
+	return result, nil
+}
+
+func (s *service) Follow(ctx context.Context, userID int, username string) error {
+	profile, err := s.repo.Profile(ctx, username)
+	if err != nil {
+		return err
+	}
+	if profile.ID == userID {
+		return ErrFollowSelf
+	}
+	return s.repo.Follow(ctx, userID, profile.ID)
+}
+
+func (s *service) Unfollow(ctx context.Context, userID int, username string) error {
+	profile, err := s.repo.Profile(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.repo.Unfollow(ctx, userID, profile.ID)
+}
@@ -0,0 +1,98 @@
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// mockRepo has a single user "pacninja" who authored posts and comments with IDs 1 to 10
+type mockRepo struct {
+	page     Page
+	followed []int
+}
+
+func (r *mockRepo) Profile(ctx context.Context, username string) (*Profile, error) {
+	if username != "pacninja" {
+		return nil, ErrUserNotFound
+	}
+	return &Profile{ID: 1, Username: username}, nil
+}
+
+func (r *mockRepo) Posts(ctx context.Context, userID int, page Page) ([]*posts.Post, error) {
+	r.page = page
+	list := []*posts.Post{}
+	for id := 10; id > 0 && len(list) < page.Limit; id-- {
+		if page.Before == 0 || id < page.Before {
+			list = append(list, &posts.Post{ID: id, AuthorID: userID})
+		}
+	}
+	return list, nil
+}
+
+func (r *mockRepo) Comments(ctx context.Context, userID int, page Page) ([]*comments.Comment, error) {
+	r.page = page
+	list := []*comments.Comment{}
+	for id := 10; id > 0 && len(list) < page.Limit; id-- {
+		if page.Before == 0 || id < page.Before {
+			list = append(list, &comments.Comment{ID: id, CommenterID: userID})
+		}
+	}
+	return list, nil
+}
+
+func (r *mockRepo) Follow(ctx context.Context, userID, followedID int) error {
+	r.followed = append(r.followed, followedID)
+	return nil
+}
+
+func (r *mockRepo) Unfollow(ctx context.Context, userID, followedID int) error {
+	return nil
+}
+
+func TestService_Follow(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	c.Assert(service.Follow(ctx, 1, "pacninja"), qt.Equals, ErrFollowSelf)
+	c.Assert(service.Follow(ctx, 2, "nobody"), qt.Equals, ErrUserNotFound)
+	c.Assert(service.Follow(ctx, 2, "pacninja"), qt.IsNil)
+	c.Assert(repo.followed, qt.DeepEquals, []int{1})
+}
+
+func TestService_Posts(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo)
+
+	_, err := service.Posts(ctx, "nobody", Page{})
+	c.Assert(err, qt.Equals, ErrUserNotFound)
+
+	page, err := service.Posts(ctx, "pacninja", Page{Limit: 4})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Posts, qt.HasLen, 4)
+	c.Assert(page.Next, qt.Equals, 7)
+
+	page, err = service.Posts(ctx, "pacninja", Page{Before: page.Next, Limit: 1000})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.page.Limit, qt.Equals, DefaultLimit+1)
+	c.Assert(page.Posts, qt.HasLen, 6)
+	c.Assert(page.Next, qt.Equals, 0)
+}
+
+func TestService_Comments(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	service := NewService(&mockRepo{})
+
+	page, err := service.Comments(ctx, "pacninja", Page{Limit: 5})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Comments, qt.HasLen, 5)
+	c.Assert(page.Next, qt.Equals, 6)
+}
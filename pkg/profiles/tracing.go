@@ -0,0 +1,46 @@
+package profiles
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Profile(ctx context.Context, username string) (*Profile, error) {
+	ctx, span := trace.StartSpan(ctx, "profiles.Service.Profile")
+	defer span.End()
+	return m.service.Profile(ctx, username)
+}
+
+func (m *tracingMiddleware) Posts(ctx context.Context, username string, page Page) (*PostsPage, error) {
+	ctx, span := trace.StartSpan(ctx, "profiles.Service.Posts")
+	defer span.End()
+	return m.service.Posts(ctx, username, page)
+}
+
+func (m *tracingMiddleware) Comments(ctx context.Context, username string, page Page) (*CommentsPage, error) {
+	ctx, span := trace.StartSpan(ctx, "profiles.Service.Comments")
+	defer span.End()
+	return m.service.Comments(ctx, username, page)
+}
+
+func (m *tracingMiddleware) Follow(ctx context.Context, userID int, username string) error {
+	ctx, span := trace.StartSpan(ctx, "profiles.Service.Follow")
+	defer span.End()
+	return m.service.Follow(ctx, userID, username)
+}
+
+func (m *tracingMiddleware) Unfollow(ctx context.Context, userID int, username string) error {
+	ctx, span := trace.StartSpan(ctx, "profiles.Service.Unfollow")
+	defer span.End()
+	return m.service.Unfollow(ctx, userID, username)
+}
@@ -0,0 +1,76 @@
+package profiles
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/comments"
+	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// Limits for a page of a user's posts or comments
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
+// Profile is what anyone can see about a user, it must never carry an email or password hash.
+// Karma is the sum of votes others gave to the user's posts and comments.
+type Profile struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	Joined       time.Time `json:"joined"`
+	PostKarma    int       `json:"post_karma"`
+	CommentKarma int       `json:"comment_karma"`
+	Followers    int       `json:"followers"`
+	Following    int       `json:"following"`
+}
+
+var (
+	// ErrUserNotFound for when there's no active user with a username
+	ErrUserNotFound = errors.E(errors.NotFound, "User not found")
+	// ErrFollowSelf for when an user tries to follow themselves
+	ErrFollowSelf = errors.E(errors.Invalid, "You can't follow yourself")
+)
+
+// Page selects a page of a user's posts or comments, newest first.
+// Before is the ID the previous page ended at, 0 for the first page.
+type Page struct {
+	Before int
+	Limit  int
+	// ViewerID gets an empty page for users they blocked, 0 for anonymous viewers
+	ViewerID int
+}
+
+// PostsPage is a page of a user's posts, Next is passed as Before to fetch the following page
+type PostsPage struct {
+	Posts []*posts.Post `json:"posts"`
+	Next  int           `json:"next,omitempty"`
+}
+
+// CommentsPage is a page of a user's comments, Next is passed as Before to fetch the following page
+type CommentsPage struct {
+	Comments []*comments.Comment `json:"comments"`
+	Next     int                 `json:"next,omitempty"`
+}
+
+// Repository fetches public profiles and what users authored
+type Repository interface {
+	Profile(ctx context.Context, username string) (*Profile, error)
+	Posts(ctx context.Context, userID int, page Page) ([]*posts.Post, error)
+	Comments(ctx context.Context, userID int, page Page) ([]*comments.Comment, error)
+	// Follow is a no-op if the user already follows followedID
+	Follow(ctx context.Context, userID, followedID int) error
+	Unfollow(ctx context.Context, userID, followedID int) error
+}
+
+// Service looks up users by username, paginates what they authored and manages who follows whom.
+// The feed of followed users' posts is a posts listing.
+type Service interface {
+	Profile(ctx context.Context, username string) (*Profile, error)
+	Posts(ctx context.Context, username string, page Page) (*PostsPage, error)
+	Comments(ctx context.Context, username string, page Page) (*CommentsPage, error)
+	Follow(ctx context.Context, userID int, username string) error
+	Unfollow(ctx context.Context, userID int, username string) error
+}
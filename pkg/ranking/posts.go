@@ -0,0 +1,44 @@
+package ranking
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/posts"
+)
+
+// Rerank is a posts.Middleware which re-ranks a post right after it's created or voted on.
+// Failing to re-rank doesn't fail the request, the background worker catches up on it.
+func Rerank(rs Service) posts.Middleware {
+	return func(service posts.Service) posts.Service {
+		return &rerankMiddleware{service, rs}
+	}
+}
+
+type rerankMiddleware struct {
+	posts.Service
+	ranking Service
+}
+
+func (m *rerankMiddleware) Create(ctx context.Context, post *posts.Post) (id int, err error) {
+	id, err = m.Service.Create(ctx, post)
+	if err == nil {
+		m.ranking.Recompute(ctx, id)
+	}
+	return
+}
+
+func (m *rerankMiddleware) Vote(ctx context.Context, postID, voterID, delta int) (err error) {
+	err = m.Service.Vote(ctx, postID, voterID, delta)
+	if err == nil {
+		m.ranking.Recompute(ctx, postID)
+	}
+	return
+}
+
+func (m *rerankMiddleware) Unvote(ctx context.Context, postID, voterID int) (err error) {
+	err = m.Service.Unvote(ctx, postID, voterID)
+	if err == nil {
+		m.ranking.Recompute(ctx, postID)
+	}
+	return
+}
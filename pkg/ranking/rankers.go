@@ -0,0 +1,58 @@
+package ranking
+
+import (
+	"math"
+	"time"
+)
+
+// Ranker computes the rank of a post, higher ranks are listed first
+type Ranker interface {
+	Rank(e *Entry, now time.Time) float64
+}
+
+// NewRanker returns the Ranker selected by options
+func NewRanker(o Options) (Ranker, error) {
+	switch o.Algorithm {
+	case "hn":
+		return HN{Gravity: o.Gravity}, nil
+	case "reddit":
+		return Reddit{}, nil
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+}
+
+// HN ranks like Hacker News, score divided by age in hours raised to Gravity.
+// Ranks decay with time so they have to be recomputed periodically.
+type HN struct {
+	Gravity float64
+}
+
+// Rank implements Ranker
+func (h HN) Rank(e *Entry, now time.Time) float64 {
+	hours := now.Sub(e.Created).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	return float64(e.Score) / math.Pow(hours+2, h.Gravity)
+}
+
+// redditEpoch is the epoch used by reddit's hot ranking
+var redditEpoch = time.Unix(1134028003, 0)
+
+// Reddit ranks like reddit's "hot", log10 of the score plus the age bonus.
+// Ranks only change with votes as newer posts get a bigger bonus instead of older ones decaying.
+type Reddit struct{}
+
+// Rank implements Ranker
+func (Reddit) Rank(e *Entry, now time.Time) float64 {
+	order := math.Log10(math.Max(math.Abs(float64(e.Score)), 1))
+	sign := 0.0
+	if e.Score > 0 {
+		sign = 1
+	} else if e.Score < 0 {
+		sign = -1
+	}
+	seconds := e.Created.Sub(redditEpoch).Seconds()
+	return sign*order + seconds/45000
+}
@@ -0,0 +1,60 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var now = time.Date(2018, 10, 8, 12, 0, 0, 0, time.UTC)
+
+func TestNewRanker(t *testing.T) {
+	c := qt.New(t)
+
+	r, err := NewRanker(Options{Algorithm: "hn", Gravity: 1.8})
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.Equals, Ranker(HN{Gravity: 1.8}))
+
+	r, err = NewRanker(Options{Algorithm: "reddit"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.Equals, Ranker(Reddit{}))
+
+	_, err = NewRanker(Options{Algorithm: "digg"})
+	c.Assert(err, qt.Equals, ErrUnknownAlgorithm)
+}
+
+func TestHN(t *testing.T) {
+	c := qt.New(t)
+	hn := HN{Gravity: 1.8}
+
+	fresh := &Entry{Score: 10, Created: now.Add(-time.Hour)}
+	old := &Entry{Score: 10, Created: now.Add(-24 * time.Hour)}
+	popularOld := &Entry{Score: 1000, Created: now.Add(-24 * time.Hour)}
+
+	c.Assert(hn.Rank(fresh, now) > hn.Rank(old, now), qt.Equals, true)
+	c.Assert(hn.Rank(popularOld, now) > hn.Rank(fresh, now), qt.Equals, true)
+	// decays with time
+	c.Assert(hn.Rank(fresh, now.Add(time.Hour)) < hn.Rank(fresh, now), qt.Equals, true)
+	// higher gravity sinks old posts faster
+	steep := HN{Gravity: 3}
+	c.Assert(steep.Rank(old, now)/steep.Rank(fresh, now) < hn.Rank(old, now)/hn.Rank(fresh, now), qt.Equals, true)
+}
+
+func TestReddit(t *testing.T) {
+	c := qt.New(t)
+	reddit := Reddit{}
+
+	e := &Entry{Score: 10, Created: now.Add(-45000 * time.Second)}
+	newer := &Entry{Score: 10, Created: now}
+	downvoted := &Entry{Score: -10, Created: now}
+
+	c.Assert(reddit.Rank(newer, now) > reddit.Rank(e, now), qt.Equals, true)
+	c.Assert(reddit.Rank(downvoted, now) < reddit.Rank(newer, now), qt.Equals, true)
+	// doesn't decay
+	c.Assert(reddit.Rank(e, now.Add(time.Hour)), qt.Equals, reddit.Rank(e, now))
+	// 12.5 hours of age is worth a 10x score
+	diff := reddit.Rank(&Entry{Score: 100, Created: e.Created}, now) - reddit.Rank(newer, now)
+	c.Assert(math.Abs(diff) < 1e-9, qt.Equals, true)
+}
@@ -0,0 +1,65 @@
+package ranking
+
+import (
+	"context"
+	"time"
+)
+
+type service struct {
+	repo   Repository
+	ranker Ranker
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewService is a constructor for ranking.Service
+func NewService(repo Repository, ranker Ranker, window time.Duration) Service {
+	return &service{
+		repo:   repo,
+		ranker: ranker,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+func (s *service) Recompute(ctx context.Context, postID int) error {
+	e, err := s.repo.Entry(ctx, postID)
+	if err != nil {
+		return err
+	}
+	return s.repo.SetRanks(ctx, map[int]float64{
+		postID: s.ranker.Rank(e, s.now().UTC()),
+	})
+}
+
+func (s *service) RecomputeStale(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	entries, err := s.repo.Stale(ctx, now, s.window)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	ranks := make(map[int]float64, len(entries))
+	for _, e := range entries {
+		ranks[e.PostID] = s.ranker.Rank(e, now)
+	}
+	return len(ranks), s.repo.SetRanks(ctx, ranks)
+}
+
+// Schedule re-ranks stale posts every interval until ctx is done
+func Schedule(ctx context.Context, s Service, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		// errors are logged by the service's logging middleware and retried on next tick
+		s.RecomputeStale(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package ranking
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Recompute(ctx context.Context, postID int) error {
+	ctx, span := trace.StartSpan(ctx, "ranking.Service.Recompute")
+	defer span.End()
+	return m.service.Recompute(ctx, postID)
+}
+
+func (m *tracingMiddleware) RecomputeStale(ctx context.Context) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "ranking.Service.RecomputeStale")
+	defer span.End()
+	return m.service.RecomputeStale(ctx)
+}
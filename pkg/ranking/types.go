@@ -0,0 +1,59 @@
+package ranking
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+var (
+	// ErrPostNotFound for when post is not found.
+	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
+	// ErrUnknownAlgorithm for when Options ask for a ranker that doesn't exist
+	ErrUnknownAlgorithm = errors.E(errors.Invalid, "Unknown ranking algorithm")
+)
+
+// Options configures ranking of posts
+type Options struct {
+	// Algorithm is one of "hn" or "reddit"
+	Algorithm string
+	// Gravity is how fast posts sink with age, only used by "hn"
+	Gravity float64
+	// Window is how long after creation a post keeps getting re-ranked
+	Window time.Duration
+	// Interval is how often the background worker re-ranks posts
+	Interval time.Duration
+}
+
+// Stored reports whether hot listings are ordered by the ranks Service stores.
+// hn ranks decay with time, so listings rank posts as of when they were first listed instead.
+func (o Options) Stored() bool {
+	return o.Algorithm != "hn"
+}
+
+// Entry is what a post is ranked by
+type Entry struct {
+	PostID  int
+	Score   int
+	Created time.Time
+}
+
+// Repository handles fetching entries and storing their ranks
+type Repository interface {
+	// Entry fetches the entry of a specific post
+	Entry(ctx context.Context, postID int) (*Entry, error)
+	// Stale fetches entries of posts younger than window along with the ones
+	// which haven't been ranked since they became older than it.
+	Stale(ctx context.Context, now time.Time, window time.Duration) ([]*Entry, error)
+	// SetRanks stores ranks keyed by post id
+	SetRanks(ctx context.Context, ranks map[int]float64) error
+}
+
+// Service keeps the stored rank of posts up to date
+type Service interface {
+	// Recompute re-ranks a specific post
+	Recompute(ctx context.Context, postID int) error
+	// RecomputeStale re-ranks posts within the ranking window, returns how many were re-ranked
+	RecomputeStale(ctx context.Context) (n int, err error)
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Memory keeps buckets in memory, it's only accurate when running a single instance
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemory is a constructor
+func NewMemory() *Memory {
+	return &Memory{buckets: map[string]*bucket{}}
+}
+
+// Take takes a token from key's bucket
+func (m *Memory) Take(ctx context.Context, key string, policy Policy, now time.Time) (time.Duration, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), updated: now}
+		m.buckets[key] = b
+	}
+	left, wait, ok := policy.Take(b.tokens, b.updated, now)
+	b.tokens, b.updated = left, now
+	return wait, ok, nil
+}
+
+// Sweep forgets buckets that weren't touched since before
+func (m *Memory) Sweep(ctx context.Context, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, b := range m.buckets {
+		if b.updated.Before(before) {
+			delete(m.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Groups of routes that are limited separately.
+// GroupMail is for routes that send emails and GroupToken for redeeming the tokens emailed.
+const (
+	GroupRegister = "register"
+	GroupLogin    = "login"
+	GroupPost     = "post"
+	GroupComment  = "comment"
+	GroupVote     = "vote"
+	GroupMail     = "mail"
+	GroupToken    = "token"
+)
+
+// ErrLimited is returned if a key ran out of tokens
+var ErrLimited = errors.E(errors.TooManyRequests, "Slow down, try again later")
+
+// Policy is a token bucket.
+// A key starts with Burst tokens, each request takes one and one is given back every Every, up to Burst.
+type Policy struct {
+	Burst int
+	Every time.Duration
+}
+
+// DefaultPolicies are the policies for each group unless configured otherwise
+var DefaultPolicies = map[string]Policy{
+	GroupRegister: {Burst: 3, Every: 20 * time.Minute},
+	GroupLogin:    {Burst: 10, Every: time.Minute},
+	GroupPost:     {Burst: 5, Every: 2 * time.Minute},
+	GroupComment:  {Burst: 10, Every: 30 * time.Second},
+	GroupVote:     {Burst: 60, Every: time.Second},
+	GroupMail:     {Burst: 3, Every: 10 * time.Minute},
+	GroupToken:    {Burst: 10, Every: time.Minute},
+}
+
+// String formats a policy as burst/every eg. 5/2m0s
+func (p *Policy) String() string {
+	return fmt.Sprintf("%d/%s", p.Burst, p.Every)
+}
+
+// Set parses a policy formatted as burst/every eg. 5/2m, so a Policy can be a flag
+func (p *Policy) Set(s string) error {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("policy %q isn't formatted as burst/every", s)
+	}
+	burst, err := strconv.Atoi(parts[0])
+	if err != nil || burst < 1 {
+		return fmt.Errorf("invalid burst %q", parts[0])
+	}
+	every, err := time.ParseDuration(parts[1])
+	if err != nil || every <= 0 {
+		return fmt.Errorf("invalid interval %q", parts[1])
+	}
+	p.Burst, p.Every = burst, every
+	return nil
+}
+
+// Take refills a bucket for the time since it was last updated, then takes a token if there's a whole one.
+// A bucket that doesn't exist yet is passed as full.
+func (p Policy) Take(tokens float64, updated, now time.Time) (left float64, wait time.Duration, ok bool) {
+	if elapsed := now.Sub(updated); elapsed > 0 {
+		tokens += float64(elapsed) / float64(p.Every)
+	}
+	tokens = math.Min(tokens, float64(p.Burst))
+	if tokens >= 1 {
+		return tokens - 1, 0, true
+	}
+	return tokens, time.Duration((1 - tokens) * float64(p.Every)), false
+}
+
+// full is how long an untouched bucket takes to fill up, after which it can be forgotten
+func (p Policy) full() time.Duration {
+	return time.Duration(p.Burst) * p.Every
+}
+
+// Store keeps buckets
+type Store interface {
+	// Take takes a token from key's bucket, returns how long to wait if there's none
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (wait time.Duration, ok bool, err error)
+	// Sweep forgets buckets that weren't touched since before
+	Sweep(ctx context.Context, before time.Time) error
+}
+
+// Limiter limits each group of routes with its policy
+type Limiter struct {
+	store    Store
+	policies map[string]Policy
+	now      func() time.Time
+}
+
+// New is a constructor, groups without a policy aren't limited
+func New(store Store, policies map[string]Policy) *Limiter {
+	return &Limiter{store: store, policies: policies, now: time.Now}
+}
+
+// Allow takes a token for key in group, returns ErrLimited and how long to wait if there's none
+func (l *Limiter) Allow(ctx context.Context, group, key string) (time.Duration, error) {
+	policy, ok := l.policies[group]
+	if !ok {
+		return 0, nil
+	}
+	wait, ok, err := l.store.Take(ctx, group+":"+key, policy, l.now())
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return wait, ErrLimited
+	}
+	return 0, nil
+}
+
+// Sweep forgets buckets that have filled back up, they're no different from new ones
+func (l *Limiter) Sweep(ctx context.Context) error {
+	var longest time.Duration
+	for _, policy := range l.policies {
+		if full := policy.full(); full > longest {
+			longest = full
+		}
+	}
+	return l.store.Sweep(ctx, l.now().Add(-longest))
+}
+
+// Schedule sweeps the limiter every so often until ctx is done
+func Schedule(ctx context.Context, l *Limiter, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// a failed sweep is retried on next tick
+			l.Sweep(ctx)
+		}
+	}
+}
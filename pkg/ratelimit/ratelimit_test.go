@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPolicy_Take(t *testing.T) {
+	c := qt.New(t)
+	p := Policy{Burst: 2, Every: time.Minute}
+	now := time.Now()
+
+	left, _, ok := p.Take(2, now, now)
+	c.Assert(ok, qt.Equals, true)
+	c.Assert(left, qt.Equals, 1.0)
+	left, _, ok = p.Take(left, now, now)
+	c.Assert(ok, qt.Equals, true)
+	left, wait, ok := p.Take(left, now, now)
+	c.Assert(ok, qt.Equals, false)
+	c.Assert(wait, qt.Equals, time.Minute)
+
+	// half a token comes back after 30s, so the rest is a 30s wait
+	left, wait, ok = p.Take(left, now, now.Add(30*time.Second))
+	c.Assert(ok, qt.Equals, false)
+	c.Assert(wait, qt.Equals, 30*time.Second)
+	_, _, ok = p.Take(left, now.Add(30*time.Second), now.Add(time.Minute))
+	c.Assert(ok, qt.Equals, true)
+
+	// buckets don't fill past the burst
+	left, _, _ = p.Take(0, now, now.Add(time.Hour))
+	c.Assert(left, qt.Equals, 1.0)
+}
+
+func TestPolicy_Set(t *testing.T) {
+	c := qt.New(t)
+	p := &Policy{}
+	c.Assert(p.Set("5/2m"), qt.IsNil)
+	c.Assert(*p, qt.Equals, Policy{Burst: 5, Every: 2 * time.Minute})
+	c.Assert(p.String(), qt.Equals, "5/2m0s")
+
+	for _, invalid := range []string{"5", "0/1m", "x/1m", "5/x", "5/-1m"} {
+		c.Assert(p.Set(invalid), qt.Not(qt.IsNil), qt.Commentf(invalid))
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory()
+	l := New(store, map[string]Policy{GroupVote: {Burst: 1, Every: time.Minute}})
+	l.now = func() time.Time { return now }
+
+	_, err := l.Allow(ctx, GroupVote, "user:1")
+	c.Assert(err, qt.IsNil)
+	wait, err := l.Allow(ctx, GroupVote, "user:1")
+	c.Assert(err, qt.Equals, ErrLimited)
+	c.Assert(wait, qt.Equals, time.Minute)
+	// keys and groups have their own buckets, groups without a policy aren't limited
+	_, err = l.Allow(ctx, GroupVote, "user:2")
+	c.Assert(err, qt.IsNil)
+	_, err = l.Allow(ctx, GroupPost, "user:1")
+	c.Assert(err, qt.IsNil)
+	_, err = l.Allow(ctx, GroupPost, "user:1")
+	c.Assert(err, qt.IsNil)
+
+	// full buckets get swept
+	now = now.Add(2 * time.Minute)
+	c.Assert(l.Sweep(ctx), qt.IsNil)
+	c.Assert(store.buckets, qt.HasLen, 0)
+}
@@ -0,0 +1,71 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware is anything that wraps around a `Service`
+type Middleware func(Service) Service
+
+// Logging is a middleware that provides logging to Service
+func Logging(log *zap.Logger) Middleware {
+	return func(service Service) Service {
+		return &loggingMiddleware{service, log}
+	}
+}
+
+// Chain lets you chain multiple middleware
+func Chain(service Service, middlewares ...Middleware) Service {
+	if len(middlewares) == 0 {
+		return service
+	}
+
+	// Wrap the first middleware with the service
+	s := middlewares[len(middlewares)-1](service)
+	// Wrap that with the rest of the middleware chain
+	for i := len(middlewares) - 2; i >= 0; i-- {
+		s = middlewares[i](s)
+	}
+
+	return s
+}
+
+type loggingMiddleware struct {
+	service Service
+	log     *zap.Logger
+}
+
+func (m *loggingMiddleware) Report(ctx context.Context, report *Report) (id int, err error) {
+	id, err = m.service.Report(ctx, report)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from reports.Service.Report()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Queue(ctx context.Context, moderatorID int, q QueueQuery) (queue *Queue, err error) {
+	queue, err = m.service.Queue(ctx, moderatorID, q)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from reports.Service.Queue()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Resolve(ctx context.Context, moderatorID int, kind Kind, targetID int) (err error) {
+	err = m.service.Resolve(ctx, moderatorID, kind, targetID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from reports.Service.Resolve()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Dismiss(ctx context.Context, moderatorID int, kind Kind, targetID int) (err error) {
+	err = m.service.Dismiss(ctx, moderatorID, kind, targetID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from reports.Service.Dismiss()", zap.Error(err))
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var policy = bluemonday.StrictPolicy()
+
+var reasons = map[Reason]bool{
+	Spam: true, Harassment: true, Hate: true, Violence: true, Misinformation: true, Other: true,
+}
+
+type service struct {
+	repo       Repository
+	moderators Moderators
+}
+
+// NewService is a constructor for reports.Service
+func NewService(repo Repository, moderators Moderators) Service {
+	return &service{
+		repo:       repo,
+		moderators: moderators,
+	}
+}
+
+func (s *service) Report(ctx context.Context, report *Report) (int, error) {
+	if !reasons[report.Reason] {
+		return 0, ErrInvalidReason
+	}
+	report.Details = policy.Sanitize(report.Details)
+	return s.repo.Create(ctx, report)
+}
+
+func (s *service) Queue(ctx context.Context, moderatorID int, q QueueQuery) (*Queue, error) {
+	var communityID *int
+	if q.CommunityID != 0 {
+		communityID = &q.CommunityID
+	}
+	if err := s.authorize(ctx, moderatorID, communityID); err != nil {
+		return nil, err
+	}
+	if q.Limit < 1 || q.Limit > MaxLimit {
+		q.Limit = DefaultLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	limit := q.Limit
+	q.Limit++ // one extra to know if there's a next page
+
+	items, err := s.repo.Queue(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := &Queue{Items: items}
+	if len(items) > limit {
+		queue.Items = items[:limit]
+		queue.More = true
+	}
+	return queue, nil
+}
+
+func (s *service) Resolve(ctx context.Context, moderatorID int, kind Kind, targetID int) error {
+	return s.close(ctx, moderatorID, kind, targetID, Resolved)
+}
+
+func (s *service) Dismiss(ctx context.Context, moderatorID int, kind Kind, targetID int) error {
+	return s.close(ctx, moderatorID, kind, targetID, Dismissed)
+}
+
+func (s *service) close(ctx context.Context, moderatorID int, kind Kind, targetID int, status Status) error {
+	communityID, err := s.repo.Community(ctx, kind, targetID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, moderatorID, communityID); err != nil {
+		return err
+	}
+	return s.repo.Close(ctx, kind, targetID, status, moderatorID)
+}
+
+func (s *service) authorize(ctx context.Context, moderatorID int, communityID *int) error {
+	ok, err := s.moderators.IsModerator(ctx, moderatorID, communityID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotModerator
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package reports
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// mockRepo has content with an even ID in community 10, the rest outside of any community
+type mockRepo struct {
+	created *Report
+	closed  Status
+	items   int
+}
+
+func (r *mockRepo) Create(ctx context.Context, report *Report) (int, error) {
+	r.created = report
+	return 1, nil
+}
+
+func (r *mockRepo) Queue(ctx context.Context, q QueueQuery) ([]*Item, error) {
+	items := []*Item{}
+	for i := 0; i < r.items && i < q.Limit; i++ {
+		items = append(items, &Item{Kind: KindPost, TargetID: i})
+	}
+	return items, nil
+}
+
+func (r *mockRepo) Community(ctx context.Context, kind Kind, targetID int) (*int, error) {
+	if targetID%2 == 0 {
+		community := 10
+		return &community, nil
+	}
+	return nil, nil
+}
+
+func (r *mockRepo) Close(ctx context.Context, kind Kind, targetID int, status Status, moderatorID int) error {
+	r.closed = status
+	return nil
+}
+
+// mockModerators has user 1 as a global moderator and user 2 moderating community 10
+type mockModerators struct{}
+
+func (mockModerators) IsModerator(ctx context.Context, userID int, communityID *int) (bool, error) {
+	if userID == 1 {
+		return true, nil
+	}
+	return userID == 2 && communityID != nil && *communityID == 10, nil
+}
+
+func TestService_Report(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{}
+	service := NewService(repo, mockModerators{})
+
+	_, err := service.Report(ctx, &Report{Kind: KindPost, TargetID: 1, Reason: "boring"})
+	c.Assert(err, qt.Equals, ErrInvalidReason)
+
+	_, err = service.Report(ctx, &Report{Kind: KindPost, TargetID: 1, Reason: Spam, Details: "<a href='x'>buy</a> now"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.created.Details, qt.Equals, "buy now")
+}
+
+func TestService_Triage(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{items: 3}
+	service := NewService(repo, mockModerators{})
+
+	// community moderators only see their community's queue
+	_, err := service.Queue(ctx, 2, QueueQuery{})
+	c.Assert(err, qt.Equals, ErrNotModerator)
+	queue, err := service.Queue(ctx, 2, QueueQuery{CommunityID: 10, Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Assert(queue.Items, qt.HasLen, 2)
+	c.Assert(queue.More, qt.Equals, true)
+	queue, err = service.Queue(ctx, 1, QueueQuery{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(queue.Items, qt.HasLen, 3)
+	c.Assert(queue.More, qt.Equals, false)
+
+	err = service.Dismiss(ctx, 2, KindComment, 3)
+	c.Assert(err, qt.Equals, ErrNotModerator)
+	err = service.Dismiss(ctx, 2, KindComment, 4)
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.closed, qt.Equals, Dismissed)
+	err = service.Resolve(ctx, 1, KindPost, 3)
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.closed, qt.Equals, Resolved)
+}
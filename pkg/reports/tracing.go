@@ -0,0 +1,40 @@
+package reports
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Report(ctx context.Context, report *Report) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "reports.Service.Report")
+	defer span.End()
+	return m.service.Report(ctx, report)
+}
+
+func (m *tracingMiddleware) Queue(ctx context.Context, moderatorID int, q QueueQuery) (*Queue, error) {
+	ctx, span := trace.StartSpan(ctx, "reports.Service.Queue")
+	defer span.End()
+	return m.service.Queue(ctx, moderatorID, q)
+}
+
+func (m *tracingMiddleware) Resolve(ctx context.Context, moderatorID int, kind Kind, targetID int) error {
+	ctx, span := trace.StartSpan(ctx, "reports.Service.Resolve")
+	defer span.End()
+	return m.service.Resolve(ctx, moderatorID, kind, targetID)
+}
+
+func (m *tracingMiddleware) Dismiss(ctx context.Context, moderatorID int, kind Kind, targetID int) error {
+	ctx, span := trace.StartSpan(ctx, "reports.Service.Dismiss")
+	defer span.End()
+	return m.service.Dismiss(ctx, moderatorID, kind, targetID)
+}
@@ -0,0 +1,123 @@
+package reports
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Kind is the kind of content a report is about
+type Kind string
+
+// Reportable content
+const (
+	KindPost    Kind = "post"
+	KindComment Kind = "comment"
+)
+
+// Reason is the category a report is filed under
+type Reason string
+
+// Report reasons
+const (
+	Spam           Reason = "spam"
+	Harassment     Reason = "harassment"
+	Hate           Reason = "hate"
+	Violence       Reason = "violence"
+	Misinformation Reason = "misinformation"
+	Other          Reason = "other"
+)
+
+// Status of a report, reports stay open until a moderator resolves or dismisses them
+type Status string
+
+// Report statuses
+const (
+	Open      Status = "open"
+	Resolved  Status = "resolved"
+	Dismissed Status = "dismissed"
+)
+
+// Limits for a page of the report queue
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
+// Report is a user flagging a post or comment
+type Report struct {
+	ID         int       `json:"id"`
+	ReporterID int       `json:"reporter_id"`
+	Kind       Kind      `json:"kind"`
+	TargetID   int       `json:"target_id"`
+	Reason     Reason    `json:"reason"`
+	Details    string    `json:"details"`
+	Created    time.Time `json:"created"`
+}
+
+// Item is a reported post or comment in the queue along with how often and why it was reported
+type Item struct {
+	Kind        Kind      `json:"kind"`
+	TargetID    int       `json:"target_id"`
+	CommunityID *int      `json:"community_id"`
+	Reports     int       `json:"reports"`
+	Reasons     []Reason  `json:"reasons"`
+	Latest      time.Time `json:"latest"`
+}
+
+// QueueQuery selects a page of the open reports queue, most reported first.
+// CommunityID restricts the queue to a community, 0 for every community.
+type QueueQuery struct {
+	CommunityID int
+	Offset      int
+	Limit       int
+}
+
+// Queue is a page of the report queue, More tells if there are further pages
+type Queue struct {
+	Items []*Item `json:"items"`
+	More  bool    `json:"more"`
+}
+
+var (
+	// ErrInvalidReason for when a report isn't filed under one of the reasons
+	ErrInvalidReason = errors.E(errors.Invalid, "Invalid reason, must be one of spam, harassment, hate, violence, misinformation or other")
+	// ErrAlreadyReported for when a user reports the same content twice
+	ErrAlreadyReported = errors.E(errors.Conflict, "Already reported")
+	// ErrNoOpenReports for when there's nothing to resolve or dismiss
+	ErrNoOpenReports = errors.E(errors.NotFound, "No open reports")
+	// ErrNotModerator for when a user triages reports of content they don't moderate
+	ErrNotModerator = errors.E(errors.Unauthorized, "Not a moderator")
+	// ErrPostNotFound for when post is not found.
+	ErrPostNotFound = errors.E(errors.NotFound, "Post not found")
+	// ErrCommentNotFound for when comment is not found.
+	ErrCommentNotFound = errors.E(errors.NotFound, "Comment not found")
+)
+
+// Repository handles storing reports and the queue
+type Repository interface {
+	// Create stores a report, each user can report some content once
+	Create(ctx context.Context, report *Report) (id int, err error)
+	Queue(ctx context.Context, q QueueQuery) ([]*Item, error)
+	// Community fetches the community of reported content
+	Community(ctx context.Context, kind Kind, targetID int) (communityID *int, err error)
+	// Close sets the status of all open reports on some content
+	Close(ctx context.Context, kind Kind, targetID int, status Status, moderatorID int) error
+}
+
+// Moderators tells who moderates what, it's implemented by moderation.Repository
+type Moderators interface {
+	IsModerator(ctx context.Context, userID int, communityID *int) (bool, error)
+}
+
+// Service validates reports and lets moderators triage them
+type Service interface {
+	Report(ctx context.Context, report *Report) (id int, err error)
+	// Queue fetches open reports for a moderator of q.CommunityID, or a global moderator when it's 0
+	Queue(ctx context.Context, moderatorID int, q QueueQuery) (*Queue, error)
+	// Resolve closes reports on content which was acted on
+	Resolve(ctx context.Context, moderatorID int, kind Kind, targetID int) error
+	// Dismiss closes reports on content which was fine
+	Dismiss(ctx context.Context, moderatorID int, kind Kind, targetID int) error
+}
@@ -71,6 +71,12 @@ func renderE(e *errors.Error) *Response {
 			Message: e.Message,
 			Data:    nil,
 		}
+	case errors.TooManyRequests:
+		return &Response{
+			Code:    http.StatusTooManyRequests,
+			Message: e.Message,
+			Data:    nil,
+		}
 	case errors.Internal:
 		return InternalError()
 	default:
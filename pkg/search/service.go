@@ -0,0 +1,65 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// snippets are cut from the Markdown source, which may hold raw HTML,
+// so everything but the <mark></mark> highlights is stripped
+var snippets = bluemonday.StrictPolicy().AllowElements("mark")
+
+type service struct {
+	repo Repository
+}
+
+// NewService is a constructor for search.Service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Search(ctx context.Context, opts Options) (*Page, error) {
+	text := strings.TrimSpace(opts.Text)
+	if text == "" {
+		return nil, ErrEmptyQuery
+	}
+	if utf8.RuneCountInString(text) > MaxQueryLength {
+		return nil, ErrQueryTooLong
+	}
+	if opts.Type != "" && opts.Type != TypePost && opts.Type != TypeComment {
+		return nil, ErrInvalidType
+	}
+	if opts.Limit < 1 || opts.Limit > MaxLimit {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	results, err := s.repo.Search(ctx, Query{
+		Text:     text,
+		Type:     opts.Type,
+		Offset:   opts.Offset,
+		Limit:    opts.Limit + 1, // one extra to know if there's a next page
+		ViewerID: opts.ViewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		result.Snippet = snippets.Sanitize(result.Snippet)
+	}
+
+	page := &Page{Results: results}
+	if len(results) > opts.Limit {
+		page.Results = results[:opts.Limit]
+		page.More = true
+	}
+	return page, nil
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type mockRepo struct {
+	q       Query
+	results int
+	snippet string
+}
+
+func (r *mockRepo) Search(ctx context.Context, q Query) ([]*Result, error) {
+	r.q = q
+	results := []*Result{}
+	for i := 0; i < r.results && i < q.Limit; i++ {
+		results = append(results, &Result{Type: TypePost, ID: i, Snippet: r.snippet})
+	}
+	return results, nil
+}
+
+func TestService_Search_Invalid(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	service := NewService(&mockRepo{})
+
+	_, err := service.Search(ctx, Options{Text: "   "})
+	c.Assert(err, qt.Equals, ErrEmptyQuery)
+
+	_, err = service.Search(ctx, Options{Text: strings.Repeat("a", MaxQueryLength+1)})
+	c.Assert(err, qt.Equals, ErrQueryTooLong)
+
+	_, err = service.Search(ctx, Options{Text: "boats", Type: "user"})
+	c.Assert(err, qt.Equals, ErrInvalidType)
+}
+
+func TestService_Search_Pages(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{results: 5}
+	service := NewService(repo)
+
+	page, err := service.Search(ctx, Options{Text: " boats ", Limit: 3})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.q.Text, qt.Equals, "boats")
+	c.Assert(page.Results, qt.HasLen, 3)
+	c.Assert(page.More, qt.Equals, true)
+
+	page, err = service.Search(ctx, Options{Text: "boats", Offset: 3, Limit: 3})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.q.Offset, qt.Equals, 3)
+	c.Assert(page.More, qt.Equals, true) // the mock doesn't apply the offset
+
+	page, err = service.Search(ctx, Options{Text: "boats", Limit: 1000})
+	c.Assert(err, qt.IsNil)
+	c.Assert(repo.q.Limit, qt.Equals, DefaultLimit+1)
+	c.Assert(page.Results, qt.HasLen, 5)
+	c.Assert(page.More, qt.Equals, false)
+}
+
+func TestService_Search_Snippet(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	repo := &mockRepo{results: 1, snippet: `<script>alert(1)</script> a <mark>boat</mark> <img src=x onerror=alert(1)>`}
+	service := NewService(repo)
+
+	page, err := service.Search(ctx, Options{Text: "boats"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Results[0].Snippet, qt.Equals, " a <mark>boat</mark> ")
+}
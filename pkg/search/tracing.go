@@ -0,0 +1,22 @@
+package search
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+type tracingMiddleware struct {
+	service Service
+}
+
+// Tracing is a middleware that provides tracing to Service
+func Tracing(service Service) Service {
+	return &tracingMiddleware{service}
+}
+
+func (m *tracingMiddleware) Search(ctx context.Context, opts Options) (*Page, error) {
+	ctx, span := trace.StartSpan(ctx, "search.Service.Search")
+	defer span.End()
+	return m.service.Search(ctx, opts)
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Types of results
+const (
+	TypePost    = "post"
+	TypeComment = "comment"
+)
+
+// Limits for a page of results
+const (
+	DefaultLimit   = 20
+	MaxLimit       = 50
+	MaxQueryLength = 200
+)
+
+var (
+	// ErrEmptyQuery for when there's nothing to search for
+	ErrEmptyQuery = errors.E(errors.Invalid, "Search query can't be empty")
+	// ErrQueryTooLong for when the search query is over MaxQueryLength
+	ErrQueryTooLong = errors.E(errors.Invalid, "Search query is too long")
+	// ErrInvalidType for when results are filtered by an unknown type
+	ErrInvalidType = errors.E(errors.Invalid, "Invalid type, must be post or comment")
+)
+
+// Result is a post or comment matching a search.
+// Snippet is an excerpt of the body with matches wrapped in <mark></mark>.
+type Result struct {
+	Type    string    `json:"type"`
+	ID      int       `json:"id"`
+	PostID  int       `json:"post_id"`
+	Title   string    `json:"title"`
+	Snippet string    `json:"snippet"`
+	Rank    float64   `json:"rank"`
+	Created time.Time `json:"created"`
+}
+
+// Query is a search as run against the repository
+type Query struct {
+	Text string
+	// Type restricts results to posts or comments, empty for both
+	Type   string
+	Offset int
+	Limit  int
+	// ViewerID leaves out posts and comments by users the viewer blocked, 0 for anonymous viewers
+	ViewerID int
+}
+
+// Options are the options for a search as requested by a client
+type Options struct {
+	Text     string
+	Type     string
+	Offset   int
+	Limit    int
+	ViewerID int
+}
+
+// Page is a page of results ordered by relevance, More tells if there are further pages
+type Page struct {
+	Results []*Result `json:"results"`
+	More    bool      `json:"more"`
+}
+
+// Repository handles searching through posts and comments
+type Repository interface {
+	// Search fetches results ordered by relevance, deleted posts and comments are left out
+	// along with those by users the viewer blocked
+	Search(ctx context.Context, q Query) ([]*Result, error)
+}
+
+// Service validates searches before running them
+type Service interface {
+	Search(ctx context.Context, opts Options) (*Page, error)
+}
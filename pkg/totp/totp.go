@@ -0,0 +1,106 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Codes are the ones authenticator apps show by default, 6 digits from HMAC-SHA1 changing every 30 seconds
+const (
+	Digits = 6
+	Period = 30 * time.Second
+	// Skew is how many steps off a code can be, to allow for clock drift and slow typing
+	Skew = 1
+)
+
+// ErrInvalidSecret is returned if a secret isn't valid base32
+var ErrInvalidSecret = errors.E(errors.Invalid, "Invalid two-factor secret")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret generates a random 160 bit secret, base32 encoded as authenticator apps expect
+func NewSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.E(errors.Internal, errors.Op("rand.Read"), err)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// URI is the otpauth URI authenticator apps enroll from, usually shown as a QR code
+func URI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(int(Period/time.Second)))
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// Step is the time step t falls in
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code is the code for secret at t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(sha1.New, key, Step(t), Digits), nil
+}
+
+// Validate checks code against the steps around t, returning the step it matched
+func Validate(secret, code string, t time.Time) (step int64, ok bool) {
+	key, err := decode(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+	now := Step(t)
+	for step := now - Skew; step <= now+Skew; step++ {
+		want := hotp(sha1.New, key, step, Digits)
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func decode(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.Replace(secret, " ", "", -1), "="))
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// hotp is RFC 4226 HOTP, which TOTP is with the time step as the counter
+func hotp(h func() hash.Hash, key []byte, counter int64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(h, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
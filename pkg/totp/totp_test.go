@@ -0,0 +1,91 @@
+package totp
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// Test vectors from RFC 6238 Appendix B
+func TestHOTP_RFC6238(t *testing.T) {
+	c := qt.New(t)
+	keys := []struct {
+		hash func() hash.Hash
+		key  string
+	}{
+		{sha1.New, "12345678901234567890"},
+		{sha256.New, "12345678901234567890123456789012"},
+		{sha512.New, "1234567890123456789012345678901234567890123456789012345678901234"},
+	}
+	vectors := []struct {
+		unix  int64
+		codes [3]string
+	}{
+		{59, [3]string{"94287082", "46119246", "90693936"}},
+		{1111111109, [3]string{"07081804", "68084774", "25091201"}},
+		{1111111111, [3]string{"14050471", "67062674", "99943326"}},
+		{1234567890, [3]string{"89005924", "91819424", "93441116"}},
+		{2000000000, [3]string{"69279037", "90698825", "38618901"}},
+		{20000000000, [3]string{"65353130", "77737706", "47863826"}},
+	}
+	for _, v := range vectors {
+		step := Step(time.Unix(v.unix, 0))
+		for i, k := range keys {
+			c.Assert(hotp(k.hash, []byte(k.key), step, 8), qt.Equals, v.codes[i], qt.Commentf("t=%d key=%d", v.unix, i))
+		}
+	}
+}
+
+func TestCode(t *testing.T) {
+	c := qt.New(t)
+	secret := encoding.EncodeToString([]byte("12345678901234567890"))
+	code, err := Code(secret, time.Unix(59, 0))
+	c.Assert(err, qt.IsNil)
+	// the 6 digit code is the last 6 digits of the 8 digit one
+	c.Assert(code, qt.Equals, "287082")
+
+	// apps show secrets lowercase and in groups
+	spaced := strings.ToLower(secret[:4] + " " + secret[4:])
+	code, err = Code(spaced, time.Unix(59, 0))
+	c.Assert(err, qt.IsNil)
+	c.Assert(code, qt.Equals, "287082")
+
+	_, err = Code("not base32!", time.Unix(59, 0))
+	c.Assert(err, qt.Equals, ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	c := qt.New(t)
+	secret, err := NewSecret()
+	c.Assert(err, qt.IsNil)
+	now := time.Unix(1500000000, 0)
+
+	code, err := Code(secret, now)
+	c.Assert(err, qt.IsNil)
+	step, ok := Validate(secret, code, now)
+	c.Assert(ok, qt.Equals, true)
+	c.Assert(step, qt.Equals, Step(now))
+
+	// a step of drift either way is fine, more isn't
+	_, ok = Validate(secret, code, now.Add(Period))
+	c.Assert(ok, qt.Equals, true)
+	_, ok = Validate(secret, code, now.Add(-Period))
+	c.Assert(ok, qt.Equals, true)
+	_, ok = Validate(secret, code, now.Add(2*Period))
+	c.Assert(ok, qt.Equals, false)
+
+	_, ok = Validate(secret, "12345", now)
+	c.Assert(ok, qt.Equals, false)
+}
+
+func TestURI(t *testing.T) {
+	c := qt.New(t)
+	uri := URI("upboat", "pac@pac.com", "JBSWY3DPEHPK3PXP")
+	c.Assert(uri, qt.Equals, "otpauth://totp/upboat:pac@pac.com?digits=6&issuer=upboat&period=30&secret=JBSWY3DPEHPK3PXP")
+}
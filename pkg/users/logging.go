@@ -47,10 +47,130 @@ func (m *loggingMiddleware) Register(ctx context.Context, user *User, password s
 	return
 }
 
-func (m *loggingMiddleware) Login(ctx context.Context, email string, password string) (u *User, err error) {
-	u, err = m.service.Login(ctx, email, password)
+func (m *loggingMiddleware) Login(ctx context.Context, email string, password string, ip string) (u *User, err error) {
+	u, err = m.service.Login(ctx, email, password, ip)
 	if errors.Is(errors.Internal, err) {
 		m.log.Error("Error from users.Service.Login()", zap.Error(err))
 	}
 	return
 }
+
+func (m *loggingMiddleware) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	err = m.service.RequestPasswordReset(ctx, email)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.RequestPasswordReset()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) ResetPassword(ctx context.Context, token string, password string) (err error) {
+	err = m.service.ResetPassword(ctx, token, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.ResetPassword()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	err = m.service.VerifyEmail(ctx, token)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.VerifyEmail()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) ResendVerification(ctx context.Context, userID int) (err error) {
+	err = m.service.ResendVerification(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.ResendVerification()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) EnsureVerified(ctx context.Context, userID int) (err error) {
+	err = m.service.EnsureVerified(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.EnsureVerified()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) ChangePassword(ctx context.Context, userID int, current string, password string) (epoch int, err error) {
+	epoch, err = m.service.ChangePassword(ctx, userID, current, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.ChangePassword()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) ChangeEmail(ctx context.Context, userID int, password string, email string) (err error) {
+	err = m.service.ChangeEmail(ctx, userID, password, email)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.ChangeEmail()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Deactivate(ctx context.Context, userID int, password string) (err error) {
+	err = m.service.Deactivate(ctx, userID, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.Deactivate()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) CheckSession(ctx context.Context, userID int, epoch int) (err error) {
+	err = m.service.CheckSession(ctx, userID, epoch)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.CheckSession()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) VerifyPassword(ctx context.Context, userID int, password string) (err error) {
+	err = m.service.VerifyPassword(ctx, userID, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.VerifyPassword()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) Unlock(ctx context.Context, token string) (err error) {
+	err = m.service.Unlock(ctx, token)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.Unlock()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) EnrollTwoFactor(ctx context.Context, userID int) (secret string, uri string, err error) {
+	secret, uri, err = m.service.EnrollTwoFactor(ctx, userID)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.EnrollTwoFactor()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) ConfirmTwoFactor(ctx context.Context, userID int, code string) (recovery []string, err error) {
+	recovery, err = m.service.ConfirmTwoFactor(ctx, userID, code)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.ConfirmTwoFactor()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) VerifyTwoFactor(ctx context.Context, userID int, code string, ip string) (err error) {
+	err = m.service.VerifyTwoFactor(ctx, userID, code, ip)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.VerifyTwoFactor()", zap.Error(err))
+	}
+	return
+}
+
+func (m *loggingMiddleware) DisableTwoFactor(ctx context.Context, userID int, password string) (err error) {
+	err = m.service.DisableTwoFactor(ctx, userID, password)
+	if errors.Is(errors.Internal, err) {
+		m.log.Error("Error from users.Service.DisableTwoFactor()", zap.Error(err))
+	}
+	return
+}
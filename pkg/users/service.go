@@ -2,42 +2,480 @@ package users
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/godwhoa/upboat/pkg/errors"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/godwhoa/upboat/pkg/mail"
+	"github.com/godwhoa/upboat/pkg/passwords"
+	"github.com/godwhoa/upboat/pkg/totp"
 )
 
 // Service implements UserService interface
 type service struct {
-	repo Repository
+	repo   Repository
+	mailer mail.Mailer
+	opts   Options
 }
 
 // NewService is a constructor for user.Service
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, mailer mail.Mailer, opts Options) Service {
+	if opts.ResetTTL <= 0 {
+		opts.ResetTTL = DefaultResetTTL
+	}
+	if opts.VerifyTTL <= 0 {
+		opts.VerifyTTL = DefaultVerifyTTL
+	}
+	if opts.Lockout == (Lockout{}) {
+		opts.Lockout = DefaultLockout
+	}
+	if opts.Hasher == nil {
+		opts.Hasher = DefaultHasher
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+	return &service{repo: repo, mailer: mailer, opts: opts}
 }
 
 func (s *service) Register(ctx context.Context, u *User, password string) (*User, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashed, err := s.opts.Hasher.Hash(password)
 	if err != nil {
-		return nil, errors.E(errors.Internal, errors.Op("bcrypt.GenerateFromPassword"), err)
+		return nil, err
 	}
-	u.Hash = string(hashed)
+	u.Hash = hashed
 
 	if err := s.repo.Create(ctx, u); err != nil {
 		return nil, err
 	}
-	return s.repo.FindByEmail(ctx, u.Email)
+	user, err := s.repo.FindByEmail(ctx, u.Email)
+	if err != nil {
+		return nil, err
+	}
+	// the account exists even if this fails, the email can be resent later
+	if err := s.sendVerification(ctx, user); err != nil {
+		s.opts.OnError(err)
+	}
+	return user, nil
 }
 
-func (s *service) Login(ctx context.Context, email string, password string) (*User, error) {
+func (s *service) Login(ctx context.Context, email string, password string, ip string) (*User, error) {
+	ipKey := "ip:" + ip
+	if err := s.locked(ctx, ipKey, s.opts.Lockout.IPThreshold); err != nil {
+		return nil, err
+	}
 	user, err := s.repo.FindByEmail(ctx, email)
+	if err == ErrUserNotFound {
+		// guessing emails counts against the IP too
+		if _, err := s.repo.RecordLoginFailure(ctx, ipKey, s.window()); err != nil {
+			return nil, err
+		}
+		return nil, ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(user.Hash), []byte(password))
-	if err != nil {
+	if err := s.locked(ctx, accountKey(user.ID), s.opts.Lockout.AccountThreshold); err != nil {
+		return nil, err
+	}
+
+	rehash, err := s.opts.Hasher.Verify(user.Hash, password)
+	if err == passwords.ErrMismatch {
+		if err := s.fail(ctx, user, ipKey); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidCredentials
 	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Deactivated {
+		return nil, ErrDeactivated
+	}
+	// the password is at hand only now, so this is where hashes from older settings get upgraded
+	if rehash {
+		hashed, err := s.opts.Hasher.Hash(password)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.Rehash(ctx, user.ID, user.Hash, hashed); err != nil {
+			return nil, err
+		}
+		user.Hash = hashed
+	}
+	// with 2FA the failures are only cleared once the code checks out too
+	if user.TwoFactor {
+		return user, nil
+	}
+	if err := s.repo.ClearLoginFailures(ctx, accountKey(user.ID)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *service) Unlock(ctx context.Context, token string) error {
+	userID, err := s.repo.ConsumeToken(ctx, TokenUnlock, hashToken(token))
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearLoginFailures(ctx, accountKey(userID))
+}
+
+// accountKey is what failed logins of an account are counted under
+func accountKey(userID int) string {
+	return "user:" + strconv.Itoa(userID)
+}
+
+// window is when the failures that still count started
+func (s *service) window() time.Time {
+	return time.Now().Add(-s.opts.Lockout.Window)
+}
+
+// locked returns ErrTooManyAttempts if key failed often and recently enough to be locked out
+func (s *service) locked(ctx context.Context, key string, threshold int) error {
+	failures, last, err := s.repo.LoginFailures(ctx, key)
+	if err != nil {
+		return err
+	}
+	if time.Since(last) < s.opts.Lockout.duration(failures, threshold) {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// fail counts a failed login against the account and IP.
+// Once the account is locked its owner is emailed a way to unlock it, unless an earlier email's link still works.
+func (s *service) fail(ctx context.Context, user *User, ipKey string) error {
+	if _, err := s.repo.RecordLoginFailure(ctx, ipKey, s.window()); err != nil {
+		return err
+	}
+	failures, err := s.repo.RecordLoginFailure(ctx, accountKey(user.ID), s.window())
+	if err != nil {
+		return err
+	}
+	if failures < s.opts.Lockout.AccountThreshold {
+		return nil
+	}
+	outstanding, err := s.repo.HasToken(ctx, user.ID, TokenUnlock)
+	if err != nil || outstanding {
+		return err
+	}
+
+	token, err := s.issue(ctx, user.ID, TokenUnlock, s.opts.Lockout.Window)
+	if err != nil {
+		return err
+	}
+	link := s.opts.BaseURL + "/unlock?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Your upboat account was locked",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone failed to log in to your account %d times in a row, "+
+			"so logging in is blocked for a while. If it was you, unlock it here:\n\n%s\n\n"+
+			"If it wasn't, you may want to change your password.\n",
+			user.Username, failures, link),
+	})
+	if err != nil {
+		// the token nobody got is used up, so the next failure emails a new one
+		s.opts.OnError(err)
+		if _, err := s.repo.ConsumeToken(ctx, TokenUnlock, hashToken(token)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	// looking the email up and sending the token take time only for registered emails,
+	// so it's all done in the background to answer every request just as fast
+	go func() {
+		if err := s.sendPasswordReset(context.Background(), email); err != nil {
+			s.opts.OnError(err)
+		}
+	}()
+	return nil
+}
+
+// sendPasswordReset emails a reset token to the user registered with email, if there's one
+func (s *service) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err == ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issue(ctx, user.ID, TokenPasswordReset, s.opts.ResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.opts.BaseURL + "/reset-password?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Reset your upboat password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your account. "+
+			"If it was you, pick a new one here:\n\n%s\n\n"+
+			"The link expires in %s. If it wasn't you, you can ignore this email.\n",
+			user.Username, link, s.opts.ResetTTL),
+	})
+}
+
+func (s *service) ResetPassword(ctx context.Context, token string, password string) error {
+	userID, err := s.repo.ConsumeToken(ctx, TokenPasswordReset, hashToken(token))
+	if err != nil {
+		return err
+	}
+	hashed, err := s.opts.Hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.SetHash(ctx, userID, hashed)
+	return err
+}
+
+func (s *service) VerifyEmail(ctx context.Context, token string) error {
+	userID, err := s.repo.ConsumeToken(ctx, TokenVerifyEmail, hashToken(token))
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkVerified(ctx, userID)
+}
+
+func (s *service) ResendVerification(ctx context.Context, userID int) error {
+	user, err := s.repo.Find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Verified {
+		return ErrAlreadyVerified
+	}
+	return s.sendVerification(ctx, user)
+}
+
+func (s *service) EnsureVerified(ctx context.Context, userID int) error {
+	if !s.opts.RequireVerified {
+		return nil
+	}
+	user, err := s.repo.Find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.Verified {
+		return ErrUnverified
+	}
+	return nil
+}
+
+func (s *service) ChangePassword(ctx context.Context, userID int, current string, password string) (int, error) {
+	if _, err := s.authenticate(ctx, userID, current); err != nil {
+		return 0, err
+	}
+	hashed, err := s.opts.Hasher.Hash(password)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.SetHash(ctx, userID, hashed)
+}
+
+func (s *service) ChangeEmail(ctx context.Context, userID int, password string, email string) error {
+	user, err := s.authenticate(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+	if user.Email == email {
+		return nil
+	}
+	previous := user.Email
+	if err := s.repo.SetEmail(ctx, userID, email); err != nil {
+		return err
+	}
+	user.Email = email
+
+	// the change is made either way, so emails failing to send are only reported
+	if err := s.sendVerification(ctx, user); err != nil {
+		s.opts.OnError(err)
+	}
+	err = s.mailer.Send(ctx, &mail.Message{
+		To:      previous,
+		Subject: "Your upboat email was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe email of your account was changed from this address to %s. "+
+			"If it wasn't you, someone else knows your password and you should contact us.\n",
+			user.Username, email),
+	})
+	if err != nil {
+		s.opts.OnError(err)
+	}
+	return nil
+}
+
+func (s *service) Deactivate(ctx context.Context, userID int, password string) error {
+	if _, err := s.authenticate(ctx, userID, password); err != nil {
+		return err
+	}
+	return s.repo.Deactivate(ctx, userID)
+}
+
+func (s *service) VerifyPassword(ctx context.Context, userID int, password string) error {
+	_, err := s.authenticate(ctx, userID, password)
+	return err
+}
+
+func (s *service) CheckSession(ctx context.Context, userID int, epoch int) error {
+	user, err := s.repo.Find(ctx, userID)
+	if err == ErrUserNotFound {
+		return ErrSessionExpired
+	}
+	if err != nil {
+		return err
+	}
+	if user.Deactivated || user.Epoch != epoch {
+		return ErrSessionExpired
+	}
+	return nil
+}
+
+func (s *service) EnrollTwoFactor(ctx context.Context, userID int) (string, string, error) {
+	user, err := s.repo.Find(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TwoFactor {
+		return "", "", ErrTwoFactorEnabled
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.repo.SetTwoFactorSecret(ctx, userID, secret); err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(Issuer, user.Email, secret), nil
+}
+
+func (s *service) ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	tf, err := s.repo.TwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	if tf.Secret == "" {
+		return nil, ErrTwoFactorDisabled
+	}
+	step, ok := totp.Validate(tf.Secret, strings.TrimSpace(code), s.opts.Now())
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *service) VerifyTwoFactor(ctx context.Context, userID int, code string, ip string) error {
+	// an IP guessing codes for many pending logins is locked out like one guessing passwords
+	ipKey := "ip:" + ip
+	if err := s.locked(ctx, ipKey, s.opts.Lockout.IPThreshold); err != nil {
+		return err
+	}
+	user, err := s.repo.Find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.locked(ctx, accountKey(user.ID), s.opts.Lockout.AccountThreshold); err != nil {
+		return err
+	}
+	tf, err := s.repo.TwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled {
+		return ErrTwoFactorDisabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(tf.Secret, code, s.opts.Now()); ok {
+		err = s.repo.UseTwoFactorStep(ctx, userID, step)
+	} else {
+		err = s.repo.UseRecoveryCode(ctx, userID, hashToken(normalizeCode(code)))
+	}
+	if err == ErrInvalidCode {
+		if err := s.fail(ctx, user, ipKey); err != nil {
+			return err
+		}
+		return ErrInvalidCode
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearLoginFailures(ctx, accountKey(user.ID))
+}
+
+func (s *service) DisableTwoFactor(ctx context.Context, userID int, password string) error {
+	user, err := s.authenticate(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactor {
+		return ErrTwoFactorDisabled
+	}
+	return s.repo.DisableTwoFactor(ctx, userID)
+}
+
+// authenticate confirms an account change with the user's password
+func (s *service) authenticate(ctx context.Context, userID int, password string) (*User, error) {
+	user, err := s.repo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = passwords.Verify(user.Hash, password)
+	if err == passwords.ErrMismatch {
+		return nil, ErrIncorrectPassword
+	}
+	if err != nil {
+		return nil, err
+	}
 	return user, nil
 }
+
+// issue creates and stores a token for an user, the token is returned to be emailed
+func (s *service) issue(ctx context.Context, userID int, kind TokenKind, ttl time.Duration) (string, error) {
+	token, hash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.repo.CreateToken(ctx, &Token{
+		UserID:  userID,
+		Kind:    kind,
+		Hash:    hash,
+		Expires: time.Now().Add(ttl),
+	})
+	return token, err
+}
+
+func (s *service) sendVerification(ctx context.Context, user *User) error {
+	token, err := s.issue(ctx, user.ID, TokenVerifyEmail, s.opts.VerifyTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.opts.BaseURL + "/verify-email?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Verify your upboat email",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm this is your email by following the link below:\n\n%s\n\n"+
+			"The link expires in %s.\n",
+			user.Username, link, s.opts.VerifyTTL),
+	})
+}
@@ -2,10 +2,15 @@ package users
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/mail"
+	"github.com/godwhoa/upboat/pkg/passwords"
+	"github.com/godwhoa/upboat/pkg/totp"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -16,6 +21,15 @@ type mockRepo struct {
 	findcalled    bool
 	byemailcalled bool
 	u             *User
+	tokens        map[string]*Token
+	failures      map[string]*failure
+	tf            TwoFactor
+	recovery      map[string]bool
+}
+
+type failure struct {
+	count int
+	last  time.Time
 }
 
 func (r *mockRepo) Create(ctx context.Context, user *User) error {
@@ -42,9 +56,144 @@ func (r *mockRepo) FindByEmail(ctx context.Context, email string) (*User, error)
 	return r.u, nil
 }
 
+func (r *mockRepo) MarkVerified(ctx context.Context, userID int) error {
+	r.u.Verified = true
+	return nil
+}
+func (r *mockRepo) SetHash(ctx context.Context, userID int, hash string) (int, error) {
+	r.u.Hash = hash
+	r.u.Epoch++
+	return r.u.Epoch, nil
+}
+func (r *mockRepo) Rehash(ctx context.Context, userID int, old string, hash string) error {
+	if r.u.Hash == old {
+		r.u.Hash = hash
+	}
+	return nil
+}
+func (r *mockRepo) SetEmail(ctx context.Context, userID int, email string) error {
+	r.u.Email = email
+	r.u.Verified = false
+	return nil
+}
+func (r *mockRepo) Deactivate(ctx context.Context, userID int) error {
+	r.u.Deactivated = true
+	r.u.Epoch++
+	return nil
+}
+func (r *mockRepo) CreateToken(ctx context.Context, token *Token) error {
+	if r.tokens == nil {
+		r.tokens = map[string]*Token{}
+	}
+	r.tokens[token.Hash] = token
+	return nil
+}
+func (r *mockRepo) HasToken(ctx context.Context, userID int, kind TokenKind) (bool, error) {
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.Kind == kind && token.Expires.After(time.Now()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (r *mockRepo) ConsumeToken(ctx context.Context, kind TokenKind, hash string) (int, error) {
+	token, ok := r.tokens[hash]
+	if !ok || token.Kind != kind || token.Expires.Before(time.Now()) {
+		return 0, ErrInvalidToken
+	}
+	delete(r.tokens, hash)
+	return token.UserID, nil
+}
+
+func (r *mockRepo) LoginFailures(ctx context.Context, key string) (int, time.Time, error) {
+	f, ok := r.failures[key]
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+	return f.count, f.last, nil
+}
+func (r *mockRepo) RecordLoginFailure(ctx context.Context, key string, since time.Time) (int, error) {
+	if r.failures == nil {
+		r.failures = map[string]*failure{}
+	}
+	f, ok := r.failures[key]
+	if !ok || f.last.Before(since) {
+		f = &failure{}
+		r.failures[key] = f
+	}
+	f.count++
+	f.last = time.Now()
+	return f.count, nil
+}
+func (r *mockRepo) ClearLoginFailures(ctx context.Context, key string) error {
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *mockRepo) TwoFactor(ctx context.Context, userID int) (*TwoFactor, error) {
+	tf := r.tf
+	return &tf, nil
+}
+func (r *mockRepo) SetTwoFactorSecret(ctx context.Context, userID int, secret string) error {
+	if r.tf.Enabled {
+		return ErrTwoFactorEnabled
+	}
+	r.tf.Secret = secret
+	return nil
+}
+func (r *mockRepo) EnableTwoFactor(ctx context.Context, userID int, step int64, hashes []string) error {
+	r.tf.Enabled, r.tf.LastStep = true, step
+	r.u.TwoFactor = true
+	r.recovery = map[string]bool{}
+	for _, hash := range hashes {
+		r.recovery[hash] = true
+	}
+	return nil
+}
+func (r *mockRepo) UseTwoFactorStep(ctx context.Context, userID int, step int64) error {
+	if step <= r.tf.LastStep {
+		return ErrInvalidCode
+	}
+	r.tf.LastStep = step
+	return nil
+}
+func (r *mockRepo) UseRecoveryCode(ctx context.Context, userID int, hash string) error {
+	if !r.recovery[hash] {
+		return ErrInvalidCode
+	}
+	delete(r.recovery, hash)
+	return nil
+}
+func (r *mockRepo) DisableTwoFactor(ctx context.Context, userID int) error {
+	r.tf = TwoFactor{}
+	r.u.TwoFactor = false
+	r.recovery = nil
+	return nil
+}
+
+var errMailDown = errors.E(errors.Internal, "mail server is down")
+
+// failingMailer fails to send anything
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg *mail.Message) error {
+	return errMailDown
+}
+
+// waitMail waits for the n-th email to be sent from the background and returns it
+func waitMail(c *qt.C, mailer *mail.Memory, n int) *mail.Message {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if sent := mailer.Sent(); len(sent) >= n {
+			return sent[n-1]
+		}
+	}
+	c.Fatalf("email %d was never sent", n)
+	return nil
+}
+
 func TestNewService(t *testing.T) {
 	c := qt.New(t)
-	service := NewService(&mockRepo{})
+	service := NewService(&mockRepo{}, mail.NewMemory(), Options{})
 	c.Assert(service, qt.Not(qt.IsNil))
 }
 
@@ -52,7 +201,7 @@ func TestNewService(t *testing.T) {
 func TestService_Register_EnsureHashing(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
-	service := NewService(&mockRepo{})
+	service := NewService(&mockRepo{}, mail.NewMemory(), Options{})
 
 	user, err := service.Register(ctx, &User{Username: "blah", Email: "blah@blah.com"}, "password")
 	c.Assert(user, qt.Not(qt.IsNil))
@@ -70,7 +219,7 @@ func TestService_Register_EnsureCreate(t *testing.T) {
 	ctx := context.Background()
 
 	repo := &mockRepo{}
-	service := NewService(repo)
+	service := NewService(repo, mail.NewMemory(), Options{})
 	service.Register(ctx, &User{Username: "blah", Email: "blah@blah.com"}, "password")
 	c.Assert(repo.createcalled, qt.Equals, true)
 }
@@ -80,7 +229,7 @@ func TestService_Register_AlreadyExists(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
 	repo := &mockRepo{createerr: true}
-	service := NewService(repo)
+	service := NewService(repo, mail.NewMemory(), Options{})
 	user, err := service.Register(ctx, &User{Username: "blah", Email: "blah@blah.com"}, "password")
 	c.Assert(errors.Is(errors.Conflict, err), qt.Equals, true)
 	c.Assert(user, qt.IsNil)
@@ -91,12 +240,23 @@ func TestService_Register_OK(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
 	repo := &mockRepo{}
-	service := NewService(repo)
+	service := NewService(repo, mail.NewMemory(), Options{})
 	user, err := service.Register(ctx, &User{Username: "blah", Email: "blah@blah.com"}, "password")
 	c.Assert(err, qt.IsNil)
 	c.Assert(user, qt.Not(qt.IsNil))
 }
 
+// Registering succeeds even if the verification email can't be sent
+func TestService_Register_MailError(t *testing.T) {
+	c := qt.New(t)
+	var errs []error
+	service := NewService(&mockRepo{}, failingMailer{}, Options{OnError: func(err error) { errs = append(errs, err) }})
+	user, err := service.Register(context.Background(), &User{Username: "blah", Email: "blah@blah.com"}, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Email, qt.Equals, "blah@blah.com")
+	c.Assert(errs, qt.DeepEquals, []error{errMailDown})
+}
+
 func TestService_Login_OK(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -107,8 +267,8 @@ func TestService_Login_OK(t *testing.T) {
 		Email:    "blah@blah.com",
 		Hash:     string(hash),
 	}
-	service := NewService(&mockRepo{u: u})
-	user, err := service.Login(ctx, "blah@blah.com", "password")
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{})
+	user, err := service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
 	c.Assert(err, qt.IsNil)
 	c.Assert(user, qt.Not(qt.IsNil))
 }
@@ -124,8 +284,8 @@ func TestService_Login_NotFound(t *testing.T) {
 		Email:    "blah@blah.com",
 		Hash:     string(hash),
 	}
-	service := NewService(&mockRepo{u: u, finderr: true})
-	user, err := service.Login(ctx, "apple@kak.com", "password")
+	service := NewService(&mockRepo{u: u, finderr: true}, mail.NewMemory(), Options{})
+	user, err := service.Login(ctx, "apple@kak.com", "password", "127.0.0.1")
 	c.Assert(user, qt.IsNil)
 	c.Assert(errors.Is(errors.NotFound, err), qt.Equals, true)
 }
@@ -140,8 +300,395 @@ func TestService_LoginInvalid(t *testing.T) {
 		Email:    "blah@blah.com",
 		Hash:     string(hash),
 	}
-	service := NewService(&mockRepo{u: u})
-	user, err := service.Login(ctx, "blah@blah.com", "passwordddd")
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{})
+	user, err := service.Login(ctx, "blah@blah.com", "passwordddd", "127.0.0.1")
 	c.Assert(user, qt.IsNil)
 	c.Assert(errors.Is(errors.Unauthorized, err), qt.Equals, true)
 }
+
+func TestService_PasswordReset(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{
+		ID:       1,
+		Username: "blah",
+		Email:    "blah@blah.com",
+		Hash:     string(hash),
+	}
+	mailer := mail.NewMemory()
+	service := NewService(&mockRepo{u: u}, mailer, Options{BaseURL: "https://upboat.test"})
+
+	err := service.RequestPasswordReset(ctx, "blah@blah.com")
+	c.Assert(err, qt.IsNil)
+	msg := waitMail(c, mailer, 1)
+	c.Assert(msg.To, qt.Equals, "blah@blah.com")
+
+	token := tokenFrom(c, msg, "https://upboat.test/reset-password?token=")
+
+	err = service.ResetPassword(ctx, token, "newpassword")
+	c.Assert(err, qt.IsNil)
+	_, err = service.Login(ctx, "blah@blah.com", "newpassword", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+
+	// tokens are single-use
+	err = service.ResetPassword(ctx, token, "another")
+	c.Assert(err, qt.Equals, ErrInvalidToken)
+}
+
+// Unknown emails get the same answer as known ones but no mail is sent
+func TestService_RequestPasswordReset_NotFound(t *testing.T) {
+	c := qt.New(t)
+	mailer := mail.NewMemory()
+	errs := make(chan error, 1)
+	service := NewService(&mockRepo{finderr: true}, mailer, Options{OnError: func(err error) { errs <- err }})
+	err := service.RequestPasswordReset(context.Background(), "none@none.com")
+	c.Assert(err, qt.IsNil)
+	time.Sleep(50 * time.Millisecond)
+	c.Assert(mailer.Sent(), qt.HasLen, 0)
+	c.Assert(errs, qt.HasLen, 0)
+}
+
+// Mail failures don't reach the client, they go to OnError
+func TestService_RequestPasswordReset_MailError(t *testing.T) {
+	c := qt.New(t)
+	errs := make(chan error, 1)
+	service := NewService(&mockRepo{u: &User{ID: 1, Email: "blah@blah.com"}}, failingMailer{}, Options{OnError: func(err error) { errs <- err }})
+	err := service.RequestPasswordReset(context.Background(), "blah@blah.com")
+	c.Assert(err, qt.IsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, qt.Equals, errMailDown)
+	case <-time.After(time.Second):
+		c.Fatal("the mail error never reached OnError")
+	}
+}
+
+func TestService_ResetPassword_Invalid(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{}, mail.NewMemory(), Options{})
+	err := service.ResetPassword(context.Background(), "bogus", "password")
+	c.Assert(err, qt.Equals, ErrInvalidToken)
+}
+
+// tokenFrom pulls the token out of the link in an email
+func tokenFrom(c *qt.C, msg *mail.Message, link string) string {
+	c.Assert(msg, qt.Not(qt.IsNil))
+	i := strings.Index(msg.Body, link)
+	c.Assert(i >= 0, qt.Equals, true)
+	return strings.Fields(msg.Body[i+len(link):])[0]
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mailer := mail.NewMemory()
+	service := NewService(&mockRepo{}, mailer, Options{BaseURL: "https://upboat.test", RequireVerified: true})
+
+	user, err := service.Register(ctx, &User{ID: 1, Username: "blah", Email: "blah@blah.com"}, "password")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Verified, qt.Equals, false)
+	c.Assert(service.EnsureVerified(ctx, user.ID), qt.Equals, ErrUnverified)
+
+	token := tokenFrom(c, mailer.Last(), "https://upboat.test/verify-email?token=")
+	// a verification token can't reset a password
+	err = service.ResetPassword(ctx, token, "newpassword")
+	c.Assert(err, qt.Equals, ErrInvalidToken)
+
+	err = service.VerifyEmail(ctx, token)
+	c.Assert(err, qt.IsNil)
+	c.Assert(service.EnsureVerified(ctx, user.ID), qt.IsNil)
+	c.Assert(service.ResendVerification(ctx, user.ID), qt.Equals, ErrAlreadyVerified)
+}
+
+// Without RequireVerified anyone can post and vote
+func TestService_EnsureVerified_NotRequired(t *testing.T) {
+	c := qt.New(t)
+	service := NewService(&mockRepo{u: &User{ID: 1}}, mail.NewMemory(), Options{})
+	c.Assert(service.EnsureVerified(context.Background(), 1), qt.IsNil)
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{})
+	c.Assert(service.CheckSession(ctx, 1, 0), qt.IsNil)
+
+	_, err := service.ChangePassword(ctx, 1, "wrong", "newpassword")
+	c.Assert(err, qt.Equals, ErrIncorrectPassword)
+
+	epoch, err := service.ChangePassword(ctx, 1, "password", "newpassword")
+	c.Assert(err, qt.IsNil)
+	// older sessions are over, the one from the returned epoch isn't
+	c.Assert(service.CheckSession(ctx, 1, 0), qt.Equals, ErrSessionExpired)
+	c.Assert(service.CheckSession(ctx, 1, epoch), qt.IsNil)
+	_, err = service.Login(ctx, "blah@blah.com", "newpassword", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+}
+
+func TestService_ChangeEmail(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash), Verified: true}
+	mailer := mail.NewMemory()
+	service := NewService(&mockRepo{u: u}, mailer, Options{})
+
+	err := service.ChangeEmail(ctx, 1, "wrong", "new@blah.com")
+	c.Assert(err, qt.Equals, ErrIncorrectPassword)
+	c.Assert(mailer.Sent(), qt.HasLen, 0)
+
+	err = service.ChangeEmail(ctx, 1, "password", "new@blah.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Verified, qt.Equals, false)
+	sent := mailer.Sent()
+	c.Assert(sent, qt.HasLen, 2)
+	c.Assert(sent[0].To, qt.Equals, "new@blah.com")
+	c.Assert(sent[1].To, qt.Equals, "blah@blah.com")
+	c.Assert(strings.Contains(sent[1].Body, "new@blah.com"), qt.Equals, true)
+
+	// the change sticks even if no email goes out
+	var errs []error
+	service = NewService(&mockRepo{u: u}, failingMailer{}, Options{OnError: func(err error) { errs = append(errs, err) }})
+	err = service.ChangeEmail(ctx, 1, "password", "newer@blah.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Email, qt.Equals, "newer@blah.com")
+	c.Assert(errs, qt.HasLen, 2)
+}
+
+func TestService_Deactivate(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{})
+
+	err := service.Deactivate(ctx, 1, "wrong")
+	c.Assert(err, qt.Equals, ErrIncorrectPassword)
+	err = service.Deactivate(ctx, 1, "password")
+	c.Assert(err, qt.IsNil)
+
+	_, err = service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.Equals, ErrDeactivated)
+	c.Assert(service.CheckSession(ctx, 1, u.Epoch), qt.Equals, ErrSessionExpired)
+}
+
+func TestService_Login_Lockout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	mailer := mail.NewMemory()
+	service := NewService(&mockRepo{u: u}, mailer, Options{
+		BaseURL: "https://upboat.test",
+		Lockout: Lockout{AccountThreshold: 3, IPThreshold: 100, Base: time.Minute, Max: time.Hour, Window: time.Hour},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Login(ctx, "blah@blah.com", "wrong", "127.0.0.1")
+		c.Assert(err, qt.Equals, ErrInvalidCredentials)
+	}
+	// even the right password is turned away, from any IP
+	_, err := service.Login(ctx, "blah@blah.com", "password", "10.0.0.1")
+	c.Assert(err, qt.Equals, ErrTooManyAttempts)
+	c.Assert(mailer.Sent(), qt.HasLen, 1)
+
+	token := tokenFrom(c, mailer.Last(), "https://upboat.test/unlock?token=")
+	c.Assert(service.Unlock(ctx, token), qt.IsNil)
+	_, err = service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(service.Unlock(ctx, token), qt.Equals, ErrInvalidToken)
+}
+
+// A wrong password is still just that when the unlock email fails, and the email is tried again on the next failure
+func TestService_Login_Lockout_MailError(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	repo := &mockRepo{u: u}
+	var errs []error
+	service := NewService(repo, failingMailer{}, Options{
+		Lockout: Lockout{AccountThreshold: 2, IPThreshold: 100, Base: time.Nanosecond, Max: time.Nanosecond, Window: time.Hour},
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+		_, err := service.Login(ctx, "blah@blah.com", "wrong", "127.0.0.1")
+		c.Assert(err, qt.Equals, ErrInvalidCredentials)
+	}
+	c.Assert(errs, qt.HasLen, 2)
+	has, _ := repo.HasToken(ctx, u.ID, TokenUnlock)
+	c.Assert(has, qt.Equals, false)
+
+	// with the email out, further failures don't send more
+	mailer := mail.NewMemory()
+	service = NewService(repo, mailer, Options{
+		Lockout: Lockout{AccountThreshold: 2, IPThreshold: 100, Base: time.Nanosecond, Max: time.Nanosecond, Window: time.Hour},
+	})
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		_, err := service.Login(ctx, "blah@blah.com", "wrong", "127.0.0.1")
+		c.Assert(err, qt.Equals, ErrInvalidCredentials)
+	}
+	c.Assert(mailer.Sent(), qt.HasLen, 1)
+}
+
+func TestService_Login_IPLockout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{
+		Lockout: Lockout{AccountThreshold: 100, IPThreshold: 2, Base: time.Minute, Max: time.Hour, Window: time.Hour},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := service.Login(ctx, "blah@blah.com", "wrong", "127.0.0.1")
+		c.Assert(err, qt.Equals, ErrInvalidCredentials)
+	}
+	_, err := service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.Equals, ErrTooManyAttempts)
+	// other IPs are unaffected
+	_, err = service.Login(ctx, "blah@blah.com", "password", "10.0.0.1")
+	c.Assert(err, qt.IsNil)
+}
+
+func TestLockout_Duration(t *testing.T) {
+	c := qt.New(t)
+	l := Lockout{Base: time.Minute, Max: 5 * time.Minute}
+	c.Assert(l.duration(2, 3), qt.Equals, time.Duration(0))
+	c.Assert(l.duration(3, 3), qt.Equals, time.Minute)
+	c.Assert(l.duration(4, 3), qt.Equals, 2*time.Minute)
+	c.Assert(l.duration(5, 3), qt.Equals, 4*time.Minute)
+	c.Assert(l.duration(6, 3), qt.Equals, 5*time.Minute)
+}
+
+// fakeClock is a settable Options.Now
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestService_TwoFactor(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	clock := &fakeClock{now: time.Unix(1500000000, 0)}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{Now: clock.Now})
+
+	secret, uri, err := service.EnrollTwoFactor(ctx, 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(uri, "otpauth://totp/upboat:blah@blah.com?"), qt.Equals, true)
+	c.Assert(strings.Contains(uri, "secret="+secret), qt.Equals, true)
+
+	// not enabled until confirmed
+	_, err = service.ConfirmTwoFactor(ctx, 1, "000000")
+	c.Assert(err, qt.Equals, ErrInvalidCode)
+	c.Assert(u.TwoFactor, qt.Equals, false)
+
+	code, _ := totp.Code(secret, clock.now)
+	recovery, err := service.ConfirmTwoFactor(ctx, 1, code)
+	c.Assert(err, qt.IsNil)
+	c.Assert(recovery, qt.HasLen, RecoveryCodes)
+	_, _, err = service.EnrollTwoFactor(ctx, 1)
+	c.Assert(err, qt.Equals, ErrTwoFactorEnabled)
+
+	user, err := service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.TwoFactor, qt.Equals, true)
+
+	// the code used to confirm can't be replayed
+	err = service.VerifyTwoFactor(ctx, 1, code, "127.0.0.1")
+	c.Assert(err, qt.Equals, ErrInvalidCode)
+	clock.now = clock.now.Add(totp.Period)
+	code, _ = totp.Code(secret, clock.now)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, code, "127.0.0.1"), qt.IsNil)
+
+	// recovery codes work once, however they're typed
+	typed := strings.ToUpper(strings.Replace(recovery[0], "-", " ", -1))
+	c.Assert(service.VerifyTwoFactor(ctx, 1, typed, "127.0.0.1"), qt.IsNil)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, recovery[0], "127.0.0.1"), qt.Equals, ErrInvalidCode)
+
+	c.Assert(service.DisableTwoFactor(ctx, 1, "wrong"), qt.Equals, ErrIncorrectPassword)
+	c.Assert(service.DisableTwoFactor(ctx, 1, "password"), qt.IsNil)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, recovery[1], "127.0.0.1"), qt.Equals, ErrTwoFactorDisabled)
+}
+
+// Guessing codes locks the account out like guessing passwords
+func TestService_VerifyTwoFactor_Lockout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com"}
+	clock := &fakeClock{now: time.Unix(1500000000, 0)}
+	repo := &mockRepo{u: u}
+	service := NewService(repo, mail.NewMemory(), Options{
+		Now:     clock.Now,
+		Lockout: Lockout{AccountThreshold: 3, IPThreshold: 100, Base: time.Minute, Max: time.Hour, Window: time.Hour},
+	})
+	secret, _, err := service.EnrollTwoFactor(ctx, 1)
+	c.Assert(err, qt.IsNil)
+	code, _ := totp.Code(secret, clock.now)
+	_, err = service.ConfirmTwoFactor(ctx, 1, code)
+	c.Assert(err, qt.IsNil)
+
+	for i := 0; i < 3; i++ {
+		c.Assert(service.VerifyTwoFactor(ctx, 1, "000000", "127.0.0.1"), qt.Equals, ErrInvalidCode)
+	}
+	clock.now = clock.now.Add(totp.Period)
+	code, _ = totp.Code(secret, clock.now)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, code, "127.0.0.1"), qt.Equals, ErrTooManyAttempts)
+}
+
+func TestService_VerifyTwoFactor_IPLockout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com"}
+	clock := &fakeClock{now: time.Unix(1500000000, 0)}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{
+		Now:     clock.Now,
+		Lockout: Lockout{AccountThreshold: 100, IPThreshold: 3, Base: time.Minute, Max: time.Hour, Window: time.Hour},
+	})
+	secret, _, err := service.EnrollTwoFactor(ctx, 1)
+	c.Assert(err, qt.IsNil)
+	code, _ := totp.Code(secret, clock.now)
+	_, err = service.ConfirmTwoFactor(ctx, 1, code)
+	c.Assert(err, qt.IsNil)
+
+	for i := 0; i < 3; i++ {
+		c.Assert(service.VerifyTwoFactor(ctx, 1, "000000", "127.0.0.1"), qt.Equals, ErrInvalidCode)
+	}
+	clock.now = clock.now.Add(totp.Period)
+	code, _ = totp.Code(secret, clock.now)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, code, "127.0.0.1"), qt.Equals, ErrTooManyAttempts)
+	c.Assert(service.VerifyTwoFactor(ctx, 1, code, "10.0.0.1"), qt.IsNil)
+}
+
+// Logging in replaces hashes from older settings without ending sessions
+func TestService_Login_Rehash(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	u := &User{ID: 1, Username: "blah", Email: "blah@blah.com", Hash: string(hash)}
+	argon := passwords.Argon2id{Memory: 1024, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+	service := NewService(&mockRepo{u: u}, mail.NewMemory(), Options{Hasher: argon})
+
+	_, err := service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(u.Hash, "$argon2id$"), qt.Equals, true)
+	c.Assert(u.Epoch, qt.Equals, 0)
+
+	upgraded := u.Hash
+	_, err = service.Login(ctx, "blah@blah.com", "password", "127.0.0.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Hash, qt.Equals, upgraded)
+	_, err = service.Login(ctx, "blah@blah.com", "wrong", "127.0.0.1")
+	c.Assert(err, qt.Equals, ErrInvalidCredentials)
+}
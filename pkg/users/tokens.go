@@ -0,0 +1,46 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// TokenKind is what a token is for, a token of one kind can't be used for another
+type TokenKind string
+
+// Kinds of tokens
+const (
+	TokenPasswordReset TokenKind = "password_reset"
+	TokenVerifyEmail   TokenKind = "verify_email"
+	TokenUnlock        TokenKind = "unlock"
+)
+
+// Token is a single-use secret sent to a user's email.
+// Only its hash is stored, the token itself only ever exists in the email.
+type Token struct {
+	UserID  int
+	Kind    TokenKind
+	Hash    string
+	Expires time.Time
+}
+
+// newToken generates a random token along with the hash to store
+func newToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", errors.E(errors.Internal, errors.Op("rand.Read"), err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+// hashToken hashes a token for storage, tokens are random so a fast hash is fine
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -22,8 +22,98 @@ func (m *tracingMiddleware) Register(ctx context.Context, user *User, password s
 	return m.service.Register(ctx, user, password)
 }
 
-func (m *tracingMiddleware) Login(ctx context.Context, email string, password string) (*User, error) {
+func (m *tracingMiddleware) Login(ctx context.Context, email string, password string, ip string) (*User, error) {
 	ctx, span := trace.StartSpan(ctx, "users.Service.Login")
 	defer span.End()
-	return m.service.Login(ctx, email, password)
+	return m.service.Login(ctx, email, password, ip)
+}
+
+func (m *tracingMiddleware) RequestPasswordReset(ctx context.Context, email string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.RequestPasswordReset")
+	defer span.End()
+	return m.service.RequestPasswordReset(ctx, email)
+}
+
+func (m *tracingMiddleware) ResetPassword(ctx context.Context, token string, password string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.ResetPassword")
+	defer span.End()
+	return m.service.ResetPassword(ctx, token, password)
+}
+
+func (m *tracingMiddleware) VerifyEmail(ctx context.Context, token string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.VerifyEmail")
+	defer span.End()
+	return m.service.VerifyEmail(ctx, token)
+}
+
+func (m *tracingMiddleware) ResendVerification(ctx context.Context, userID int) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.ResendVerification")
+	defer span.End()
+	return m.service.ResendVerification(ctx, userID)
+}
+
+func (m *tracingMiddleware) EnsureVerified(ctx context.Context, userID int) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.EnsureVerified")
+	defer span.End()
+	return m.service.EnsureVerified(ctx, userID)
+}
+
+func (m *tracingMiddleware) ChangePassword(ctx context.Context, userID int, current string, password string) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "users.Service.ChangePassword")
+	defer span.End()
+	return m.service.ChangePassword(ctx, userID, current, password)
+}
+
+func (m *tracingMiddleware) ChangeEmail(ctx context.Context, userID int, password string, email string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.ChangeEmail")
+	defer span.End()
+	return m.service.ChangeEmail(ctx, userID, password, email)
+}
+
+func (m *tracingMiddleware) Deactivate(ctx context.Context, userID int, password string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.Deactivate")
+	defer span.End()
+	return m.service.Deactivate(ctx, userID, password)
+}
+
+func (m *tracingMiddleware) CheckSession(ctx context.Context, userID int, epoch int) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.CheckSession")
+	defer span.End()
+	return m.service.CheckSession(ctx, userID, epoch)
+}
+
+func (m *tracingMiddleware) VerifyPassword(ctx context.Context, userID int, password string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.VerifyPassword")
+	defer span.End()
+	return m.service.VerifyPassword(ctx, userID, password)
+}
+
+func (m *tracingMiddleware) Unlock(ctx context.Context, token string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.Unlock")
+	defer span.End()
+	return m.service.Unlock(ctx, token)
+}
+
+func (m *tracingMiddleware) EnrollTwoFactor(ctx context.Context, userID int) (string, string, error) {
+	ctx, span := trace.StartSpan(ctx, "users.Service.EnrollTwoFactor")
+	defer span.End()
+	return m.service.EnrollTwoFactor(ctx, userID)
+}
+
+func (m *tracingMiddleware) ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "users.Service.ConfirmTwoFactor")
+	defer span.End()
+	return m.service.ConfirmTwoFactor(ctx, userID, code)
+}
+
+func (m *tracingMiddleware) VerifyTwoFactor(ctx context.Context, userID int, code string, ip string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.VerifyTwoFactor")
+	defer span.End()
+	return m.service.VerifyTwoFactor(ctx, userID, code, ip)
+}
+
+func (m *tracingMiddleware) DisableTwoFactor(ctx context.Context, userID int, password string) error {
+	ctx, span := trace.StartSpan(ctx, "users.Service.DisableTwoFactor")
+	defer span.End()
+	return m.service.DisableTwoFactor(ctx, userID, password)
 }
@@ -0,0 +1,37 @@
+package users
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+
+	"github.com/godwhoa/upboat/pkg/errors"
+)
+
+// Issuer is what authenticator apps list upboat accounts under
+const Issuer = "upboat"
+
+// RecoveryCodes is how many recovery codes enabling 2FA gives out
+const RecoveryCodes = 10
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes generates recovery codes along with the hashes to store.
+// Codes are 80 random bits in groups of four so they're easy to copy down.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < RecoveryCodes; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.E(errors.Internal, errors.Op("rand.Read"), err)
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))
+		codes = append(codes, raw[0:4]+"-"+raw[4:8]+"-"+raw[8:12]+"-"+raw[12:16])
+		hashes = append(hashes, hashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeCode drops what people add when typing a code back in
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
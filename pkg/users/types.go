@@ -2,8 +2,11 @@ package users
 
 import (
 	"context"
+	"time"
 
 	"github.com/godwhoa/upboat/pkg/errors"
+	"github.com/godwhoa/upboat/pkg/passwords"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -13,14 +16,118 @@ var (
 	ErrInvalidCredentials = errors.E(errors.Unauthorized, "Invalid login credentials")
 	// ErrUserNotFound is returned if user in not found in the database
 	ErrUserNotFound = errors.E(errors.NotFound, "User not found")
+	// ErrInvalidToken is returned if a token doesn't exist, expired or was already used
+	ErrInvalidToken = errors.E(errors.Invalid, "Invalid or expired token")
+	// ErrUnverified is returned if an user needs to verify their email before doing something
+	ErrUnverified = errors.E(errors.Unauthorized, "Email needs to be verified first")
+	// ErrAlreadyVerified is returned on asking for another verification email when the email is already verified
+	ErrAlreadyVerified = errors.E(errors.Conflict, "Email is already verified")
+	// ErrEmailTaken is returned on changing to an email another user has
+	ErrEmailTaken = errors.E(errors.Conflict, "Email is already in use")
+	// ErrIncorrectPassword is returned if the current password given to confirm an account change is wrong
+	ErrIncorrectPassword = errors.E(errors.Invalid, "Incorrect password")
+	// ErrDeactivated is returned on logging in to a deactivated account
+	ErrDeactivated = errors.E(errors.Unauthorized, "Account is deactivated")
+	// ErrSessionExpired is returned if a session was ended by a password change or deactivation
+	ErrSessionExpired = errors.E(errors.Unauthorized, "Session expired")
+	// ErrTooManyAttempts is returned on logging in while an account or IP is locked out for failing too often
+	ErrTooManyAttempts = errors.E(errors.TooManyRequests, "Too many failed logins, try again later")
+	// ErrInvalidCode is returned if a two-factor or recovery code is wrong or was already used
+	ErrInvalidCode = errors.E(errors.Unauthorized, "Invalid two-factor code")
+	// ErrTwoFactorEnabled is returned on enrolling in two-factor authentication when it's already on
+	ErrTwoFactorEnabled = errors.E(errors.Conflict, "Two-factor authentication is already enabled")
+	// ErrTwoFactorDisabled is returned if two-factor authentication needs to be set up or enabled first
+	ErrTwoFactorDisabled = errors.E(errors.Invalid, "Two-factor authentication isn't enabled")
 )
 
-// User models an user
+// DefaultHasher is bcrypt at its default cost, which is what passwords were always hashed with
+var DefaultHasher passwords.Hasher = passwords.Bcrypt{Cost: bcrypt.DefaultCost}
+
+// How long tokens are valid for unless configured otherwise
+const (
+	DefaultResetTTL  = time.Hour
+	DefaultVerifyTTL = 48 * time.Hour
+)
+
+// Lockout decides when failed logins lock an account or IP out.
+// Once failures reach a threshold, each failure locks out for twice as long as the last, from Base up to Max.
+// Failures are forgotten once Window passes without one.
+type Lockout struct {
+	AccountThreshold int
+	IPThreshold      int
+	Base             time.Duration
+	Max              time.Duration
+	Window           time.Duration
+}
+
+// DefaultLockout is used unless configured otherwise
+var DefaultLockout = Lockout{
+	AccountThreshold: 5,
+	IPThreshold:      20,
+	Base:             time.Minute,
+	Max:              time.Hour,
+	Window:           24 * time.Hour,
+}
+
+// duration is how long failures lock out for, 0 while below threshold
+func (l Lockout) duration(failures, threshold int) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+	d := l.Base
+	for i := threshold; i < failures && d < l.Max; i++ {
+		d *= 2
+	}
+	if d > l.Max {
+		d = l.Max
+	}
+	return d
+}
+
+// Options configures the account emails Service sends
+type Options struct {
+	// BaseURL is where the frontend is served, links in emails point to it
+	BaseURL string
+	// ResetTTL is how long password reset tokens are valid for
+	ResetTTL time.Duration
+	// VerifyTTL is how long email verification tokens are valid for
+	VerifyTTL time.Duration
+	// RequireVerified stops users with an unverified email from posting and voting,
+	// they can still log in either way.
+	RequireVerified bool
+	// Lockout is DefaultLockout if left zero
+	Lockout Lockout
+	// Hasher hashes new passwords, it's DefaultHasher if left nil.
+	// Hashes from other algorithms or settings still verify and are replaced on the next login.
+	Hasher passwords.Hasher
+	// Now is time.Now if left nil, two-factor codes are checked against it
+	Now func() time.Time
+	// OnError is handed the errors that aren't the caller's to deal with, like a password reset email
+	// failing to send in the background. They're dropped if it's left nil.
+	OnError func(err error)
+}
+
+// User models an user.
+// Epoch goes up whenever sessions of the user need to be ended, sessions from an older epoch are rejected.
 type User struct {
-	ID       int
-	Email    string
-	Username string
-	Hash     string
+	ID          int
+	Email       string
+	Username    string
+	Hash        string
+	Verified    bool
+	Deactivated bool
+	Epoch       int
+	// TwoFactor is whether logging in takes a two-factor code after the password
+	TwoFactor bool
+}
+
+// TwoFactor is an user's TOTP setup.
+// Secret is set on enrolling and Enabled once a first code confirms it.
+// LastStep is the time step of the last code used, codes from it or before can't be used again.
+type TwoFactor struct {
+	Secret   string
+	Enabled  bool
+	LastStep int64
 }
 
 // Repository handles storing/retrieving an user
@@ -31,10 +138,86 @@ type Repository interface {
 	Find(ctx context.Context, id int) (*User, error)
 	// FindByEmail finds an user by email, returns ErrUserNotFound if no user is found
 	FindByEmail(ctx context.Context, email string) (*User, error)
+	// MarkVerified marks the email of an user as verified
+	MarkVerified(ctx context.Context, userID int) error
+	// SetHash replaces the password hash of an user, ends their sessions and revokes their API tokens, returns the new epoch
+	SetHash(ctx context.Context, userID int, hash string) (epoch int, err error)
+	// Rehash replaces the password hash without ending sessions, if it's still old.
+	// It's for upgrading a hash of the same password.
+	Rehash(ctx context.Context, userID int, old string, hash string) error
+	// SetEmail replaces the email of an user, marks it unverified and revokes pending verification tokens.
+	// Returns ErrEmailTaken if another user has the email.
+	SetEmail(ctx context.Context, userID int, email string) error
+	// Deactivate marks an user deleted and ends their sessions
+	Deactivate(ctx context.Context, userID int) error
+	// LoginFailures returns how many times logging in failed for key and when it last did
+	LoginFailures(ctx context.Context, key string) (failures int, last time.Time, err error)
+	// RecordLoginFailure counts a failed login for key, starting over if the last one was before since
+	RecordLoginFailure(ctx context.Context, key string, since time.Time) (failures int, err error)
+	// ClearLoginFailures forgets the failed logins of key
+	ClearLoginFailures(ctx context.Context, key string) error
+	// TwoFactor returns the TOTP setup of an user
+	TwoFactor(ctx context.Context, userID int) (*TwoFactor, error)
+	// SetTwoFactorSecret stores a secret pending confirmation, returns ErrTwoFactorEnabled if 2FA is already on
+	SetTwoFactorSecret(ctx context.Context, userID int, secret string) error
+	// EnableTwoFactor turns 2FA on with step as the last used one and replaces the recovery codes with hashes
+	EnableTwoFactor(ctx context.Context, userID int, step int64, hashes []string) error
+	// UseTwoFactorStep records step as used, returns ErrInvalidCode if it or a later one already was
+	UseTwoFactorStep(ctx context.Context, userID int, step int64) error
+	// UseRecoveryCode marks a recovery code used, returns ErrInvalidCode if the user has no unused one with hash
+	UseRecoveryCode(ctx context.Context, userID int, hash string) error
+	// DisableTwoFactor turns 2FA off, dropping the secret and recovery codes
+	DisableTwoFactor(ctx context.Context, userID int) error
+	// CreateToken stores a token
+	CreateToken(ctx context.Context, token *Token) error
+	// HasToken tells if an user has a token of kind that's neither used nor expired
+	HasToken(ctx context.Context, userID int, kind TokenKind) (bool, error)
+	// ConsumeToken marks a token used, along with the user's other tokens of the same kind.
+	// Returns ErrInvalidToken if the token doesn't exist, expired or was already used.
+	ConsumeToken(ctx context.Context, kind TokenKind, hash string) (userID int, err error)
 }
 
 // Service handles creation and authentication of a user
 type Service interface {
 	Register(ctx context.Context, user *User, password string) (*User, error)
-	Login(ctx context.Context, email string, password string) (*User, error)
+	// Login checks an user's credentials, ip is where the attempt came from.
+	// Failed attempts are counted per account and per IP, returns ErrTooManyAttempts while either is locked out.
+	Login(ctx context.Context, email string, password string, ip string) (*User, error)
+	// Unlock lifts an account's lockout using the token emailed when it was locked
+	Unlock(ctx context.Context, token string) error
+	// RequestPasswordReset emails a reset token if an user is registered with the email.
+	// It doesn't tell whether one is, so it can't be used to find out who has an account:
+	// the email is looked up and sent in the background, with any errors going to Options.OnError.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password using a token from RequestPasswordReset
+	ResetPassword(ctx context.Context, token string, password string) error
+	// VerifyEmail marks an email verified using the token emailed on registration
+	VerifyEmail(ctx context.Context, token string) error
+	// ResendVerification emails a new verification token
+	ResendVerification(ctx context.Context, userID int) error
+	// EnsureVerified returns ErrUnverified if verification is required and the user hasn't verified yet
+	EnsureVerified(ctx context.Context, userID int) error
+	// ChangePassword replaces the password after checking the current one.
+	// It ends all other sessions, the returned epoch keeps the current one going.
+	ChangePassword(ctx context.Context, userID int, current string, password string) (epoch int, err error)
+	// ChangeEmail replaces the email after checking the password, the new email has to be verified again.
+	// The previous email is told about the change.
+	ChangeEmail(ctx context.Context, userID int, password string, email string) error
+	// Deactivate disables an account after checking the password, it can't be logged in to afterwards
+	Deactivate(ctx context.Context, userID int, password string) error
+	// VerifyPassword returns ErrIncorrectPassword if password isn't the user's
+	VerifyPassword(ctx context.Context, userID int, password string) error
+	// EnrollTwoFactor starts setting up 2FA, returning a new secret and the otpauth URI authenticator apps take.
+	// It's only enabled once ConfirmTwoFactor gets a code made from it.
+	EnrollTwoFactor(ctx context.Context, userID int) (secret string, uri string, err error)
+	// ConfirmTwoFactor enables 2FA with a first code from the authenticator, returning recovery codes.
+	// Only their hashes are stored, so this is the only time they can be shown.
+	ConfirmTwoFactor(ctx context.Context, userID int, code string) (recovery []string, err error)
+	// VerifyTwoFactor is the second login step of users with 2FA, code is from the authenticator or a recovery code.
+	// Wrong codes count towards the lockout like wrong passwords.
+	VerifyTwoFactor(ctx context.Context, userID int, code string, ip string) error
+	// DisableTwoFactor turns 2FA off after checking the password
+	DisableTwoFactor(ctx context.Context, userID int, password string) error
+	// CheckSession returns ErrSessionExpired if a session from the given epoch is no longer valid
+	CheckSession(ctx context.Context, userID int, epoch int) error
 }